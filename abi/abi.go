@@ -4,13 +4,34 @@
 package abi
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strings"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/set"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported type")
+
+	addressType     = reflect.TypeOf(codec.Address{})
+	idType          = reflect.TypeOf(ids.ID{})
+	bigIntType      = reflect.TypeOf(big.Int{})
+	codecBigIntType = reflect.TypeOf(codec.BigInt{})
+
+	// mapKeyTypes are the types that can be used as JSON object keys
+	mapKeyTypes = set.Of(
+		"string", "Address", "ID",
+		"uint8", "uint16", "uint32", "uint64",
+		"int8", "int16", "int32", "int64",
+	)
 )
 
 type ABI struct {
@@ -25,6 +46,23 @@ func (ABI) GetTypeID() uint8 {
 	return 0
 }
 
+// Hash returns the sha256 hash of the binary encoding of the ABI. Two nodes
+// that report the same hash encode and decode actions and outputs the same way.
+func (a ABI) Hash() (ids.ID, error) {
+	size, err := codec.LinearCodec.Size(a)
+	if err != nil {
+		return ids.Empty, err
+	}
+	p := codec.NewWriter(size, consts.NetworkSizeLimit)
+	if err := codec.LinearCodec.MarshalInto(a, p.Packer); err != nil {
+		return ids.Empty, err
+	}
+	if err := p.Err(); err != nil {
+		return ids.Empty, err
+	}
+	return hashing.ComputeHash256Array(p.Bytes()), nil
+}
+
 type Field struct {
 	Name string `serialize:"true" json:"name"`
 	Type string `serialize:"true" json:"type"`
@@ -80,7 +118,7 @@ func NewABI(actions []codec.Typed, returnTypes []codec.Typed) (ABI, error) {
 
 // describeTypedStruct generates the TypedStruct and Types for a single typed struct (action or output).
 // It handles both struct and pointer types, and recursively processes nested structs.
-// Does not support maps or interfaces - only standard go types, slices, arrays and structs
+// Supports standard go types, slices, arrays, maps, pointers and structs but not interfaces
 
 func describeTypedStruct(typedStruct codec.Typed, typesAlreadyProcessed set.Set[reflect.Type]) (TypedStruct, []Type, error) {
 	t := reflect.TypeOf(typedStruct)
@@ -166,30 +204,11 @@ func describeStruct(t reflect.Type) ([]Field, []reflect.Type, error) {
 			fields = append(fields, embeddedFields...)
 			otherStructsSeen = append(otherStructsSeen, moreTypes...)
 		} else {
-			arrayPrefix := ""
-
-			// Here we assume that all types without a name are slices or arrays.
-			// We completely ignore the fact that maps exist as we don't support them.
-			// Types like `type Address = [33]byte` are arrays technically, but they have a name
-			// and we need them to be named types instead of slices.
-			for fieldType.Name() == "" {
-				if fieldType.Kind() == reflect.Array {
-					arrayPrefix += fmt.Sprintf("[%d]", fieldType.Len())
-					fieldType = fieldType.Elem()
-				} else {
-					arrayPrefix += "[]"
-					fieldType = fieldType.Elem()
-				}
-			}
-
-			typeName := arrayPrefix + fieldType.Name()
-
-			// Add nested structs and pointers to structs to the list for processing
-			if fieldType.Kind() == reflect.Struct {
-				otherStructsSeen = append(otherStructsSeen, fieldType)
-			} else if fieldType.Kind() == reflect.Ptr {
-				otherStructsSeen = append(otherStructsSeen, fieldType.Elem())
+			typeName, moreTypes, err := describeType(fieldType)
+			if err != nil {
+				return nil, nil, fmt.Errorf("field %s of %s: %w", field.Name, t, err)
 			}
+			otherStructsSeen = append(otherStructsSeen, moreTypes...)
 
 			fields = append(fields, Field{
 				Name: fieldName,
@@ -201,6 +220,57 @@ func describeStruct(t reflect.Type) ([]Field, []reflect.Type, error) {
 	return fields, otherStructsSeen, nil
 }
 
+// describeType returns the ABI name of [t] and the structs it references
+func describeType(t reflect.Type) (string, []reflect.Type, error) {
+	switch t {
+	case addressType:
+		return "Address", nil, nil
+	case idType:
+		return "ID", nil, nil
+	case codecBigIntType:
+		return "BigInt", nil, nil
+	case bigIntType:
+		// LinearCodec cannot serialize big.Int
+		return "", nil, fmt.Errorf("%w: %s, use codec.BigInt instead", ErrUnsupportedType, t)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Named types such as `type Amount uint64` are described by their kind
+		return t.Kind().String(), nil, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return "", nil, fmt.Errorf("%w: anonymous struct", ErrUnsupportedType)
+		}
+		return t.Name(), []reflect.Type{t}, nil
+	case reflect.Ptr:
+		// Pointers are serialized as the value they point to, like LinearCodec,
+		// which rejects nil pointers
+		elem, structs, err := describeType(t.Elem())
+		return "*" + elem, structs, err
+	case reflect.Slice:
+		elem, structs, err := describeType(t.Elem())
+		return "[]" + elem, structs, err
+	case reflect.Array:
+		elem, structs, err := describeType(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), structs, err
+	case reflect.Map:
+		key, _, err := describeType(t.Key())
+		if err != nil {
+			return "", nil, err
+		}
+		if !mapKeyTypes.Contains(key) {
+			return "", nil, fmt.Errorf("%w: map key %s", ErrUnsupportedType, key)
+		}
+		value, structs, err := describeType(t.Elem())
+		return fmt.Sprintf("map[%s]%s", key, value), structs, err
+	default:
+		return "", nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
+
 func (a *ABI) FindOutputByID(id uint8) (TypedStruct, bool) {
 	for _, output := range a.Outputs {
 		if output.ID == id {
@@ -5,6 +5,7 @@ package abi
 
 import (
 	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,6 +28,10 @@ func TestNewABI(t *testing.T) {
 		ActionWithOutput{},
 		FixedBytes{},
 		Bools{},
+		MockObjectMaps{},
+		MockObjectPointers{},
+		MockObjectIDs{},
+		MockObjectBigInts{},
 	}, []codec.Typed{
 		ActionOutput{},
 	})
@@ -38,6 +43,44 @@ func TestNewABI(t *testing.T) {
 	require.Equal(expectedABI, actualABI)
 }
 
+type unsupportedBigInt struct {
+	Amount big.Int `serialize:"true" json:"amount"`
+}
+
+func (unsupportedBigInt) GetTypeID() uint8 {
+	return 0
+}
+
+type unsupportedBigIntPointer struct {
+	Amount *big.Int `serialize:"true" json:"amount"`
+}
+
+func (unsupportedBigIntPointer) GetTypeID() uint8 {
+	return 0
+}
+
+func TestNewABIUnsupportedTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		typed codec.Typed
+	}{
+		{
+			name:  "big.Int",
+			typed: unsupportedBigInt{},
+		},
+		{
+			name:  "*big.Int",
+			typed: unsupportedBigIntPointer{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewABI([]codec.Typed{tt.typed}, []codec.Typed{})
+			require.ErrorIs(t, err, ErrUnsupportedType)
+		})
+	}
+}
+
 func TestGetABIofABI(t *testing.T) {
 	require := require.New(t)
 
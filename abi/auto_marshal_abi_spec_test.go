@@ -37,6 +37,10 @@ func TestABIHash(t *testing.T) {
 	abiHash := sha256.Sum256(abiBytes)
 	expectedHashHex := strings.TrimSpace(string(mustReadFile(t, "testdata/abi.hash.hex")))
 	require.Equal(expectedHashHex, hex.EncodeToString(abiHash[:]))
+
+	hash, err := abiFromFile.Hash()
+	require.NoError(err)
+	require.Equal(expectedHashHex, hex.EncodeToString(hash[:]))
 }
 
 // Used to verify implementation in other languages, relies on testdata dir
@@ -61,6 +65,10 @@ func TestMarshalSpecs(t *testing.T) {
 		{"outer", &Outer{}},
 		{"fixedBytes", &FixedBytes{}},
 		{"bools", &Bools{}},
+		{"maps", &MockObjectMaps{}},
+		{"pointers", &MockObjectPointers{}},
+		{"ids", &MockObjectIDs{}},
+		{"bigInts", &MockObjectBigInts{}},
 	}
 
 	for _, tc := range testCases {
@@ -4,8 +4,11 @@
 package abi
 
 import (
+	"errors"
 	"fmt"
 	"go/format"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -15,10 +18,8 @@ import (
 func GenerateGoStructs(abi ABI, packageName string) (string, error) {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("package %s\n\n", packageName))
-	sb.WriteString("import \"github.com/ava-labs/hypersdk/codec\"\n\n")
-
 	processed := set.Set[string]{}
+	usesIDs := false
 
 	for _, typ := range abi.Types {
 		if processed.Contains(typ.Name) {
@@ -30,9 +31,15 @@ func GenerateGoStructs(abi ABI, packageName string) (string, error) {
 		for _, field := range typ.Fields {
 			fieldNameUpperCase := strings.ToUpper(field.Name[0:1]) + field.Name[1:]
 
+			parsed, err := parseFieldType(field.Type)
+			if err != nil {
+				return "", fmt.Errorf("field %s of %s: %w", field.Name, typ.Name, err)
+			}
+			usesIDs = usesIDs || slices.Contains(parsed.named(), "ID")
+
 			// If the first character is uppercase, use the default JSON tag.
 			// Otherwise, specify the exported field (upper case) and the lowercase version as the JSON key.
-			goType := convertToGoType(field.Type)
+			goType := convertToGoType(parsed)
 			if unicode.IsUpper(rune(field.Name[0])) {
 				sb.WriteString(fmt.Sprintf("\t%s %s `serialize:\"true\"`\n", fieldNameUpperCase, goType))
 			} else {
@@ -54,7 +61,18 @@ func GenerateGoStructs(abi ABI, packageName string) (string, error) {
 		sb.WriteString("}\n\n")
 	}
 
-	formatted, err := format.Source([]byte(sb.String()))
+	var header strings.Builder
+	header.WriteString(fmt.Sprintf("package %s\n\n", packageName))
+	if usesIDs {
+		header.WriteString("import (\n")
+		header.WriteString("\t\"github.com/ava-labs/avalanchego/ids\"\n\n")
+		header.WriteString("\t\"github.com/ava-labs/hypersdk/codec\"\n")
+		header.WriteString(")\n\n")
+	} else {
+		header.WriteString("import \"github.com/ava-labs/hypersdk/codec\"\n\n")
+	}
+
+	formatted, err := format.Source([]byte(header.String() + sb.String()))
 	if err != nil {
 		return "", fmt.Errorf("failed to format generated code: %w", err)
 	}
@@ -62,18 +80,166 @@ func GenerateGoStructs(abi ABI, packageName string) (string, error) {
 	return string(formatted), nil
 }
 
-func convertToGoType(abiType string) string {
-	switch abiType {
-	case "string":
-		return "string"
-	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
-		return abiType
+func convertToGoType(f *fieldType) string {
+	switch f.kind {
+	case sliceKind:
+		return "[]" + convertToGoType(f.elem)
+	case arrayKind:
+		return fmt.Sprintf("[%d]%s", f.len, convertToGoType(f.elem))
+	case pointerKind:
+		return "*" + convertToGoType(f.elem)
+	case mapKind:
+		return fmt.Sprintf("map[%s]%s", convertToGoType(f.key), convertToGoType(f.elem))
+	}
+	switch f.name {
 	case "Address":
 		return "codec.Address"
+	case "ID":
+		return "ids.ID"
+	case "BigInt":
+		// LinearCodec cannot serialize *big.Int
+		return "codec.BigInt"
+	default:
+		return f.name // For primitives and custom types, we'll use the type name as-is
+	}
+}
+
+var errInvalidType = errors.New("invalid ABI type")
+
+type fieldKind int
+
+const (
+	namedKind fieldKind = iota
+	sliceKind
+	arrayKind
+	pointerKind
+	mapKind
+)
+
+// fieldType is a parsed ABI field type. Named types are identified by their
+// [name] and every other kind of type has an [elem].
+type fieldType struct {
+	kind fieldKind
+	name string
+	// key is the key type of a map
+	key  *fieldType
+	elem *fieldType
+	// len is the length of an array
+	len int
+}
+
+func parseFieldType(abiType string) (*fieldType, error) {
+	switch {
+	case strings.HasPrefix(abiType, "[]"):
+		elem, err := parseFieldType(abiType[2:])
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: sliceKind, elem: elem}, nil
+	case strings.HasPrefix(abiType, "["):
+		end := strings.Index(abiType, "]")
+		if end == -1 {
+			return nil, fmt.Errorf("%w: %q", errInvalidType, abiType)
+		}
+		length, err := strconv.Atoi(abiType[1:end])
+		if err != nil || length < 0 {
+			return nil, fmt.Errorf("%w: %q", errInvalidType, abiType)
+		}
+		elem, err := parseFieldType(abiType[end+1:])
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: arrayKind, elem: elem, len: length}, nil
+	case strings.HasPrefix(abiType, "*"):
+		elem, err := parseFieldType(abiType[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: pointerKind, elem: elem}, nil
+	case strings.HasPrefix(abiType, "map["):
+		// map keys are always named types
+		end := strings.Index(abiType, "]")
+		if end == -1 || !mapKeyTypes.Contains(abiType[4:end]) {
+			return nil, fmt.Errorf("%w: %q", errInvalidType, abiType)
+		}
+		elem, err := parseFieldType(abiType[end+1:])
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: mapKind, key: &fieldType{name: abiType[4:end]}, elem: elem}, nil
+	case abiType == "" || strings.ContainsAny(abiType, "[]*"):
+		return nil, fmt.Errorf("%w: %q", errInvalidType, abiType)
 	default:
-		if strings.HasPrefix(abiType, "[]") {
-			return "[]" + convertToGoType(strings.TrimPrefix(abiType, "[]"))
+		return &fieldType{name: abiType}, nil
+	}
+}
+
+// isBytes returns true if [f] is a slice or an array of bytes
+func (f *fieldType) isBytes() bool {
+	return (f.kind == sliceKind || f.kind == arrayKind) && f.elem.name == "uint8" && f.elem.kind == namedKind
+}
+
+// named returns the named types referenced by [f]
+func (f *fieldType) named() []string {
+	switch f.kind {
+	case namedKind:
+		return []string{f.name}
+	case mapKind:
+		return append(f.key.named(), f.elem.named()...)
+	default:
+		return f.elem.named()
+	}
+}
+
+// checkFieldTypes verifies that every field of [abi] is a primitive or a type
+// declared in [abi]
+func checkFieldTypes(abi ABI, isPrimitive func(string) bool) error {
+	declared := set.Set[string]{}
+	for _, typ := range abi.Types {
+		declared.Add(typ.Name)
+	}
+	for _, typ := range abi.Types {
+		for _, field := range typ.Fields {
+			parsed, err := parseFieldType(field.Type)
+			if err != nil {
+				return fmt.Errorf("field %s of %s: %w", field.Name, typ.Name, err)
+			}
+			for _, name := range parsed.named() {
+				if !isPrimitive(name) && !declared.Contains(name) {
+					return fmt.Errorf("field %s of %s: %w: unknown type %q", field.Name, typ.Name, errInvalidType, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueTypes returns the types of [abi] without duplicates
+func uniqueTypes(abi ABI) []Type {
+	processed := set.Set[string]{}
+	types := make([]Type, 0, len(abi.Types))
+	for _, typ := range abi.Types {
+		if processed.Contains(typ.Name) {
+			continue
+		}
+		processed.Add(typ.Name)
+		types = append(types, typ)
+	}
+	return types
+}
+
+// splitWords splits a camelCase or PascalCase identifier into lowercase words
+func splitWords(name string) []string {
+	runes := []rune(name)
+	words := []string{}
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(runes[i]) && (prevLower || (unicode.IsUpper(runes[i-1]) && nextLower)) {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+			start = i
 		}
-		return abiType // For custom types, we'll use the type name as-is
 	}
+	return append(words, strings.ToLower(string(runes[start:])))
 }
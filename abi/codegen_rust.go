@@ -0,0 +1,327 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// rustRuntime is emitted at the top of every generated Rust file. Encode and
+// Decode follow the layout of codec.Packer.
+const rustRuntime = `// Code generated by abigen. DO NOT EDIT.
+
+#![allow(dead_code)]
+
+use std::collections::BTreeMap;
+
+pub const ADDRESS_LEN: usize = 33;
+pub const ID_LEN: usize = 32;
+
+pub type Address = [u8; ADDRESS_LEN];
+pub type Id = [u8; ID_LEN];
+
+/// An arbitrary precision integer stored as its sign and the big-endian bytes
+/// of its absolute value
+#[derive(Debug, Clone, Default, PartialEq, Eq)]
+pub struct BigInt {
+    pub negative: bool,
+    pub abs: Vec<u8>,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum DecodeError {
+    UnexpectedEnd,
+    InvalidBool(u8),
+    InvalidString,
+    UnsortedMap,
+    TrailingBytes(usize),
+}
+
+pub trait Encode {
+    fn encode(&self, out: &mut Vec<u8>);
+}
+
+pub trait Decode: Sized {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError>;
+}
+
+pub fn to_bytes<T: Encode>(value: &T) -> Vec<u8> {
+    let mut out = Vec::new();
+    value.encode(&mut out);
+    out
+}
+
+pub fn from_bytes<T: Decode>(mut bytes: &[u8]) -> Result<T, DecodeError> {
+    let value = T::decode(&mut bytes)?;
+    if !bytes.is_empty() {
+        return Err(DecodeError::TrailingBytes(bytes.len()));
+    }
+    Ok(value)
+}
+
+fn take<'a>(input: &mut &'a [u8], len: usize) -> Result<&'a [u8], DecodeError> {
+    if input.len() < len {
+        return Err(DecodeError::UnexpectedEnd);
+    }
+    let (head, tail) = input.split_at(len);
+    *input = tail;
+    Ok(head)
+}
+
+macro_rules! impl_int {
+    ($($t:ty),*) => {$(
+        impl Encode for $t {
+            fn encode(&self, out: &mut Vec<u8>) {
+                out.extend_from_slice(&self.to_be_bytes());
+            }
+        }
+
+        impl Decode for $t {
+            fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+                let bytes = take(input, core::mem::size_of::<$t>())?;
+                Ok(<$t>::from_be_bytes(bytes.try_into().expect("length was checked")))
+            }
+        }
+    )*};
+}
+
+impl_int!(u8, u16, u32, u64, i8, i16, i32, i64);
+
+impl Encode for bool {
+    fn encode(&self, out: &mut Vec<u8>) {
+        out.push(u8::from(*self));
+    }
+}
+
+impl Decode for bool {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        match u8::decode(input)? {
+            0 => Ok(false),
+            1 => Ok(true),
+            v => Err(DecodeError::InvalidBool(v)),
+        }
+    }
+}
+
+impl Encode for String {
+    fn encode(&self, out: &mut Vec<u8>) {
+        u16::try_from(self.len())
+            .expect("string is too long")
+            .encode(out);
+        out.extend_from_slice(self.as_bytes());
+    }
+}
+
+impl Decode for String {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let len = u16::decode(input)?;
+        let bytes = take(input, usize::from(len))?;
+        String::from_utf8(bytes.to_vec()).map_err(|_| DecodeError::InvalidString)
+    }
+}
+
+impl<T: Encode> Encode for Vec<T> {
+    fn encode(&self, out: &mut Vec<u8>) {
+        u32::try_from(self.len())
+            .expect("slice is too long")
+            .encode(out);
+        self.iter().for_each(|e| e.encode(out));
+    }
+}
+
+impl<T: Decode> Decode for Vec<T> {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let len = u32::decode(input)?;
+        (0..len).map(|_| T::decode(input)).collect()
+    }
+}
+
+impl<T: Encode, const N: usize> Encode for [T; N] {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.iter().for_each(|e| e.encode(out));
+    }
+}
+
+impl<T: Decode, const N: usize> Decode for [T; N] {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let elems = (0..N)
+            .map(|_| T::decode(input))
+            .collect::<Result<Vec<T>, _>>()?;
+        Ok(elems
+            .try_into()
+            .unwrap_or_else(|_| unreachable!("decoded {N} elements")))
+    }
+}
+
+/// Entries are encoded in the order of their encoded keys
+impl<K: Encode, V: Encode> Encode for BTreeMap<K, V> {
+    fn encode(&self, out: &mut Vec<u8>) {
+        let mut entries = self
+            .iter()
+            .map(|(k, v)| (to_bytes(k), v))
+            .collect::<Vec<_>>();
+        entries.sort_by(|a, b| a.0.cmp(&b.0));
+        u32::try_from(entries.len())
+            .expect("map is too long")
+            .encode(out);
+        for (key, value) in entries {
+            out.extend_from_slice(&key);
+            value.encode(out);
+        }
+    }
+}
+
+impl<K: Decode + Ord, V: Decode> Decode for BTreeMap<K, V> {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let len = u32::decode(input)?;
+        let mut map = BTreeMap::new();
+        let mut prev_key: Option<&[u8]> = None;
+        for _ in 0..len {
+            let start = *input;
+            let key = K::decode(input)?;
+            let key_bytes = &start[..start.len() - input.len()];
+            if prev_key.is_some_and(|prev| prev >= key_bytes) {
+                return Err(DecodeError::UnsortedMap);
+            }
+            prev_key = Some(key_bytes);
+            map.insert(key, V::decode(input)?);
+        }
+        Ok(map)
+    }
+}
+
+impl Encode for BigInt {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.negative.encode(out);
+        self.abs.encode(out);
+    }
+}
+
+impl Decode for BigInt {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            negative: Decode::decode(input)?,
+            abs: Decode::decode(input)?,
+        })
+    }
+}
+`
+
+var rustPrimitives = map[string]string{
+	"uint8":   "u8",
+	"uint16":  "u16",
+	"uint32":  "u32",
+	"uint64":  "u64",
+	"int8":    "i8",
+	"int16":   "i16",
+	"int32":   "i32",
+	"int64":   "i64",
+	"bool":    "bool",
+	"string":  "String",
+	"Address": "Address",
+	"ID":      "Id",
+	"BigInt":  "BigInt",
+}
+
+var rustKeywords = set.Of(
+	"as", "async", "await", "break", "const", "continue", "crate", "dyn", "else",
+	"enum", "extern", "false", "fn", "for", "if", "impl", "in", "let", "loop",
+	"match", "mod", "move", "mut", "pub", "ref", "return", "static", "struct",
+	"trait", "true", "type", "unsafe", "use", "where", "while", "yield",
+)
+
+// GenerateRust generates Rust structs for the types of [abi] that implement
+// the generated Encode and Decode traits. The encoding matches codec.Packer.
+func GenerateRust(abi ABI) (string, error) {
+	if err := checkFieldTypes(abi, func(name string) bool {
+		_, ok := rustPrimitives[name]
+		return ok
+	}); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(rustRuntime)
+
+	for _, typ := range uniqueTypes(abi) {
+		names := make([]string, len(typ.Fields))
+		for i, field := range typ.Fields {
+			names[i] = rustFieldName(field.Name)
+		}
+
+		sb.WriteString("\n#[derive(Debug, Clone, PartialEq, Eq)]\n")
+		sb.WriteString(fmt.Sprintf("pub struct %s {\n", typ.Name))
+		for i, field := range typ.Fields {
+			// types were validated by checkFieldTypes
+			parsed, _ := parseFieldType(field.Type)
+			sb.WriteString(fmt.Sprintf("    pub %s: %s,\n", names[i], rustType(parsed)))
+		}
+		sb.WriteString("}\n")
+
+		out, input := "out", "input"
+		if len(typ.Fields) == 0 {
+			out, input = "_out", "_input"
+		}
+
+		sb.WriteString(fmt.Sprintf("\nimpl Encode for %s {\n", typ.Name))
+		sb.WriteString(fmt.Sprintf("    fn encode(&self, %s: &mut Vec<u8>) {\n", out))
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("        self.%s.encode(out);\n", name))
+		}
+		sb.WriteString("    }\n")
+		sb.WriteString("}\n")
+
+		sb.WriteString(fmt.Sprintf("\nimpl Decode for %s {\n", typ.Name))
+		sb.WriteString(fmt.Sprintf("    fn decode(%s: &mut &[u8]) -> Result<Self, DecodeError> {\n", input))
+		sb.WriteString("        Ok(Self {\n")
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("            %s: Decode::decode(input)?,\n", name))
+		}
+		sb.WriteString("        })\n")
+		sb.WriteString("    }\n")
+		sb.WriteString("}\n")
+	}
+
+	writeRustIDs(&sb, "action_type_ids", abi.Actions)
+	writeRustIDs(&sb, "output_type_ids", abi.Outputs)
+
+	return sb.String(), nil
+}
+
+func writeRustIDs(sb *strings.Builder, module string, typed []TypedStruct) {
+	sb.WriteString(fmt.Sprintf("\npub mod %s {\n", module))
+	for _, t := range typed {
+		sb.WriteString(fmt.Sprintf("    pub const %s: u8 = %d;\n", strings.ToUpper(strings.Join(splitWords(t.Name), "_")), t.ID))
+	}
+	sb.WriteString("}\n")
+}
+
+func rustFieldName(name string) string {
+	snake := strings.Join(splitWords(name), "_")
+	if rustKeywords.Contains(snake) {
+		return "r#" + snake
+	}
+	return snake
+}
+
+func rustType(f *fieldType) string {
+	switch f.kind {
+	case sliceKind:
+		return fmt.Sprintf("Vec<%s>", rustType(f.elem))
+	case arrayKind:
+		return fmt.Sprintf("[%s; %d]", rustType(f.elem), f.len)
+	case pointerKind:
+		// LinearCodec does not allow nil pointers
+		return rustType(f.elem)
+	case mapKind:
+		return fmt.Sprintf("BTreeMap<%s, %s>", rustType(f.key), rustType(f.elem))
+	}
+	if rustType, ok := rustPrimitives[f.name]; ok {
+		return rustType
+	}
+	return f.name
+}
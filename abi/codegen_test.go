@@ -4,7 +4,11 @@
 package abi
 
 import (
+	"fmt"
 	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -37,3 +41,156 @@ func removeCommentLines(input []byte) []byte {
 	}
 	return []byte(strings.Join(result, "\n"))
 }
+
+// specTypes maps the test vectors in testdata to the type they encode
+var specTypes = []struct {
+	name string
+	typ  string
+}{
+	{"empty", "MockObjectSingleNumber"},
+	{"uint16", "MockObjectSingleNumber"},
+	{"numbers", "MockObjectAllNumbers"},
+	{"arrays", "MockObjectArrays"},
+	{"transfer", "MockActionTransfer"},
+	{"transferField", "MockActionWithTransfer"},
+	{"transfersArray", "MockActionWithTransferArray"},
+	{"strBytes", "MockObjectStringAndBytes"},
+	{"strByteZero", "MockObjectStringAndBytes"},
+	{"strBytesEmpty", "MockObjectStringAndBytes"},
+	{"strOnly", "MockObjectStringAndBytes"},
+	{"outer", "Outer"},
+	{"fixedBytes", "FixedBytes"},
+	{"bools", "Bools"},
+	{"maps", "MockObjectMaps"},
+	{"pointers", "MockObjectPointers"},
+	{"ids", "MockObjectIDs"},
+	{"bigInts", "MockObjectBigInts"},
+}
+
+// The golden files are regenerated with:
+// go run ./cmd/abigen ./abi/testdata/abi.json ./abi/testdata/abi.ts --lang=typescript
+// go run ./cmd/abigen ./abi/testdata/abi.json ./abi/testdata/abi.rs --lang=rust
+func TestGenerateTypeScript(t *testing.T) {
+	require := require.New(t)
+
+	abi := mustJSONParse[ABI](t, string(mustReadFile(t, "testdata/abi.json")))
+
+	code, err := GenerateTypeScript(abi)
+	require.NoError(err)
+	require.Equal(string(mustReadFile(t, "testdata/abi.ts")), code)
+}
+
+func TestGenerateRust(t *testing.T) {
+	require := require.New(t)
+
+	abi := mustJSONParse[ABI](t, string(mustReadFile(t, "testdata/abi.json")))
+
+	code, err := GenerateRust(abi)
+	require.NoError(err)
+	require.Equal(string(mustReadFile(t, "testdata/abi.rs")), code)
+}
+
+func TestGenerateUnknownType(t *testing.T) {
+	abi := ABI{
+		Types: []Type{
+			{Name: "Action", Fields: []Field{{Name: "field", Type: "[]Missing"}}},
+		},
+	}
+
+	_, err := GenerateTypeScript(abi)
+	require.ErrorIs(t, err, errInvalidType)
+	_, err = GenerateRust(abi)
+	require.ErrorIs(t, err, errInvalidType)
+}
+
+// TestRustRoundTrip decodes and re-encodes every test vector with the
+// generated Rust code
+func TestRustRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	rustc, err := exec.LookPath("rustc")
+	if err != nil {
+		t.Skip("rustc not found")
+	}
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "abi.rs"), mustReadFile(t, "testdata/abi.rs"), 0o600))
+
+	var main strings.Builder
+	main.WriteString(`mod abi;
+
+use abi::{from_bytes, to_bytes, Decode, Encode};
+
+fn round_trip<T: Encode + Decode>(dir: &str, name: &str) {
+    let hex = std::fs::read_to_string(format!("{dir}/{name}.hex")).unwrap();
+    let hex = hex.trim();
+    let bytes = (0..hex.len())
+        .step_by(2)
+        .map(|i| u8::from_str_radix(&hex[i..i + 2], 16).unwrap())
+        .collect::<Vec<u8>>();
+    let value: T = from_bytes(&bytes).unwrap_or_else(|e| panic!("{name}: {e:?}"));
+    assert_eq!(to_bytes(&value), bytes, "{name}");
+}
+
+fn main() {
+    let dir = std::env::args().nth(1).unwrap();
+`)
+	for _, spec := range specTypes {
+		main.WriteString(fmt.Sprintf("    round_trip::<abi::%s>(&dir, %q);\n", spec.typ, spec.name))
+	}
+	main.WriteString("}\n")
+	require.NoError(os.WriteFile(filepath.Join(dir, "main.rs"), []byte(main.String()), 0o600))
+
+	binary := filepath.Join(dir, "roundtrip")
+	out, err := exec.Command(rustc, "--edition=2021", "-o", binary, filepath.Join(dir, "main.rs")).CombinedOutput()
+	require.NoError(err, string(out))
+
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(err)
+	out, err = exec.Command(binary, testdata).CombinedOutput()
+	require.NoError(err, string(out))
+}
+
+// TestTypeScriptRoundTrip decodes and re-encodes every test vector with the
+// generated TypeScript code
+func TestTypeScriptRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	tsx, err := exec.LookPath("tsx")
+	if err != nil {
+		t.Skip("tsx not found")
+	}
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "abi.ts"), mustReadFile(t, "testdata/abi.ts"), 0o600))
+
+	var main strings.Builder
+	main.WriteString(`import { readFileSync } from "fs";
+import * as abi from "./abi";
+
+function roundTrip<T>(
+  dir: string,
+  name: string,
+  encode: (w: abi.Writer, v: T) => void,
+  decode: (r: abi.Reader) => T,
+): void {
+  const hex = readFileSync(` + "`${dir}/${name}.hex`" + `, "utf8").trim();
+  const value = abi.unmarshal(decode, Buffer.from(hex, "hex"));
+  const encoded = Buffer.from(abi.marshal(encode, value)).toString("hex");
+  if (encoded !== hex) {
+    throw new Error(` + "`${name}: expected ${hex} but got ${encoded}`" + `);
+  }
+}
+
+const dir = process.argv[2];
+`)
+	for _, spec := range specTypes {
+		main.WriteString(fmt.Sprintf("roundTrip(dir, %q, abi.encode%s, abi.decode%s);\n", spec.name, spec.typ, spec.typ))
+	}
+	require.NoError(os.WriteFile(filepath.Join(dir, "main.ts"), []byte(main.String()), 0o600))
+
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(err)
+	out, err := exec.Command(tsx, filepath.Join(dir, "main.ts"), testdata).CombinedOutput()
+	require.NoError(err, string(out))
+}
@@ -0,0 +1,466 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// typeScriptRuntime is emitted at the top of every generated TypeScript file.
+// Writer and Reader follow the layout of codec.Packer.
+const typeScriptRuntime = `// Code generated by abigen. DO NOT EDIT.
+
+export const ADDRESS_LEN = 33;
+export const ID_LEN = 32;
+
+const textEncoder = new TextEncoder();
+const textDecoder = new TextDecoder("utf-8", { fatal: true });
+
+function checkLength(actual: number, expected: number): void {
+  if (actual !== expected) {
+    throw new Error(` + "`expected length ${expected} but got ${actual}`" + `);
+  }
+}
+
+function compareBytes(a: Uint8Array, b: Uint8Array): number {
+  for (let i = 0; i < Math.min(a.length, b.length); i++) {
+    if (a[i] !== b[i]) {
+      return a[i] - b[i];
+    }
+  }
+  return a.length - b.length;
+}
+
+export class Writer {
+  private buf = new Uint8Array(256);
+  private view = new DataView(this.buf.buffer);
+  private offset = 0;
+
+  private grow(n: number): number {
+    const offset = this.offset;
+    if (offset + n > this.buf.length) {
+      const buf = new Uint8Array(Math.max(this.buf.length * 2, offset + n));
+      buf.set(this.buf);
+      this.buf = buf;
+      this.view = new DataView(buf.buffer);
+    }
+    this.offset += n;
+    return offset;
+  }
+
+  uint8(v: number): void {
+    this.view.setUint8(this.grow(1), v);
+  }
+
+  uint16(v: number): void {
+    this.view.setUint16(this.grow(2), v);
+  }
+
+  uint32(v: number): void {
+    this.view.setUint32(this.grow(4), v);
+  }
+
+  uint64(v: bigint): void {
+    this.view.setBigUint64(this.grow(8), v);
+  }
+
+  int8(v: number): void {
+    this.view.setInt8(this.grow(1), v);
+  }
+
+  int16(v: number): void {
+    this.view.setInt16(this.grow(2), v);
+  }
+
+  int32(v: number): void {
+    this.view.setInt32(this.grow(4), v);
+  }
+
+  int64(v: bigint): void {
+    this.view.setBigInt64(this.grow(8), v);
+  }
+
+  bool(v: boolean): void {
+    this.uint8(v ? 1 : 0);
+  }
+
+  string(v: string): void {
+    const bytes = textEncoder.encode(v);
+    if (bytes.length > 0xffff) {
+      throw new Error("string is too long");
+    }
+    this.uint16(bytes.length);
+    this.raw(bytes);
+  }
+
+  bytes(v: Uint8Array): void {
+    this.uint32(v.length);
+    this.raw(v);
+  }
+
+  fixedBytes(v: Uint8Array, len: number): void {
+    checkLength(v.length, len);
+    this.raw(v);
+  }
+
+  address(v: Uint8Array): void {
+    this.fixedBytes(v, ADDRESS_LEN);
+  }
+
+  id(v: Uint8Array): void {
+    this.fixedBytes(v, ID_LEN);
+  }
+
+  // bigint writes the sign of v followed by the big-endian bytes of its
+  // absolute value
+  bigint(v: bigint): void {
+    this.bool(v < 0n);
+    let hex = (v < 0n ? -v : v).toString(16);
+    if (hex === "0") {
+      hex = "";
+    } else if (hex.length % 2 === 1) {
+      hex = "0" + hex;
+    }
+    const abs = new Uint8Array(hex.length / 2);
+    for (let i = 0; i < abs.length; i++) {
+      abs[i] = parseInt(hex.slice(i * 2, i * 2 + 2), 16);
+    }
+    this.bytes(abs);
+  }
+
+  array<T>(v: T[], encode: (w: Writer, e: T) => void): void {
+    this.uint32(v.length);
+    v.forEach((e) => encode(this, e));
+  }
+
+  fixedArray<T>(v: T[], len: number, encode: (w: Writer, e: T) => void): void {
+    checkLength(v.length, len);
+    v.forEach((e) => encode(this, e));
+  }
+
+  // map writes the entries of v sorted by their encoded keys
+  map<K, V>(
+    v: Map<K, V>,
+    encodeKey: (w: Writer, k: K) => void,
+    encodeValue: (w: Writer, e: V) => void,
+  ): void {
+    const entries = [...v.entries()].map(([k, e]) => {
+      const w = new Writer();
+      encodeKey(w, k);
+      return { key: w.finish(), value: e };
+    });
+    entries.sort((a, b) => compareBytes(a.key, b.key));
+    this.uint32(entries.length);
+    entries.forEach(({ key, value }, i) => {
+      if (i > 0 && compareBytes(entries[i - 1].key, key) === 0) {
+        throw new Error("duplicate map key");
+      }
+      this.raw(key);
+      encodeValue(this, value);
+    });
+  }
+
+  raw(v: Uint8Array): void {
+    this.buf.set(v, this.grow(v.length));
+  }
+
+  finish(): Uint8Array {
+    return this.buf.slice(0, this.offset);
+  }
+}
+
+export class Reader {
+  private readonly view: DataView;
+  private offset = 0;
+
+  constructor(private readonly buf: Uint8Array) {
+    this.view = new DataView(buf.buffer, buf.byteOffset, buf.byteLength);
+  }
+
+  private take(n: number): number {
+    if (this.offset + n > this.buf.length) {
+      throw new Error("unexpected end of input");
+    }
+    const offset = this.offset;
+    this.offset += n;
+    return offset;
+  }
+
+  uint8(): number {
+    return this.view.getUint8(this.take(1));
+  }
+
+  uint16(): number {
+    return this.view.getUint16(this.take(2));
+  }
+
+  uint32(): number {
+    return this.view.getUint32(this.take(4));
+  }
+
+  uint64(): bigint {
+    return this.view.getBigUint64(this.take(8));
+  }
+
+  int8(): number {
+    return this.view.getInt8(this.take(1));
+  }
+
+  int16(): number {
+    return this.view.getInt16(this.take(2));
+  }
+
+  int32(): number {
+    return this.view.getInt32(this.take(4));
+  }
+
+  int64(): bigint {
+    return this.view.getBigInt64(this.take(8));
+  }
+
+  bool(): boolean {
+    const v = this.uint8();
+    if (v > 1) {
+      throw new Error(` + "`invalid bool ${v}`" + `);
+    }
+    return v === 1;
+  }
+
+  string(): string {
+    return textDecoder.decode(this.raw(this.uint16()));
+  }
+
+  bytes(): Uint8Array {
+    return this.raw(this.uint32());
+  }
+
+  fixedBytes(len: number): Uint8Array {
+    return this.raw(len);
+  }
+
+  address(): Uint8Array {
+    return this.fixedBytes(ADDRESS_LEN);
+  }
+
+  id(): Uint8Array {
+    return this.fixedBytes(ID_LEN);
+  }
+
+  bigint(): bigint {
+    const negative = this.bool();
+    let v = 0n;
+    for (const b of this.bytes()) {
+      v = (v << 8n) | BigInt(b);
+    }
+    return negative ? -v : v;
+  }
+
+  array<T>(decode: (r: Reader) => T): T[] {
+    return this.fixedArray(this.uint32(), decode);
+  }
+
+  fixedArray<T>(len: number, decode: (r: Reader) => T): T[] {
+    const v: T[] = [];
+    for (let i = 0; i < len; i++) {
+      v.push(decode(this));
+    }
+    return v;
+  }
+
+  // map reads entries that must be sorted by their encoded keys
+  map<K, V>(decodeKey: (r: Reader) => K, decodeValue: (r: Reader) => V): Map<K, V> {
+    const len = this.uint32();
+    const v = new Map<K, V>();
+    let prevKey: Uint8Array | undefined;
+    for (let i = 0; i < len; i++) {
+      const start = this.offset;
+      const key = decodeKey(this);
+      const keyBytes = this.buf.subarray(start, this.offset);
+      if (prevKey !== undefined && compareBytes(prevKey, keyBytes) >= 0) {
+        throw new Error("map keys are not sorted");
+      }
+      prevKey = keyBytes;
+      v.set(key, decodeValue(this));
+    }
+    return v;
+  }
+
+  raw(len: number): Uint8Array {
+    const offset = this.take(len);
+    return new Uint8Array(this.buf.subarray(offset, offset + len));
+  }
+
+  remaining(): number {
+    return this.buf.length - this.offset;
+  }
+}
+
+export function marshal<T>(encode: (w: Writer, v: T) => void, value: T): Uint8Array {
+  const w = new Writer();
+  encode(w, value);
+  return w.finish();
+}
+
+export function unmarshal<T>(decode: (r: Reader) => T, bytes: Uint8Array): T {
+  const r = new Reader(bytes);
+  const value = decode(r);
+  if (r.remaining() !== 0) {
+    throw new Error(` + "`${r.remaining()} trailing bytes`" + `);
+  }
+  return value;
+}
+`
+
+var typeScriptPrimitives = map[string]string{
+	"uint8":   "number",
+	"uint16":  "number",
+	"uint32":  "number",
+	"uint64":  "bigint",
+	"int8":    "number",
+	"int16":   "number",
+	"int32":   "number",
+	"int64":   "bigint",
+	"bool":    "boolean",
+	"string":  "string",
+	"Address": "Uint8Array",
+	"ID":      "Uint8Array",
+	"BigInt":  "bigint",
+}
+
+// typeScriptMethods are the Writer and Reader methods of primitives whose
+// name differs from the ABI type
+var typeScriptMethods = map[string]string{
+	"Address": "address",
+	"ID":      "id",
+	"BigInt":  "bigint",
+}
+
+// GenerateTypeScript generates TypeScript interfaces for the types of [abi]
+// along with functions that encode and decode them in the same layout as
+// codec.Packer.
+func GenerateTypeScript(abi ABI) (string, error) {
+	if err := checkFieldTypes(abi, func(name string) bool {
+		_, ok := typeScriptPrimitives[name]
+		return ok
+	}); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(typeScriptRuntime)
+
+	for _, typ := range uniqueTypes(abi) {
+		fields := make([]*fieldType, len(typ.Fields))
+		for i, field := range typ.Fields {
+			// types were validated by checkFieldTypes
+			fields[i], _ = parseFieldType(field.Type)
+		}
+
+		sb.WriteString(fmt.Sprintf("\nexport interface %s {\n", typ.Name))
+		for i, field := range typ.Fields {
+			sb.WriteString(fmt.Sprintf("  %s: %s;\n", field.Name, typeScriptType(fields[i])))
+		}
+		sb.WriteString("}\n")
+
+		sb.WriteString(fmt.Sprintf("\nexport function encode%s(w: Writer, v: %s): void {\n", typ.Name, typ.Name))
+		for i, field := range typ.Fields {
+			sb.WriteString(fmt.Sprintf("  %s;\n", typeScriptEncode(fields[i], "v."+field.Name, 0)))
+		}
+		sb.WriteString("}\n")
+
+		sb.WriteString(fmt.Sprintf("\nexport function decode%s(r: Reader): %s {\n", typ.Name, typ.Name))
+		sb.WriteString("  return {\n")
+		for i, field := range typ.Fields {
+			sb.WriteString(fmt.Sprintf("    %s: %s,\n", field.Name, typeScriptDecode(fields[i])))
+		}
+		sb.WriteString("  };\n")
+		sb.WriteString("}\n")
+	}
+
+	writeTypeScriptIDs(&sb, "ActionTypeIDs", abi.Actions)
+	writeTypeScriptIDs(&sb, "OutputTypeIDs", abi.Outputs)
+
+	return sb.String(), nil
+}
+
+func writeTypeScriptIDs(sb *strings.Builder, name string, typed []TypedStruct) {
+	sb.WriteString(fmt.Sprintf("\nexport const %s = {\n", name))
+	for _, t := range typed {
+		sb.WriteString(fmt.Sprintf("  %s: %d,\n", t.Name, t.ID))
+	}
+	sb.WriteString("} as const;\n")
+}
+
+func typeScriptType(f *fieldType) string {
+	switch {
+	case f.isBytes():
+		return "Uint8Array"
+	case f.kind == sliceKind || f.kind == arrayKind:
+		return typeScriptType(f.elem) + "[]"
+	case f.kind == pointerKind:
+		// LinearCodec does not allow nil pointers
+		return typeScriptType(f.elem)
+	case f.kind == mapKind:
+		return fmt.Sprintf("Map<%s, %s>", typeScriptType(f.key), typeScriptType(f.elem))
+	}
+	if tsType, ok := typeScriptPrimitives[f.name]; ok {
+		return tsType
+	}
+	return f.name
+}
+
+// typeScriptEncode returns the statement that writes [value] to the Writer w.
+// [depth] keeps the names of nested elements unique.
+func typeScriptEncode(f *fieldType, value string, depth int) string {
+	elem := fmt.Sprintf("e%d", depth)
+	switch {
+	case f.isBytes() && f.kind == sliceKind:
+		return fmt.Sprintf("w.bytes(%s)", value)
+	case f.isBytes():
+		return fmt.Sprintf("w.fixedBytes(%s, %d)", value, f.len)
+	case f.kind == sliceKind:
+		return fmt.Sprintf("w.array(%s, (w, %s) => %s)", value, elem, typeScriptEncode(f.elem, elem, depth+1))
+	case f.kind == arrayKind:
+		return fmt.Sprintf("w.fixedArray(%s, %d, (w, %s) => %s)", value, f.len, elem, typeScriptEncode(f.elem, elem, depth+1))
+	case f.kind == pointerKind:
+		return typeScriptEncode(f.elem, value, depth)
+	case f.kind == mapKind:
+		key := fmt.Sprintf("k%d", depth)
+		return fmt.Sprintf("w.map(%s, (w, %s) => %s, (w, %s) => %s)", value, key, typeScriptEncode(f.key, key, depth+1), elem, typeScriptEncode(f.elem, elem, depth+1))
+	}
+	if method, ok := typeScriptMethods[f.name]; ok {
+		return fmt.Sprintf("w.%s(%s)", method, value)
+	}
+	if _, ok := typeScriptPrimitives[f.name]; ok {
+		return fmt.Sprintf("w.%s(%s)", f.name, value)
+	}
+	return fmt.Sprintf("encode%s(w, %s)", f.name, value)
+}
+
+// typeScriptDecode returns the expression that reads a value of type [f] from
+// the Reader r
+func typeScriptDecode(f *fieldType) string {
+	switch {
+	case f.isBytes() && f.kind == sliceKind:
+		return "r.bytes()"
+	case f.isBytes():
+		return fmt.Sprintf("r.fixedBytes(%d)", f.len)
+	case f.kind == sliceKind:
+		return fmt.Sprintf("r.array((r) => %s)", typeScriptDecode(f.elem))
+	case f.kind == arrayKind:
+		return fmt.Sprintf("r.fixedArray(%d, (r) => %s)", f.len, typeScriptDecode(f.elem))
+	case f.kind == pointerKind:
+		return typeScriptDecode(f.elem)
+	case f.kind == mapKind:
+		return fmt.Sprintf("r.map((r) => %s, (r) => %s)", typeScriptDecode(f.key), typeScriptDecode(f.elem))
+	}
+	if method, ok := typeScriptMethods[f.name]; ok {
+		return fmt.Sprintf("r.%s()", method)
+	}
+	if _, ok := typeScriptPrimitives[f.name]; ok {
+		return fmt.Sprintf("r.%s()", f.name)
+	}
+	return fmt.Sprintf("decode%s(r)", f.name)
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrTypeIDReused     = errors.New("type ID reused")
+	ErrTypeIDChanged    = errors.New("type ID changed")
+	ErrTypeRemoved      = errors.New("type removed")
+	ErrFieldRemoved     = errors.New("field removed")
+	ErrFieldAdded       = errors.New("field added")
+	ErrFieldReordered   = errors.New("fields reordered")
+	ErrFieldTypeChanged = errors.New("field type changed")
+)
+
+// CheckCompatibility returns the breaking changes between [previous] and
+// [next]. A change is breaking if a client built against [previous] would
+// encode or decode a value differently than a node running [next].
+//
+// Adding actions, outputs and types is not a breaking change. Because fields
+// are encoded in order and without tags, any change to the fields of an
+// existing type is.
+func CheckCompatibility(previous, next ABI) []error {
+	var errs []error
+	errs = append(errs, checkTypedStructs("action", previous.Actions, next.Actions)...)
+	errs = append(errs, checkTypedStructs("output", previous.Outputs, next.Outputs)...)
+
+	for _, previousType := range previous.Types {
+		nextType, ok := next.FindTypeByName(previousType.Name)
+		if !ok {
+			// Types that are still referenced are reported as a field type
+			// change or as a removed action or output
+			continue
+		}
+		errs = append(errs, checkFields(previousType, nextType)...)
+	}
+	return errs
+}
+
+func checkTypedStructs(kind string, previous, next []TypedStruct) []error {
+	var errs []error
+	for _, p := range previous {
+		byID := slices.IndexFunc(next, func(n TypedStruct) bool { return n.ID == p.ID })
+		byName := slices.IndexFunc(next, func(n TypedStruct) bool { return n.Name == p.Name })
+		switch {
+		case byID != -1 && next[byID].Name != p.Name:
+			errs = append(errs, fmt.Errorf("%w: %s %d was %s and is now %s", ErrTypeIDReused, kind, p.ID, p.Name, next[byID].Name))
+		case byName != -1 && next[byName].ID != p.ID:
+			errs = append(errs, fmt.Errorf("%w: %s %s was %d and is now %d", ErrTypeIDChanged, kind, p.Name, p.ID, next[byName].ID))
+		case byName == -1:
+			errs = append(errs, fmt.Errorf("%w: %s %s (%d)", ErrTypeRemoved, kind, p.Name, p.ID))
+		}
+	}
+	return errs
+}
+
+func checkFields(previous, next Type) []error {
+	var (
+		errs []error
+		// names of the fields that are in both types, in their order in each
+		previousOrder []string
+		nextOrder     []string
+	)
+	for _, p := range previous.Fields {
+		i := slices.IndexFunc(next.Fields, func(n Field) bool { return n.Name == p.Name })
+		if i == -1 {
+			errs = append(errs, fmt.Errorf("%w: %s.%s", ErrFieldRemoved, previous.Name, p.Name))
+			continue
+		}
+		if next.Fields[i].Type != p.Type {
+			errs = append(errs, fmt.Errorf("%w: %s.%s was %s and is now %s", ErrFieldTypeChanged, previous.Name, p.Name, p.Type, next.Fields[i].Type))
+		}
+		previousOrder = append(previousOrder, p.Name)
+	}
+	for _, n := range next.Fields {
+		if !slices.ContainsFunc(previous.Fields, func(p Field) bool { return p.Name == n.Name }) {
+			errs = append(errs, fmt.Errorf("%w: %s.%s", ErrFieldAdded, next.Name, n.Name))
+			continue
+		}
+		nextOrder = append(nextOrder, n.Name)
+	}
+	if !slices.Equal(previousOrder, nextOrder) {
+		errs = append(errs, fmt.Errorf("%w: %s was %v and is now %v", ErrFieldReordered, previous.Name, previousOrder, nextOrder))
+	}
+	return errs
+}
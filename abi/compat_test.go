@@ -0,0 +1,140 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	previous := ABI{
+		Actions: []TypedStruct{{ID: 0, Name: "Transfer"}, {ID: 1, Name: "Mint"}},
+		Outputs: []TypedStruct{{ID: 0, Name: "TransferResult"}},
+		Types: []Type{
+			{Name: "Transfer", Fields: []Field{{Name: "to", Type: "Address"}, {Name: "value", Type: "uint64"}}},
+			{Name: "Mint", Fields: []Field{{Name: "to", Type: "Address"}}},
+			{Name: "TransferResult", Fields: []Field{{Name: "balance", Type: "uint64"}}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(*ABI)
+		expectedErr []error
+	}{
+		{
+			name:   "unchanged",
+			modify: func(*ABI) {},
+		},
+		{
+			name: "action added",
+			modify: func(a *ABI) {
+				a.Actions = append(a.Actions, TypedStruct{ID: 2, Name: "Burn"})
+				a.Types = append(a.Types, Type{Name: "Burn", Fields: []Field{{Name: "value", Type: "uint64"}}})
+			},
+		},
+		{
+			name: "action ID reused",
+			modify: func(a *ABI) {
+				a.Actions[1] = TypedStruct{ID: 1, Name: "Burn"}
+			},
+			expectedErr: []error{ErrTypeIDReused},
+		},
+		{
+			name: "output ID changed",
+			modify: func(a *ABI) {
+				a.Outputs[0].ID = 1
+			},
+			expectedErr: []error{ErrTypeIDChanged},
+		},
+		{
+			name: "action removed",
+			modify: func(a *ABI) {
+				a.Actions = a.Actions[:1]
+			},
+			expectedErr: []error{ErrTypeRemoved},
+		},
+		{
+			name: "field removed",
+			modify: func(a *ABI) {
+				a.Types[0].Fields = []Field{{Name: "to", Type: "Address"}}
+			},
+			expectedErr: []error{ErrFieldRemoved},
+		},
+		{
+			name: "field added",
+			modify: func(a *ABI) {
+				a.Types[1].Fields = append(a.Types[1].Fields, Field{Name: "value", Type: "uint64"})
+			},
+			expectedErr: []error{ErrFieldAdded},
+		},
+		{
+			name: "fields reordered",
+			modify: func(a *ABI) {
+				a.Types[0].Fields = []Field{{Name: "value", Type: "uint64"}, {Name: "to", Type: "Address"}}
+			},
+			expectedErr: []error{ErrFieldReordered},
+		},
+		{
+			name: "field type changed",
+			modify: func(a *ABI) {
+				a.Types[2].Fields[0].Type = "uint32"
+			},
+			expectedErr: []error{ErrFieldTypeChanged},
+		},
+		{
+			name: "field removed and type changed",
+			modify: func(a *ABI) {
+				a.Types[0].Fields = []Field{{Name: "value", Type: "int64"}}
+			},
+			expectedErr: []error{ErrFieldRemoved, ErrFieldTypeChanged},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			next := copyABI(previous)
+			tt.modify(&next)
+
+			errs := CheckCompatibility(previous, next)
+			require.Len(errs, len(tt.expectedErr))
+			for i, err := range errs {
+				require.ErrorIs(err, tt.expectedErr[i])
+			}
+		})
+	}
+}
+
+func TestABIHashChanges(t *testing.T) {
+	require := require.New(t)
+
+	abi := ABI{
+		Actions: []TypedStruct{{ID: 0, Name: "Transfer"}},
+		Types:   []Type{{Name: "Transfer", Fields: []Field{{Name: "to", Type: "Address"}, {Name: "value", Type: "uint64"}}}},
+	}
+	hash, err := abi.Hash()
+	require.NoError(err)
+
+	reordered := copyABI(abi)
+	reordered.Types[0].Fields[0], reordered.Types[0].Fields[1] = reordered.Types[0].Fields[1], reordered.Types[0].Fields[0]
+	reorderedHash, err := reordered.Hash()
+	require.NoError(err)
+	require.NotEqual(hash, reorderedHash)
+}
+
+func copyABI(a ABI) ABI {
+	c := ABI{
+		Actions: slices.Clone(a.Actions),
+		Outputs: slices.Clone(a.Outputs),
+		Types:   make([]Type, len(a.Types)),
+	}
+	for i, typ := range a.Types {
+		c.Types[i] = Type{Name: typ.Name, Fields: slices.Clone(typ.Fields)}
+	}
+	return c
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	errInvalidName   = errors.New("invalid name")
+	errDuplicateName = errors.New("duplicate name")
+	errRecursiveType = errors.New("recursive type")
+
+	// identifierRegex matches the names of functions, types and fields
+	identifierRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+	// contractPrimitives are the types of a contract ABI that are not
+	// declared in its types
+	contractPrimitives = set.Of(
+		"bool", "string", "Address", "ID", "BigInt",
+		"uint8", "uint16", "uint32", "uint64",
+		"int8", "int16", "int32", "int64",
+	)
+)
+
+// ContractABI describes the public functions of a WASM contract.
+// Types are named the same way as in ABI and are serialized with borsh.
+type ContractABI struct {
+	Functions []Function `serialize:"true" json:"functions"`
+	Types     []Type     `serialize:"true" json:"types"`
+}
+
+type Function struct {
+	Name string  `serialize:"true" json:"name"`
+	Args []Field `serialize:"true" json:"args"`
+	// Output is the type returned by the function, empty if it returns nothing
+	Output string `serialize:"true" json:"output,omitempty"`
+}
+
+func (c *ContractABI) FindFunctionByName(name string) (Function, bool) {
+	for _, function := range c.Functions {
+		if function.Name == name {
+			return function, true
+		}
+	}
+	return Function{}, false
+}
+
+func (c *ContractABI) FindTypeByName(name string) (Type, bool) {
+	for _, typ := range c.Types {
+		if typ.Name == name {
+			return typ, true
+		}
+	}
+	return Type{}, false
+}
+
+// Verify returns an error if [c] cannot be used to encode calls and decode
+// their results. Names must be identifiers that are unique (ignoring case)
+// within their scope, and every type must be a primitive or a declared type
+// that does not contain itself.
+func (c *ContractABI) Verify() error {
+	types := make(map[string]Type, len(c.Types))
+	typeNames := set.NewSet[string](len(c.Types))
+	for _, typ := range c.Types {
+		if err := checkName(typ.Name, typeNames); err != nil {
+			return fmt.Errorf("type %q: %w", typ.Name, err)
+		}
+		if contractPrimitives.Contains(typ.Name) {
+			return fmt.Errorf("type %q: %w: shadows a primitive", typ.Name, errDuplicateName)
+		}
+		types[typ.Name] = typ
+	}
+	for _, typ := range c.Types {
+		if err := checkContractFields(typ.Fields, types); err != nil {
+			return fmt.Errorf("type %s: %w", typ.Name, err)
+		}
+	}
+
+	functionNames := set.NewSet[string](len(c.Functions))
+	for _, function := range c.Functions {
+		if err := checkName(function.Name, functionNames); err != nil {
+			return fmt.Errorf("function %q: %w", function.Name, err)
+		}
+		if err := checkContractFields(function.Args, types); err != nil {
+			return fmt.Errorf("function %s: %w", function.Name, err)
+		}
+		if function.Output == "" {
+			continue
+		}
+		if err := checkContractType(function.Output, types); err != nil {
+			return fmt.Errorf("output of function %s: %w", function.Name, err)
+		}
+	}
+
+	// Types are visited depth first to find types that contain themselves
+	visited := set.NewSet[string](len(types))
+	for name := range types {
+		if err := checkNotRecursive(name, types, set.Set[string]{}, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkName returns an error if [name] is not an identifier or is already in
+// [names], ignoring case
+func checkName(name string, names set.Set[string]) error {
+	if !identifierRegex.MatchString(name) {
+		return errInvalidName
+	}
+	lower := strings.ToLower(name)
+	if names.Contains(lower) {
+		return errDuplicateName
+	}
+	names.Add(lower)
+	return nil
+}
+
+func checkContractFields(fields []Field, types map[string]Type) error {
+	names := set.NewSet[string](len(fields))
+	for _, field := range fields {
+		if err := checkName(field.Name, names); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		if err := checkContractType(field.Type, types); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkContractType returns an error if [abiType] references a type that is
+// neither a primitive nor declared in [types]
+func checkContractType(abiType string, types map[string]Type) error {
+	parsed, err := parseFieldType(abiType)
+	if err != nil {
+		return err
+	}
+	for _, name := range parsed.named() {
+		if _, ok := types[name]; !ok && !contractPrimitives.Contains(name) {
+			return fmt.Errorf("%w: unknown type %q", errInvalidType, name)
+		}
+	}
+	return nil
+}
+
+// checkNotRecursive returns an error if [name] contains itself. [path] are the
+// types containing [name] and [visited] are the types that are known to not
+// be recursive.
+func checkNotRecursive(name string, types map[string]Type, path set.Set[string], visited set.Set[string]) error {
+	typ, ok := types[name]
+	if !ok || visited.Contains(name) {
+		return nil
+	}
+	if path.Contains(name) {
+		return fmt.Errorf("type %s: %w", name, errRecursiveType)
+	}
+
+	path.Add(name)
+	for _, field := range typ.Fields {
+		// field types were verified by checkContractFields
+		parsed, _ := parseFieldType(field.Type)
+		for _, named := range parsed.named() {
+			if err := checkNotRecursive(named, types, path, visited); err != nil {
+				return err
+			}
+		}
+	}
+	path.Remove(name)
+	visited.Add(name)
+	return nil
+}
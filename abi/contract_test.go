@@ -0,0 +1,106 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractABIVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		contractABI ContractABI
+		wantErr     error
+	}{
+		{
+			name: "valid",
+			contractABI: ContractABI{
+				Functions: []Function{
+					{
+						Name:   "get_info",
+						Args:   []Field{{Name: "owner", Type: "Address"}, {Name: "ids", Type: "[]ID"}},
+						Output: "Info",
+					},
+				},
+				Types: []Type{
+					{Name: "Info", Fields: []Field{{Name: "balances", Type: "map[string]uint64"}, {Name: "meta", Type: "Meta"}}},
+					{Name: "Meta", Fields: []Field{{Name: "tag", Type: "[4]uint8"}}},
+				},
+			},
+		},
+		{
+			name: "empty arg name",
+			contractABI: ContractABI{
+				Functions: []Function{{Name: "f", Args: []Field{{Name: "", Type: "uint64"}}}},
+			},
+			wantErr: errInvalidName,
+		},
+		{
+			name: "underscore leading arg name",
+			contractABI: ContractABI{
+				Functions: []Function{{Name: "f", Args: []Field{{Name: "_amount", Type: "uint64"}}}},
+			},
+			wantErr: errInvalidName,
+		},
+		{
+			name: "non-identifier field name",
+			contractABI: ContractABI{
+				Types: []Type{{Name: "T", Fields: []Field{{Name: `a"b`, Type: "uint64"}}}},
+			},
+			wantErr: errInvalidName,
+		},
+		{
+			name: "duplicate arg names",
+			contractABI: ContractABI{
+				Functions: []Function{{Name: "f", Args: []Field{{Name: "amount", Type: "uint64"}, {Name: "Amount", Type: "uint64"}}}},
+			},
+			wantErr: errDuplicateName,
+		},
+		{
+			name: "duplicate functions",
+			contractABI: ContractABI{
+				Functions: []Function{{Name: "f"}, {Name: "f"}},
+			},
+			wantErr: errDuplicateName,
+		},
+		{
+			name: "type shadowing a primitive",
+			contractABI: ContractABI{
+				Types: []Type{{Name: "uint64"}},
+			},
+			wantErr: errDuplicateName,
+		},
+		{
+			name: "unknown type",
+			contractABI: ContractABI{
+				Functions: []Function{{Name: "f", Output: "Missing"}},
+			},
+			wantErr: errInvalidType,
+		},
+		{
+			name: "invalid map key",
+			contractABI: ContractABI{
+				Functions: []Function{{Name: "f", Args: []Field{{Name: "m", Type: "map[[]uint8]uint64"}}}},
+			},
+			wantErr: errInvalidType,
+		},
+		{
+			name: "recursive type",
+			contractABI: ContractABI{
+				Types: []Type{
+					{Name: "A", Fields: []Field{{Name: "b", Type: "[]B"}}},
+					{Name: "B", Fields: []Field{{Name: "a", Type: "map[string]A"}}},
+				},
+			},
+			wantErr: errRecursiveType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.contractABI.Verify(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/near/borsh-go"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/ava-labs/hypersdk/abi"
+)
+
+var (
+	ErrFunctionNotFound   = errors.New("function not found in contract ABI")
+	ErrInvalidContractABI = errors.New("invalid contract ABI")
+)
+
+// MarshalCallData serializes [jsonArgs], a JSON object keyed by argument name,
+// into the call data of [functionName]. Arguments are serialized with borsh in
+// the order they are declared.
+func MarshalCallData(contractABI abi.ContractABI, functionName string, jsonArgs string) ([]byte, error) {
+	// Contract ABIs are published on-chain by anyone, so they are verified
+	// before their names are used to build types
+	if err := contractABI.Verify(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContractABI, err)
+	}
+	function, ok := contractABI.FindFunctionByName(functionName)
+	if !ok {
+		return nil, fmt.Errorf("marshalling %s: %w", functionName, ErrFunctionNotFound)
+	}
+
+	typeCache := make(map[string]reflect.Type)
+	fields := make([]reflect.StructField, len(function.Args))
+	for i, arg := range function.Args {
+		argType, err := getReflectType(arg.Type, &contractABI, typeCache)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reflect type: %w", err)
+		}
+		fields[i] = reflect.StructField{
+			Name: cases.Title(language.English).String(arg.Name),
+			Type: argType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, arg.Name)),
+		}
+	}
+	argsType, err := newType(func() reflect.Type { return reflect.StructOf(fields) })
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.New(argsType)
+
+	if len(jsonArgs) != 0 {
+		if err := json.Unmarshal([]byte(jsonArgs), value.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	callData, err := borsh.Serialize(value.Elem().Interface())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	return callData, nil
+}
+
+// UnmarshalCallResult decodes the value returned by [functionName] into JSON.
+// Returns an empty string if the function does not return a value.
+func UnmarshalCallResult(contractABI abi.ContractABI, functionName string, data []byte) (string, error) {
+	if err := contractABI.Verify(); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidContractABI, err)
+	}
+	function, ok := contractABI.FindFunctionByName(functionName)
+	if !ok {
+		return "", fmt.Errorf("unmarshalling %s: %w", functionName, ErrFunctionNotFound)
+	}
+	if function.Output == "" {
+		return "", nil
+	}
+
+	typ, err := getReflectType(function.Output, &contractABI, make(map[string]reflect.Type))
+	if err != nil {
+		return "", fmt.Errorf("failed to get reflect type: %w", err)
+	}
+
+	value := reflect.New(typ)
+	if err := borsh.Deserialize(value.Interface(), data); err != nil {
+		return "", fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+
+	jsonData, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result to JSON: %w", err)
+	}
+	return string(jsonData), nil
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamic
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/abi"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+var testContractABI = abi.ContractABI{
+	Functions: []abi.Function{
+		{
+			Name: "transfer",
+			Args: []abi.Field{
+				{Name: "to", Type: "Address"},
+				{Name: "amount", Type: "uint64"},
+				{Name: "memo", Type: "[]uint8"},
+			},
+			Output: "bool",
+		},
+		{
+			Name:   "info",
+			Output: "Info",
+		},
+		{
+			Name: "init",
+		},
+	},
+	Types: []abi.Type{
+		{
+			Name: "Info",
+			Fields: []abi.Field{
+				{Name: "name", Type: "string"},
+				{Name: "supply", Type: "uint64"},
+			},
+		},
+	},
+}
+
+func TestMarshalCallData(t *testing.T) {
+	require := require.New(t)
+
+	to := codec.CreateAddress(1, [32]byte{1, 2, 3})
+	callData, err := MarshalCallData(testContractABI, "transfer", `{"to":"`+to.String()+`","amount":5,"memo":"AQI="}`)
+	require.NoError(err)
+
+	expected := append([]byte{}, to[:]...)
+	expected = binary.LittleEndian.AppendUint64(expected, 5)
+	expected = binary.LittleEndian.AppendUint32(expected, 2)
+	expected = append(expected, 1, 2)
+	require.Equal(expected, callData)
+
+	callData, err = MarshalCallData(testContractABI, "init", "")
+	require.NoError(err)
+	require.Empty(callData)
+
+	_, err = MarshalCallData(testContractABI, "missing", "{}")
+	require.ErrorIs(err, ErrFunctionNotFound)
+}
+
+func TestMarshalCallDataInvalidContractABI(t *testing.T) {
+	tests := []struct {
+		name        string
+		contractABI abi.ContractABI
+	}{
+		{
+			name: "underscore leading arg name",
+			contractABI: abi.ContractABI{
+				Functions: []abi.Function{{Name: "f", Args: []abi.Field{{Name: "_amount", Type: "uint64"}}}},
+			},
+		},
+		{
+			name: "duplicate arg names",
+			contractABI: abi.ContractABI{
+				Functions: []abi.Function{{Name: "f", Args: []abi.Field{{Name: "fooBar", Type: "uint64"}, {Name: "foobar", Type: "uint64"}}}},
+			},
+		},
+		{
+			name: "recursive type",
+			contractABI: abi.ContractABI{
+				Functions: []abi.Function{{Name: "f", Args: []abi.Field{{Name: "a", Type: "A"}}}},
+				Types:     []abi.Type{{Name: "A", Fields: []abi.Field{{Name: "a", Type: "[]A"}}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MarshalCallData(tt.contractABI, "f", "{}")
+			require.ErrorIs(t, err, ErrInvalidContractABI)
+		})
+	}
+}
+
+func TestMarshalCallDataTooLargeArray(t *testing.T) {
+	contractABI := abi.ContractABI{
+		Functions: []abi.Function{{Name: "f", Args: []abi.Field{{Name: "a", Type: "[4294967296][4294967296][4294967296]uint64"}}}},
+	}
+	_, err := MarshalCallData(contractABI, "f", "{}")
+	require.ErrorIs(t, err, ErrInvalidType)
+}
+
+func TestUnmarshalCallResult(t *testing.T) {
+	require := require.New(t)
+
+	result, err := UnmarshalCallResult(testContractABI, "transfer", []byte{1})
+	require.NoError(err)
+	require.Equal("true", result)
+
+	data := binary.LittleEndian.AppendUint32(nil, 5)
+	data = append(data, "token"...)
+	data = binary.LittleEndian.AppendUint64(data, 100)
+	result, err = UnmarshalCallResult(testContractABI, "info", data)
+	require.NoError(err)
+	require.JSONEq(`{"name":"token","supply":100}`, result)
+
+	result, err = UnmarshalCallResult(testContractABI, "init", nil)
+	require.NoError(err)
+	require.Empty(result)
+}
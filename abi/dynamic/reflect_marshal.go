@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -21,7 +22,15 @@ import (
 	"github.com/ava-labs/hypersdk/consts"
 )
 
-var ErrTypeNotFound = errors.New("type not found in ABI")
+var (
+	ErrTypeNotFound = errors.New("type not found in ABI")
+	ErrInvalidType  = errors.New("invalid type")
+)
+
+// typeFinder finds the struct types defined by an ABI
+type typeFinder interface {
+	FindTypeByName(name string) (abi.Type, bool)
+}
 
 func Marshal(inputABI abi.ABI, typeName string, jsonData string) ([]byte, error) {
 	if _, ok := inputABI.FindTypeByName(typeName); !ok {
@@ -30,7 +39,7 @@ func Marshal(inputABI abi.ABI, typeName string, jsonData string) ([]byte, error)
 
 	typeCache := make(map[string]reflect.Type)
 
-	typ, err := getReflectType(typeName, inputABI, typeCache)
+	typ, err := getReflectType(typeName, &inputABI, typeCache)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reflect type: %w", err)
 	}
@@ -94,7 +103,7 @@ func UnmarshalAction(inputABI abi.ABI, data []byte) (string, error) {
 func Unmarshal(inputABI abi.ABI, data []byte, typeName string) (string, error) {
 	typeCache := make(map[string]reflect.Type)
 
-	typ, err := getReflectType(typeName, inputABI, typeCache)
+	typ, err := getReflectType(typeName, &inputABI, typeCache)
 	if err != nil {
 		return "", fmt.Errorf("failed to get reflect type: %w", err)
 	}
@@ -117,11 +126,17 @@ func Unmarshal(inputABI abi.ABI, data []byte, typeName string) (string, error) {
 	return string(jsonData), nil
 }
 
-// Matches fixed-size arrays like [32]uint8
-var fixedSizeArrayRegex = regexp.MustCompile(`^\[(\d+)\](.+)$`)
+var (
+	// Matches fixed-size arrays like [32]uint8
+	fixedSizeArrayRegex = regexp.MustCompile(`^\[(\d+)\](.+)$`)
+	// Matches maps like map[Address]uint64. Keys are always named types.
+	mapRegex = regexp.MustCompile(`^map\[(\w+)\](.+)$`)
+)
 
-func getReflectType(abiTypeName string, inputABI abi.ABI, typeCache map[string]reflect.Type) (reflect.Type, error) {
+func getReflectType(abiTypeName string, inputABI typeFinder, typeCache map[string]reflect.Type) (reflect.Type, error) {
 	switch abiTypeName {
+	case "bool":
+		return reflect.TypeOf(false), nil
 	case "string":
 		return reflect.TypeOf(""), nil
 	case "uint8":
@@ -142,7 +157,33 @@ func getReflectType(abiTypeName string, inputABI abi.ABI, typeCache map[string]r
 		return reflect.TypeOf(int64(0)), nil
 	case "Address":
 		return reflect.TypeOf(codec.Address{}), nil
+	case "ID":
+		return reflect.TypeOf(ids.ID{}), nil
+	case "BigInt":
+		return reflect.TypeOf(codec.BigInt{}), nil
 	default:
+		// golang pointers
+		if strings.HasPrefix(abiTypeName, "*") {
+			elemType, err := getReflectType(strings.TrimPrefix(abiTypeName, "*"), inputABI, typeCache)
+			if err != nil {
+				return nil, err
+			}
+			return reflect.PointerTo(elemType), nil
+		}
+
+		// golang maps
+		if match := mapRegex.FindStringSubmatch(abiTypeName); match != nil {
+			keyType, err := getReflectType(match[1], inputABI, typeCache)
+			if err != nil {
+				return nil, err
+			}
+			elemType, err := getReflectType(match[2], inputABI, typeCache)
+			if err != nil {
+				return nil, err
+			}
+			return newType(func() reflect.Type { return reflect.MapOf(keyType, elemType) })
+		}
+
 		// golang slices
 		if strings.HasPrefix(abiTypeName, "[]") {
 			elemType, err := getReflectType(strings.TrimPrefix(abiTypeName, "[]"), inputABI, typeCache)
@@ -164,7 +205,7 @@ func getReflectType(abiTypeName string, inputABI abi.ABI, typeCache map[string]r
 			if err != nil {
 				return nil, err
 			}
-			return reflect.ArrayOf(size, elemType), nil
+			return newType(func() reflect.Type { return reflect.ArrayOf(size, elemType) })
 		}
 
 		// For custom types, recursively construct the struct type
@@ -192,9 +233,24 @@ func getReflectType(abiTypeName string, inputABI abi.ABI, typeCache map[string]r
 			}
 		}
 
-		structType := reflect.StructOf(fields)
+		structType, err := newType(func() reflect.Type { return reflect.StructOf(fields) })
+		if err != nil {
+			return nil, err
+		}
 		typeCache[abiTypeName] = structType
 
 		return structType, nil
 	}
 }
+
+// newType returns the type built by [build], or an error if [build] panics
+// because reflect rejects the type (like a struct with duplicate field names
+// or an array that is too large)
+func newType(build func() reflect.Type) (typ reflect.Type, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrInvalidType, r)
+		}
+	}()
+	return build(), nil
+}
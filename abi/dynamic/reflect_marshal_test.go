@@ -41,6 +41,10 @@ func TestDynamicMarshal(t *testing.T) {
 		{"strOnly", "MockObjectStringAndBytes"},
 		{"outer", "Outer"},
 		{"fixedBytes", "FixedBytes"},
+		{"maps", "MockObjectMaps"},
+		{"pointers", "MockObjectPointers"},
+		{"ids", "MockObjectIDs"},
+		{"bigInts", "MockObjectBigInts"},
 	}
 
 	for _, tc := range testCases {
@@ -3,7 +3,11 @@
 
 package abi
 
-import "github.com/ava-labs/hypersdk/codec"
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
 
 type MockObjectSingleNumber struct {
 	Field1 uint16 `serialize:"true"`
@@ -76,6 +80,27 @@ type Bools struct {
 	BoolArray []bool `serialize:"true" json:"boolArray"`
 }
 
+type MockObjectMaps struct {
+	Balances map[codec.Address]uint64 `serialize:"true" json:"balances"`
+	Inners   map[string]Inner         `serialize:"true" json:"inners"`
+}
+
+type MockObjectPointers struct {
+	Inner *Inner  `serialize:"true" json:"inner"`
+	Value *uint64 `serialize:"true" json:"value"`
+}
+
+type MockObjectIDs struct {
+	AssetID  ids.ID    `serialize:"true" json:"assetID"`
+	Hash     [32]uint8 `serialize:"true" json:"hash"`
+	AssetIDs []ids.ID  `serialize:"true" json:"assetIDs"`
+}
+
+type MockObjectBigInts struct {
+	Amount  codec.BigInt   `serialize:"true" json:"amount"`
+	Amounts []codec.BigInt `serialize:"true" json:"amounts"`
+}
+
 type ActionOutput struct {
 	Field1 uint16 `serialize:"true" json:"field1"`
 }
@@ -124,6 +149,22 @@ func (Bools) GetTypeID() uint8 {
 	return 10
 }
 
+func (MockObjectMaps) GetTypeID() uint8 {
+	return 11
+}
+
+func (MockObjectPointers) GetTypeID() uint8 {
+	return 12
+}
+
+func (MockObjectIDs) GetTypeID() uint8 {
+	return 13
+}
+
+func (MockObjectBigInts) GetTypeID() uint8 {
+	return 14
+}
+
 func (ActionOutput) GetTypeID() uint8 {
 	return 0
 }
@@ -0,0 +1,626 @@
+// Code generated by abigen. DO NOT EDIT.
+
+#![allow(dead_code)]
+
+use std::collections::BTreeMap;
+
+pub const ADDRESS_LEN: usize = 33;
+pub const ID_LEN: usize = 32;
+
+pub type Address = [u8; ADDRESS_LEN];
+pub type Id = [u8; ID_LEN];
+
+/// An arbitrary precision integer stored as its sign and the big-endian bytes
+/// of its absolute value
+#[derive(Debug, Clone, Default, PartialEq, Eq)]
+pub struct BigInt {
+    pub negative: bool,
+    pub abs: Vec<u8>,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum DecodeError {
+    UnexpectedEnd,
+    InvalidBool(u8),
+    InvalidString,
+    UnsortedMap,
+    TrailingBytes(usize),
+}
+
+pub trait Encode {
+    fn encode(&self, out: &mut Vec<u8>);
+}
+
+pub trait Decode: Sized {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError>;
+}
+
+pub fn to_bytes<T: Encode>(value: &T) -> Vec<u8> {
+    let mut out = Vec::new();
+    value.encode(&mut out);
+    out
+}
+
+pub fn from_bytes<T: Decode>(mut bytes: &[u8]) -> Result<T, DecodeError> {
+    let value = T::decode(&mut bytes)?;
+    if !bytes.is_empty() {
+        return Err(DecodeError::TrailingBytes(bytes.len()));
+    }
+    Ok(value)
+}
+
+fn take<'a>(input: &mut &'a [u8], len: usize) -> Result<&'a [u8], DecodeError> {
+    if input.len() < len {
+        return Err(DecodeError::UnexpectedEnd);
+    }
+    let (head, tail) = input.split_at(len);
+    *input = tail;
+    Ok(head)
+}
+
+macro_rules! impl_int {
+    ($($t:ty),*) => {$(
+        impl Encode for $t {
+            fn encode(&self, out: &mut Vec<u8>) {
+                out.extend_from_slice(&self.to_be_bytes());
+            }
+        }
+
+        impl Decode for $t {
+            fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+                let bytes = take(input, core::mem::size_of::<$t>())?;
+                Ok(<$t>::from_be_bytes(bytes.try_into().expect("length was checked")))
+            }
+        }
+    )*};
+}
+
+impl_int!(u8, u16, u32, u64, i8, i16, i32, i64);
+
+impl Encode for bool {
+    fn encode(&self, out: &mut Vec<u8>) {
+        out.push(u8::from(*self));
+    }
+}
+
+impl Decode for bool {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        match u8::decode(input)? {
+            0 => Ok(false),
+            1 => Ok(true),
+            v => Err(DecodeError::InvalidBool(v)),
+        }
+    }
+}
+
+impl Encode for String {
+    fn encode(&self, out: &mut Vec<u8>) {
+        u16::try_from(self.len())
+            .expect("string is too long")
+            .encode(out);
+        out.extend_from_slice(self.as_bytes());
+    }
+}
+
+impl Decode for String {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let len = u16::decode(input)?;
+        let bytes = take(input, usize::from(len))?;
+        String::from_utf8(bytes.to_vec()).map_err(|_| DecodeError::InvalidString)
+    }
+}
+
+impl<T: Encode> Encode for Vec<T> {
+    fn encode(&self, out: &mut Vec<u8>) {
+        u32::try_from(self.len())
+            .expect("slice is too long")
+            .encode(out);
+        self.iter().for_each(|e| e.encode(out));
+    }
+}
+
+impl<T: Decode> Decode for Vec<T> {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let len = u32::decode(input)?;
+        (0..len).map(|_| T::decode(input)).collect()
+    }
+}
+
+impl<T: Encode, const N: usize> Encode for [T; N] {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.iter().for_each(|e| e.encode(out));
+    }
+}
+
+impl<T: Decode, const N: usize> Decode for [T; N] {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let elems = (0..N)
+            .map(|_| T::decode(input))
+            .collect::<Result<Vec<T>, _>>()?;
+        Ok(elems
+            .try_into()
+            .unwrap_or_else(|_| unreachable!("decoded {N} elements")))
+    }
+}
+
+/// Entries are encoded in the order of their encoded keys
+impl<K: Encode, V: Encode> Encode for BTreeMap<K, V> {
+    fn encode(&self, out: &mut Vec<u8>) {
+        let mut entries = self
+            .iter()
+            .map(|(k, v)| (to_bytes(k), v))
+            .collect::<Vec<_>>();
+        entries.sort_by(|a, b| a.0.cmp(&b.0));
+        u32::try_from(entries.len())
+            .expect("map is too long")
+            .encode(out);
+        for (key, value) in entries {
+            out.extend_from_slice(&key);
+            value.encode(out);
+        }
+    }
+}
+
+impl<K: Decode + Ord, V: Decode> Decode for BTreeMap<K, V> {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        let len = u32::decode(input)?;
+        let mut map = BTreeMap::new();
+        let mut prev_key: Option<&[u8]> = None;
+        for _ in 0..len {
+            let start = *input;
+            let key = K::decode(input)?;
+            let key_bytes = &start[..start.len() - input.len()];
+            if prev_key.is_some_and(|prev| prev >= key_bytes) {
+                return Err(DecodeError::UnsortedMap);
+            }
+            prev_key = Some(key_bytes);
+            map.insert(key, V::decode(input)?);
+        }
+        Ok(map)
+    }
+}
+
+impl Encode for BigInt {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.negative.encode(out);
+        self.abs.encode(out);
+    }
+}
+
+impl Decode for BigInt {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            negative: Decode::decode(input)?,
+            abs: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectSingleNumber {
+    pub field1: u16,
+}
+
+impl Encode for MockObjectSingleNumber {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.field1.encode(out);
+    }
+}
+
+impl Decode for MockObjectSingleNumber {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            field1: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockActionTransfer {
+    pub to: Address,
+    pub value: u64,
+    pub memo: Vec<u8>,
+}
+
+impl Encode for MockActionTransfer {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.to.encode(out);
+        self.value.encode(out);
+        self.memo.encode(out);
+    }
+}
+
+impl Decode for MockActionTransfer {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            to: Decode::decode(input)?,
+            value: Decode::decode(input)?,
+            memo: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectAllNumbers {
+    pub uint8: u8,
+    pub uint16: u16,
+    pub uint32: u32,
+    pub uint64: u64,
+    pub int8: i8,
+    pub int16: i16,
+    pub int32: i32,
+    pub int64: i64,
+}
+
+impl Encode for MockObjectAllNumbers {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.uint8.encode(out);
+        self.uint16.encode(out);
+        self.uint32.encode(out);
+        self.uint64.encode(out);
+        self.int8.encode(out);
+        self.int16.encode(out);
+        self.int32.encode(out);
+        self.int64.encode(out);
+    }
+}
+
+impl Decode for MockObjectAllNumbers {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            uint8: Decode::decode(input)?,
+            uint16: Decode::decode(input)?,
+            uint32: Decode::decode(input)?,
+            uint64: Decode::decode(input)?,
+            int8: Decode::decode(input)?,
+            int16: Decode::decode(input)?,
+            int32: Decode::decode(input)?,
+            int64: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectStringAndBytes {
+    pub field1: String,
+    pub field2: Vec<u8>,
+}
+
+impl Encode for MockObjectStringAndBytes {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.field1.encode(out);
+        self.field2.encode(out);
+    }
+}
+
+impl Decode for MockObjectStringAndBytes {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            field1: Decode::decode(input)?,
+            field2: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectArrays {
+    pub strings: Vec<String>,
+    pub bytes: Vec<Vec<u8>>,
+    pub uint8s: Vec<u8>,
+    pub uint16s: Vec<u16>,
+    pub uint32s: Vec<u32>,
+    pub uint64s: Vec<u64>,
+    pub int8s: Vec<i8>,
+    pub int16s: Vec<i16>,
+    pub int32s: Vec<i32>,
+    pub int64s: Vec<i64>,
+}
+
+impl Encode for MockObjectArrays {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.strings.encode(out);
+        self.bytes.encode(out);
+        self.uint8s.encode(out);
+        self.uint16s.encode(out);
+        self.uint32s.encode(out);
+        self.uint64s.encode(out);
+        self.int8s.encode(out);
+        self.int16s.encode(out);
+        self.int32s.encode(out);
+        self.int64s.encode(out);
+    }
+}
+
+impl Decode for MockObjectArrays {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            strings: Decode::decode(input)?,
+            bytes: Decode::decode(input)?,
+            uint8s: Decode::decode(input)?,
+            uint16s: Decode::decode(input)?,
+            uint32s: Decode::decode(input)?,
+            uint64s: Decode::decode(input)?,
+            int8s: Decode::decode(input)?,
+            int16s: Decode::decode(input)?,
+            int32s: Decode::decode(input)?,
+            int64s: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockActionWithTransfer {
+    pub transfer: MockActionTransfer,
+}
+
+impl Encode for MockActionWithTransfer {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.transfer.encode(out);
+    }
+}
+
+impl Decode for MockActionWithTransfer {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            transfer: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockActionWithTransferArray {
+    pub transfers: Vec<MockActionTransfer>,
+}
+
+impl Encode for MockActionWithTransferArray {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.transfers.encode(out);
+    }
+}
+
+impl Decode for MockActionWithTransferArray {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            transfers: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct Outer {
+    pub inner: Inner,
+    pub inner_arr: Vec<Inner>,
+}
+
+impl Encode for Outer {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.inner.encode(out);
+        self.inner_arr.encode(out);
+    }
+}
+
+impl Decode for Outer {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            inner: Decode::decode(input)?,
+            inner_arr: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct Inner {
+    pub field1: u8,
+}
+
+impl Encode for Inner {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.field1.encode(out);
+    }
+}
+
+impl Decode for Inner {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            field1: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct ActionWithOutput {
+    pub field1: u8,
+}
+
+impl Encode for ActionWithOutput {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.field1.encode(out);
+    }
+}
+
+impl Decode for ActionWithOutput {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            field1: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct FixedBytes {
+    pub two_bytes: [u8; 2],
+    pub thirty_two_bytes: [u8; 32],
+}
+
+impl Encode for FixedBytes {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.two_bytes.encode(out);
+        self.thirty_two_bytes.encode(out);
+    }
+}
+
+impl Decode for FixedBytes {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            two_bytes: Decode::decode(input)?,
+            thirty_two_bytes: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct Bools {
+    pub bool1: bool,
+    pub bool2: bool,
+    pub bool_array: Vec<bool>,
+}
+
+impl Encode for Bools {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.bool1.encode(out);
+        self.bool2.encode(out);
+        self.bool_array.encode(out);
+    }
+}
+
+impl Decode for Bools {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            bool1: Decode::decode(input)?,
+            bool2: Decode::decode(input)?,
+            bool_array: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectMaps {
+    pub balances: BTreeMap<Address, u64>,
+    pub inners: BTreeMap<String, Inner>,
+}
+
+impl Encode for MockObjectMaps {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.balances.encode(out);
+        self.inners.encode(out);
+    }
+}
+
+impl Decode for MockObjectMaps {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            balances: Decode::decode(input)?,
+            inners: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectPointers {
+    pub inner: Inner,
+    pub value: u64,
+}
+
+impl Encode for MockObjectPointers {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.inner.encode(out);
+        self.value.encode(out);
+    }
+}
+
+impl Decode for MockObjectPointers {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            inner: Decode::decode(input)?,
+            value: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectIDs {
+    pub asset_id: Id,
+    pub hash: [u8; 32],
+    pub asset_i_ds: Vec<Id>,
+}
+
+impl Encode for MockObjectIDs {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.asset_id.encode(out);
+        self.hash.encode(out);
+        self.asset_i_ds.encode(out);
+    }
+}
+
+impl Decode for MockObjectIDs {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            asset_id: Decode::decode(input)?,
+            hash: Decode::decode(input)?,
+            asset_i_ds: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct MockObjectBigInts {
+    pub amount: BigInt,
+    pub amounts: Vec<BigInt>,
+}
+
+impl Encode for MockObjectBigInts {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.amount.encode(out);
+        self.amounts.encode(out);
+    }
+}
+
+impl Decode for MockObjectBigInts {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            amount: Decode::decode(input)?,
+            amounts: Decode::decode(input)?,
+        })
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct ActionOutput {
+    pub field1: u16,
+}
+
+impl Encode for ActionOutput {
+    fn encode(&self, out: &mut Vec<u8>) {
+        self.field1.encode(out);
+    }
+}
+
+impl Decode for ActionOutput {
+    fn decode(input: &mut &[u8]) -> Result<Self, DecodeError> {
+        Ok(Self {
+            field1: Decode::decode(input)?,
+        })
+    }
+}
+
+pub mod action_type_ids {
+    pub const MOCK_OBJECT_SINGLE_NUMBER: u8 = 0;
+    pub const MOCK_ACTION_TRANSFER: u8 = 1;
+    pub const MOCK_OBJECT_ALL_NUMBERS: u8 = 2;
+    pub const MOCK_OBJECT_STRING_AND_BYTES: u8 = 3;
+    pub const MOCK_OBJECT_ARRAYS: u8 = 4;
+    pub const MOCK_ACTION_WITH_TRANSFER: u8 = 5;
+    pub const MOCK_ACTION_WITH_TRANSFER_ARRAY: u8 = 6;
+    pub const OUTER: u8 = 7;
+    pub const ACTION_WITH_OUTPUT: u8 = 8;
+    pub const FIXED_BYTES: u8 = 9;
+    pub const BOOLS: u8 = 10;
+    pub const MOCK_OBJECT_MAPS: u8 = 11;
+    pub const MOCK_OBJECT_POINTERS: u8 = 12;
+    pub const MOCK_OBJECT_I_DS: u8 = 13;
+    pub const MOCK_OBJECT_BIG_INTS: u8 = 14;
+}
+
+pub mod output_type_ids {
+    pub const ACTION_OUTPUT: u8 = 0;
+}
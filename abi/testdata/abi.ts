@@ -0,0 +1,645 @@
+// Code generated by abigen. DO NOT EDIT.
+
+export const ADDRESS_LEN = 33;
+export const ID_LEN = 32;
+
+const textEncoder = new TextEncoder();
+const textDecoder = new TextDecoder("utf-8", { fatal: true });
+
+function checkLength(actual: number, expected: number): void {
+  if (actual !== expected) {
+    throw new Error(`expected length ${expected} but got ${actual}`);
+  }
+}
+
+function compareBytes(a: Uint8Array, b: Uint8Array): number {
+  for (let i = 0; i < Math.min(a.length, b.length); i++) {
+    if (a[i] !== b[i]) {
+      return a[i] - b[i];
+    }
+  }
+  return a.length - b.length;
+}
+
+export class Writer {
+  private buf = new Uint8Array(256);
+  private view = new DataView(this.buf.buffer);
+  private offset = 0;
+
+  private grow(n: number): number {
+    const offset = this.offset;
+    if (offset + n > this.buf.length) {
+      const buf = new Uint8Array(Math.max(this.buf.length * 2, offset + n));
+      buf.set(this.buf);
+      this.buf = buf;
+      this.view = new DataView(buf.buffer);
+    }
+    this.offset += n;
+    return offset;
+  }
+
+  uint8(v: number): void {
+    this.view.setUint8(this.grow(1), v);
+  }
+
+  uint16(v: number): void {
+    this.view.setUint16(this.grow(2), v);
+  }
+
+  uint32(v: number): void {
+    this.view.setUint32(this.grow(4), v);
+  }
+
+  uint64(v: bigint): void {
+    this.view.setBigUint64(this.grow(8), v);
+  }
+
+  int8(v: number): void {
+    this.view.setInt8(this.grow(1), v);
+  }
+
+  int16(v: number): void {
+    this.view.setInt16(this.grow(2), v);
+  }
+
+  int32(v: number): void {
+    this.view.setInt32(this.grow(4), v);
+  }
+
+  int64(v: bigint): void {
+    this.view.setBigInt64(this.grow(8), v);
+  }
+
+  bool(v: boolean): void {
+    this.uint8(v ? 1 : 0);
+  }
+
+  string(v: string): void {
+    const bytes = textEncoder.encode(v);
+    if (bytes.length > 0xffff) {
+      throw new Error("string is too long");
+    }
+    this.uint16(bytes.length);
+    this.raw(bytes);
+  }
+
+  bytes(v: Uint8Array): void {
+    this.uint32(v.length);
+    this.raw(v);
+  }
+
+  fixedBytes(v: Uint8Array, len: number): void {
+    checkLength(v.length, len);
+    this.raw(v);
+  }
+
+  address(v: Uint8Array): void {
+    this.fixedBytes(v, ADDRESS_LEN);
+  }
+
+  id(v: Uint8Array): void {
+    this.fixedBytes(v, ID_LEN);
+  }
+
+  // bigint writes the sign of v followed by the big-endian bytes of its
+  // absolute value
+  bigint(v: bigint): void {
+    this.bool(v < 0n);
+    let hex = (v < 0n ? -v : v).toString(16);
+    if (hex === "0") {
+      hex = "";
+    } else if (hex.length % 2 === 1) {
+      hex = "0" + hex;
+    }
+    const abs = new Uint8Array(hex.length / 2);
+    for (let i = 0; i < abs.length; i++) {
+      abs[i] = parseInt(hex.slice(i * 2, i * 2 + 2), 16);
+    }
+    this.bytes(abs);
+  }
+
+  array<T>(v: T[], encode: (w: Writer, e: T) => void): void {
+    this.uint32(v.length);
+    v.forEach((e) => encode(this, e));
+  }
+
+  fixedArray<T>(v: T[], len: number, encode: (w: Writer, e: T) => void): void {
+    checkLength(v.length, len);
+    v.forEach((e) => encode(this, e));
+  }
+
+  // map writes the entries of v sorted by their encoded keys
+  map<K, V>(
+    v: Map<K, V>,
+    encodeKey: (w: Writer, k: K) => void,
+    encodeValue: (w: Writer, e: V) => void,
+  ): void {
+    const entries = [...v.entries()].map(([k, e]) => {
+      const w = new Writer();
+      encodeKey(w, k);
+      return { key: w.finish(), value: e };
+    });
+    entries.sort((a, b) => compareBytes(a.key, b.key));
+    this.uint32(entries.length);
+    entries.forEach(({ key, value }, i) => {
+      if (i > 0 && compareBytes(entries[i - 1].key, key) === 0) {
+        throw new Error("duplicate map key");
+      }
+      this.raw(key);
+      encodeValue(this, value);
+    });
+  }
+
+  raw(v: Uint8Array): void {
+    this.buf.set(v, this.grow(v.length));
+  }
+
+  finish(): Uint8Array {
+    return this.buf.slice(0, this.offset);
+  }
+}
+
+export class Reader {
+  private readonly view: DataView;
+  private offset = 0;
+
+  constructor(private readonly buf: Uint8Array) {
+    this.view = new DataView(buf.buffer, buf.byteOffset, buf.byteLength);
+  }
+
+  private take(n: number): number {
+    if (this.offset + n > this.buf.length) {
+      throw new Error("unexpected end of input");
+    }
+    const offset = this.offset;
+    this.offset += n;
+    return offset;
+  }
+
+  uint8(): number {
+    return this.view.getUint8(this.take(1));
+  }
+
+  uint16(): number {
+    return this.view.getUint16(this.take(2));
+  }
+
+  uint32(): number {
+    return this.view.getUint32(this.take(4));
+  }
+
+  uint64(): bigint {
+    return this.view.getBigUint64(this.take(8));
+  }
+
+  int8(): number {
+    return this.view.getInt8(this.take(1));
+  }
+
+  int16(): number {
+    return this.view.getInt16(this.take(2));
+  }
+
+  int32(): number {
+    return this.view.getInt32(this.take(4));
+  }
+
+  int64(): bigint {
+    return this.view.getBigInt64(this.take(8));
+  }
+
+  bool(): boolean {
+    const v = this.uint8();
+    if (v > 1) {
+      throw new Error(`invalid bool ${v}`);
+    }
+    return v === 1;
+  }
+
+  string(): string {
+    return textDecoder.decode(this.raw(this.uint16()));
+  }
+
+  bytes(): Uint8Array {
+    return this.raw(this.uint32());
+  }
+
+  fixedBytes(len: number): Uint8Array {
+    return this.raw(len);
+  }
+
+  address(): Uint8Array {
+    return this.fixedBytes(ADDRESS_LEN);
+  }
+
+  id(): Uint8Array {
+    return this.fixedBytes(ID_LEN);
+  }
+
+  bigint(): bigint {
+    const negative = this.bool();
+    let v = 0n;
+    for (const b of this.bytes()) {
+      v = (v << 8n) | BigInt(b);
+    }
+    return negative ? -v : v;
+  }
+
+  array<T>(decode: (r: Reader) => T): T[] {
+    return this.fixedArray(this.uint32(), decode);
+  }
+
+  fixedArray<T>(len: number, decode: (r: Reader) => T): T[] {
+    const v: T[] = [];
+    for (let i = 0; i < len; i++) {
+      v.push(decode(this));
+    }
+    return v;
+  }
+
+  // map reads entries that must be sorted by their encoded keys
+  map<K, V>(decodeKey: (r: Reader) => K, decodeValue: (r: Reader) => V): Map<K, V> {
+    const len = this.uint32();
+    const v = new Map<K, V>();
+    let prevKey: Uint8Array | undefined;
+    for (let i = 0; i < len; i++) {
+      const start = this.offset;
+      const key = decodeKey(this);
+      const keyBytes = this.buf.subarray(start, this.offset);
+      if (prevKey !== undefined && compareBytes(prevKey, keyBytes) >= 0) {
+        throw new Error("map keys are not sorted");
+      }
+      prevKey = keyBytes;
+      v.set(key, decodeValue(this));
+    }
+    return v;
+  }
+
+  raw(len: number): Uint8Array {
+    const offset = this.take(len);
+    return new Uint8Array(this.buf.subarray(offset, offset + len));
+  }
+
+  remaining(): number {
+    return this.buf.length - this.offset;
+  }
+}
+
+export function marshal<T>(encode: (w: Writer, v: T) => void, value: T): Uint8Array {
+  const w = new Writer();
+  encode(w, value);
+  return w.finish();
+}
+
+export function unmarshal<T>(decode: (r: Reader) => T, bytes: Uint8Array): T {
+  const r = new Reader(bytes);
+  const value = decode(r);
+  if (r.remaining() !== 0) {
+    throw new Error(`${r.remaining()} trailing bytes`);
+  }
+  return value;
+}
+
+export interface MockObjectSingleNumber {
+  Field1: number;
+}
+
+export function encodeMockObjectSingleNumber(w: Writer, v: MockObjectSingleNumber): void {
+  w.uint16(v.Field1);
+}
+
+export function decodeMockObjectSingleNumber(r: Reader): MockObjectSingleNumber {
+  return {
+    Field1: r.uint16(),
+  };
+}
+
+export interface MockActionTransfer {
+  to: Uint8Array;
+  value: bigint;
+  memo: Uint8Array;
+}
+
+export function encodeMockActionTransfer(w: Writer, v: MockActionTransfer): void {
+  w.address(v.to);
+  w.uint64(v.value);
+  w.bytes(v.memo);
+}
+
+export function decodeMockActionTransfer(r: Reader): MockActionTransfer {
+  return {
+    to: r.address(),
+    value: r.uint64(),
+    memo: r.bytes(),
+  };
+}
+
+export interface MockObjectAllNumbers {
+  uint8: number;
+  uint16: number;
+  uint32: number;
+  uint64: bigint;
+  int8: number;
+  int16: number;
+  int32: number;
+  int64: bigint;
+}
+
+export function encodeMockObjectAllNumbers(w: Writer, v: MockObjectAllNumbers): void {
+  w.uint8(v.uint8);
+  w.uint16(v.uint16);
+  w.uint32(v.uint32);
+  w.uint64(v.uint64);
+  w.int8(v.int8);
+  w.int16(v.int16);
+  w.int32(v.int32);
+  w.int64(v.int64);
+}
+
+export function decodeMockObjectAllNumbers(r: Reader): MockObjectAllNumbers {
+  return {
+    uint8: r.uint8(),
+    uint16: r.uint16(),
+    uint32: r.uint32(),
+    uint64: r.uint64(),
+    int8: r.int8(),
+    int16: r.int16(),
+    int32: r.int32(),
+    int64: r.int64(),
+  };
+}
+
+export interface MockObjectStringAndBytes {
+  field1: string;
+  field2: Uint8Array;
+}
+
+export function encodeMockObjectStringAndBytes(w: Writer, v: MockObjectStringAndBytes): void {
+  w.string(v.field1);
+  w.bytes(v.field2);
+}
+
+export function decodeMockObjectStringAndBytes(r: Reader): MockObjectStringAndBytes {
+  return {
+    field1: r.string(),
+    field2: r.bytes(),
+  };
+}
+
+export interface MockObjectArrays {
+  strings: string[];
+  bytes: Uint8Array[];
+  uint8s: Uint8Array;
+  uint16s: number[];
+  uint32s: number[];
+  uint64s: bigint[];
+  int8s: number[];
+  int16s: number[];
+  int32s: number[];
+  int64s: bigint[];
+}
+
+export function encodeMockObjectArrays(w: Writer, v: MockObjectArrays): void {
+  w.array(v.strings, (w, e0) => w.string(e0));
+  w.array(v.bytes, (w, e0) => w.bytes(e0));
+  w.bytes(v.uint8s);
+  w.array(v.uint16s, (w, e0) => w.uint16(e0));
+  w.array(v.uint32s, (w, e0) => w.uint32(e0));
+  w.array(v.uint64s, (w, e0) => w.uint64(e0));
+  w.array(v.int8s, (w, e0) => w.int8(e0));
+  w.array(v.int16s, (w, e0) => w.int16(e0));
+  w.array(v.int32s, (w, e0) => w.int32(e0));
+  w.array(v.int64s, (w, e0) => w.int64(e0));
+}
+
+export function decodeMockObjectArrays(r: Reader): MockObjectArrays {
+  return {
+    strings: r.array((r) => r.string()),
+    bytes: r.array((r) => r.bytes()),
+    uint8s: r.bytes(),
+    uint16s: r.array((r) => r.uint16()),
+    uint32s: r.array((r) => r.uint32()),
+    uint64s: r.array((r) => r.uint64()),
+    int8s: r.array((r) => r.int8()),
+    int16s: r.array((r) => r.int16()),
+    int32s: r.array((r) => r.int32()),
+    int64s: r.array((r) => r.int64()),
+  };
+}
+
+export interface MockActionWithTransfer {
+  transfer: MockActionTransfer;
+}
+
+export function encodeMockActionWithTransfer(w: Writer, v: MockActionWithTransfer): void {
+  encodeMockActionTransfer(w, v.transfer);
+}
+
+export function decodeMockActionWithTransfer(r: Reader): MockActionWithTransfer {
+  return {
+    transfer: decodeMockActionTransfer(r),
+  };
+}
+
+export interface MockActionWithTransferArray {
+  transfers: MockActionTransfer[];
+}
+
+export function encodeMockActionWithTransferArray(w: Writer, v: MockActionWithTransferArray): void {
+  w.array(v.transfers, (w, e0) => encodeMockActionTransfer(w, e0));
+}
+
+export function decodeMockActionWithTransferArray(r: Reader): MockActionWithTransferArray {
+  return {
+    transfers: r.array((r) => decodeMockActionTransfer(r)),
+  };
+}
+
+export interface Outer {
+  inner: Inner;
+  innerArr: Inner[];
+}
+
+export function encodeOuter(w: Writer, v: Outer): void {
+  encodeInner(w, v.inner);
+  w.array(v.innerArr, (w, e0) => encodeInner(w, e0));
+}
+
+export function decodeOuter(r: Reader): Outer {
+  return {
+    inner: decodeInner(r),
+    innerArr: r.array((r) => decodeInner(r)),
+  };
+}
+
+export interface Inner {
+  field1: number;
+}
+
+export function encodeInner(w: Writer, v: Inner): void {
+  w.uint8(v.field1);
+}
+
+export function decodeInner(r: Reader): Inner {
+  return {
+    field1: r.uint8(),
+  };
+}
+
+export interface ActionWithOutput {
+  field1: number;
+}
+
+export function encodeActionWithOutput(w: Writer, v: ActionWithOutput): void {
+  w.uint8(v.field1);
+}
+
+export function decodeActionWithOutput(r: Reader): ActionWithOutput {
+  return {
+    field1: r.uint8(),
+  };
+}
+
+export interface FixedBytes {
+  twoBytes: Uint8Array;
+  thirtyTwoBytes: Uint8Array;
+}
+
+export function encodeFixedBytes(w: Writer, v: FixedBytes): void {
+  w.fixedBytes(v.twoBytes, 2);
+  w.fixedBytes(v.thirtyTwoBytes, 32);
+}
+
+export function decodeFixedBytes(r: Reader): FixedBytes {
+  return {
+    twoBytes: r.fixedBytes(2),
+    thirtyTwoBytes: r.fixedBytes(32),
+  };
+}
+
+export interface Bools {
+  bool1: boolean;
+  bool2: boolean;
+  boolArray: boolean[];
+}
+
+export function encodeBools(w: Writer, v: Bools): void {
+  w.bool(v.bool1);
+  w.bool(v.bool2);
+  w.array(v.boolArray, (w, e0) => w.bool(e0));
+}
+
+export function decodeBools(r: Reader): Bools {
+  return {
+    bool1: r.bool(),
+    bool2: r.bool(),
+    boolArray: r.array((r) => r.bool()),
+  };
+}
+
+export interface MockObjectMaps {
+  balances: Map<Uint8Array, bigint>;
+  inners: Map<string, Inner>;
+}
+
+export function encodeMockObjectMaps(w: Writer, v: MockObjectMaps): void {
+  w.map(v.balances, (w, k0) => w.address(k0), (w, e0) => w.uint64(e0));
+  w.map(v.inners, (w, k0) => w.string(k0), (w, e0) => encodeInner(w, e0));
+}
+
+export function decodeMockObjectMaps(r: Reader): MockObjectMaps {
+  return {
+    balances: r.map((r) => r.address(), (r) => r.uint64()),
+    inners: r.map((r) => r.string(), (r) => decodeInner(r)),
+  };
+}
+
+export interface MockObjectPointers {
+  inner: Inner;
+  value: bigint;
+}
+
+export function encodeMockObjectPointers(w: Writer, v: MockObjectPointers): void {
+  encodeInner(w, v.inner);
+  w.uint64(v.value);
+}
+
+export function decodeMockObjectPointers(r: Reader): MockObjectPointers {
+  return {
+    inner: decodeInner(r),
+    value: r.uint64(),
+  };
+}
+
+export interface MockObjectIDs {
+  assetID: Uint8Array;
+  hash: Uint8Array;
+  assetIDs: Uint8Array[];
+}
+
+export function encodeMockObjectIDs(w: Writer, v: MockObjectIDs): void {
+  w.id(v.assetID);
+  w.fixedBytes(v.hash, 32);
+  w.array(v.assetIDs, (w, e0) => w.id(e0));
+}
+
+export function decodeMockObjectIDs(r: Reader): MockObjectIDs {
+  return {
+    assetID: r.id(),
+    hash: r.fixedBytes(32),
+    assetIDs: r.array((r) => r.id()),
+  };
+}
+
+export interface MockObjectBigInts {
+  amount: bigint;
+  amounts: bigint[];
+}
+
+export function encodeMockObjectBigInts(w: Writer, v: MockObjectBigInts): void {
+  w.bigint(v.amount);
+  w.array(v.amounts, (w, e0) => w.bigint(e0));
+}
+
+export function decodeMockObjectBigInts(r: Reader): MockObjectBigInts {
+  return {
+    amount: r.bigint(),
+    amounts: r.array((r) => r.bigint()),
+  };
+}
+
+export interface ActionOutput {
+  field1: number;
+}
+
+export function encodeActionOutput(w: Writer, v: ActionOutput): void {
+  w.uint16(v.field1);
+}
+
+export function decodeActionOutput(r: Reader): ActionOutput {
+  return {
+    field1: r.uint16(),
+  };
+}
+
+export const ActionTypeIDs = {
+  MockObjectSingleNumber: 0,
+  MockActionTransfer: 1,
+  MockObjectAllNumbers: 2,
+  MockObjectStringAndBytes: 3,
+  MockObjectArrays: 4,
+  MockActionWithTransfer: 5,
+  MockActionWithTransferArray: 6,
+  Outer: 7,
+  ActionWithOutput: 8,
+  FixedBytes: 9,
+  Bools: 10,
+  MockObjectMaps: 11,
+  MockObjectPointers: 12,
+  MockObjectIDs: 13,
+  MockObjectBigInts: 14,
+} as const;
+
+export const OutputTypeIDs = {
+  ActionOutput: 0,
+} as const;
@@ -35,6 +35,14 @@ type VM interface {
 		ctx context.Context,
 		txs []*chain.Transaction,
 	) (errs []error)
+	SubmitBundle(
+		ctx context.Context,
+		txs []*chain.Transaction,
+	) (errs []error)
+	SubmitPrivate(
+		ctx context.Context,
+		txs []*chain.Transaction,
+	) (errs []error)
 	LastAcceptedBlockResult() *chain.ExecutedBlock
 	UnitPrices(context.Context) (fees.Dimensions, error)
 	CurrentValidators(
@@ -5,6 +5,7 @@ package jsonrpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -22,6 +23,8 @@ import (
 
 const unitPricesCacheRefresh = 10 * time.Second
 
+var ErrABIMismatch = errors.New("ABI mismatch")
+
 type JSONRPCClient struct {
 	requester *requester.EndpointRequester
 
@@ -115,6 +118,32 @@ func (cli *JSONRPCClient) SubmitTx(ctx context.Context, d []byte) (ids.ID, error
 	return resp.TxID, err
 }
 
+// SubmitPrivateTx submits a tx that is not gossiped. It can only be included by
+// the node behind [cli] or by the peers it forwards private txs to.
+func (cli *JSONRPCClient) SubmitPrivateTx(ctx context.Context, d []byte) (ids.ID, error) {
+	resp := new(SubmitTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"submitTx",
+		&SubmitTxArgs{Tx: d, Private: true},
+		resp,
+	)
+	return resp.TxID, err
+}
+
+// SubmitBundle submits the signed members of a bundle, in the order they were
+// bound, and returns the ID of the bundle.
+func (cli *JSONRPCClient) SubmitBundle(ctx context.Context, txs [][]byte) (ids.ID, error) {
+	resp := new(SubmitBundleReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"submitBundle",
+		&SubmitBundleArgs{Txs: txs},
+		resp,
+	)
+	return resp.BundleID, err
+}
+
 type Modifier interface {
 	Base(*chain.Base)
 }
@@ -158,7 +187,7 @@ func (cli *JSONRPCClient) GenerateTransactionManual(
 	now := time.Now().UnixMilli()
 	rules := parser.Rules(now)
 	base := &chain.Base{
-		Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
+		Timestamp: utils.UnixRMilliModulus(now, rules.GetValidityWindow(), rules.GetTimestampModulus()),
 		ChainID:   rules.GetChainID(),
 		MaxFee:    maxFee,
 	}
@@ -193,6 +222,54 @@ func (cli *JSONRPCClient) GetABI(ctx context.Context) (abi.ABI, error) {
 	return resp.ABI, err
 }
 
+// GetContractABI returns the ABI published by the contract associated with
+// [contract]
+func (cli *JSONRPCClient) GetContractABI(ctx context.Context, contract codec.Address) (abi.ContractABI, error) {
+	resp := new(GetABIReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getABI",
+		&GetABIArgs{Contract: &contract},
+		resp,
+	)
+	if err != nil {
+		return abi.ContractABI{}, err
+	}
+	if resp.ContractABI == nil {
+		return abi.ContractABI{}, errContractABIsUnsupported
+	}
+	return *resp.ContractABI, nil
+}
+
+// GetABIHash returns the hash of the ABI used by the node
+func (cli *JSONRPCClient) GetABIHash(ctx context.Context) (ids.ID, error) {
+	resp := new(GetABIReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getABI",
+		nil,
+		resp,
+	)
+	return resp.Hash, err
+}
+
+// CheckABI returns ErrABIMismatch if the node does not use [expected]. Clients
+// should call it before signing transactions encoded with [expected].
+func (cli *JSONRPCClient) CheckABI(ctx context.Context, expected abi.ABI) error {
+	expectedHash, err := expected.Hash()
+	if err != nil {
+		return err
+	}
+	hash, err := cli.GetABIHash(ctx)
+	if err != nil {
+		return err
+	}
+	if hash != expectedHash {
+		return fmt.Errorf("%w: node has %s, expected %s", ErrABIMismatch, hash, expectedHash)
+	}
+	return nil
+}
+
 func (cli *JSONRPCClient) ExecuteActions(ctx context.Context, actor codec.Address, actionsBytes [][]byte) ([][]byte, error) {
 	args := &ExecuteActionArgs{
 		Actor:   actor,
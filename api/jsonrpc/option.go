@@ -21,10 +21,16 @@ func NewDefaultConfig() Config {
 }
 
 func With() vm.Option {
+	return WithContractABIs(nil)
+}
+
+// WithContractABIs enables the JSON-RPC API and serves the ABIs of contracts
+// from [contractABIs] in GetABI
+func WithContractABIs(contractABIs ContractABIReader) vm.Option {
 	return vm.NewOption(Namespace, NewDefaultConfig(), func(_ api.VM, config Config) (vm.Opt, error) {
 		if !config.Enabled {
 			return vm.NewOpt(), nil
 		}
-		return vm.WithVMAPIs(JSONRPCServerFactory{}), nil
+		return vm.WithVMAPIs(JSONRPCServerFactory{ContractABIs: contractABIs}), nil
 	})
 }
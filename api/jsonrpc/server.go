@@ -4,6 +4,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -31,14 +32,24 @@ var errNoActionsToExecute = errors.New("no actions to execute")
 var _ api.HandlerFactory[api.VM] = (*JSONRPCServerFactory)(nil)
 
 var (
-	errSimulateZeroActions   = errors.New("simulateAction expects at least a single action, none found")
-	errTransactionExtraBytes = errors.New("transaction has extra bytes")
+	errSimulateZeroActions     = errors.New("simulateAction expects at least a single action, none found")
+	errTransactionExtraBytes   = errors.New("transaction has extra bytes")
+	errContractABIsUnsupported = errors.New("vm does not support contract abis")
 )
 
-type JSONRPCServerFactory struct{}
+// ContractABIReader returns the ABI published by the contract associated with
+// [account]
+type ContractABIReader func(ctx context.Context, vm api.VM, account codec.Address) (abi.ContractABI, error)
 
-func (JSONRPCServerFactory) New(vm api.VM) (api.Handler, error) {
-	handler, err := api.NewJSONRPCHandler(api.Name, NewJSONRPCServer(vm))
+type JSONRPCServerFactory struct {
+	// ContractABIs is nil if the VM does not support contracts
+	ContractABIs ContractABIReader
+}
+
+func (f JSONRPCServerFactory) New(vm api.VM) (api.Handler, error) {
+	server := NewJSONRPCServer(vm)
+	server.contractABIs = f.ContractABIs
+	handler, err := api.NewJSONRPCHandler(api.Name, server)
 	if err != nil {
 		return api.Handler{}, err
 	}
@@ -50,11 +61,12 @@ func (JSONRPCServerFactory) New(vm api.VM) (api.Handler, error) {
 }
 
 type JSONRPCServer struct {
-	vm api.VM
+	vm           api.VM
+	contractABIs ContractABIReader
 }
 
 func NewJSONRPCServer(vm api.VM) *JSONRPCServer {
-	return &JSONRPCServer{vm}
+	return &JSONRPCServer{vm: vm}
 }
 
 type PingReply struct {
@@ -82,6 +94,10 @@ func (j *JSONRPCServer) Network(_ *http.Request, _ *struct{}, reply *NetworkRepl
 
 type SubmitTxArgs struct {
 	Tx []byte `json:"tx"`
+	// Private txs are not gossiped. They are only forwarded to the peers
+	// configured by the node and can only be included by the node or those
+	// peers.
+	Private bool `json:"private,omitempty"`
 }
 
 type SubmitTxReply struct {
@@ -107,9 +123,58 @@ func (j *JSONRPCServer) SubmitTx(
 	}
 	txID := tx.ID()
 	reply.TxID = txID
+	if args.Private {
+		return j.vm.SubmitPrivate(ctx, []*chain.Transaction{tx})[0]
+	}
 	return j.vm.Submit(ctx, []*chain.Transaction{tx})[0]
 }
 
+type SubmitBundleArgs struct {
+	Txs [][]byte `json:"txs"`
+}
+
+type SubmitBundleReply struct {
+	BundleID ids.ID   `json:"bundleId"`
+	TxIDs    []ids.ID `json:"txIds"`
+}
+
+// SubmitBundle submits a group of transactions that must be included
+// contiguously and atomically in a block. The transactions must be provided in
+// the order they were bound with [chain.BindBundle]. No transaction is added
+// to the mempool unless every member of the bundle is valid.
+func (j *JSONRPCServer) SubmitBundle(
+	req *http.Request,
+	args *SubmitBundleArgs,
+	reply *SubmitBundleReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.SubmitBundle")
+	defer span.End()
+
+	actionCodec, authCodec := j.vm.ActionCodec(), j.vm.AuthCodec()
+	txs := make([]*chain.Transaction, len(args.Txs))
+	for i, raw := range args.Txs {
+		rtx := codec.NewReader(raw, consts.NetworkSizeLimit) // will likely be much smaller than this
+		tx, err := chain.UnmarshalTx(rtx, actionCodec, authCodec)
+		if err != nil {
+			return fmt.Errorf("%w: unable to unmarshal on public service", err)
+		}
+		if !rtx.Empty() {
+			return errTransactionExtraBytes
+		}
+		txs[i] = tx
+	}
+	bundleID, err := chain.VerifyBundle(txs)
+	if err != nil {
+		return err
+	}
+	reply.BundleID = bundleID
+	reply.TxIDs = make([]ids.ID, len(txs))
+	for i, tx := range txs {
+		reply.TxIDs[i] = tx.ID()
+	}
+	return errors.Join(j.vm.SubmitBundle(ctx, txs)...)
+}
+
 type LastAcceptedReply struct {
 	Height    uint64 `json:"height"`
 	BlockID   ids.ID `json:"blockId"`
@@ -144,19 +209,45 @@ func (j *JSONRPCServer) UnitPrices(
 	return nil
 }
 
-type GetABIArgs struct{}
+type GetABIArgs struct {
+	// Contract is the address of a contract to return the ABI of, in addition
+	// to the ABI of the VM
+	Contract *codec.Address `json:"contract,omitempty"`
+}
 
 type GetABIReply struct {
-	ABI abi.ABI `json:"abi"`
+	ABI  abi.ABI `json:"abi"`
+	Hash ids.ID  `json:"hash"`
+	// ContractABI is only set if [GetABIArgs.Contract] is provided
+	ContractABI *abi.ContractABI `json:"contractAbi,omitempty"`
 }
 
-func (j *JSONRPCServer) GetABI(_ *http.Request, _ *GetABIArgs, reply *GetABIReply) error {
+func (j *JSONRPCServer) GetABI(req *http.Request, args *GetABIArgs, reply *GetABIReply) error {
+	if args != nil && args.Contract != nil {
+		if j.contractABIs == nil {
+			return errContractABIsUnsupported
+		}
+		ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetABI")
+		defer span.End()
+
+		contractABI, err := j.contractABIs(ctx, j.vm, *args.Contract)
+		if err != nil {
+			return err
+		}
+		reply.ContractABI = &contractABI
+	}
+
 	actionCodec, outputCodec := j.vm.ActionCodec(), j.vm.OutputCodec()
 	vmABI, err := abi.NewABI(actionCodec.GetRegisteredTypes(), outputCodec.GetRegisteredTypes())
 	if err != nil {
 		return err
 	}
+	hash, err := vmABI.Hash()
+	if err != nil {
+		return err
+	}
 	reply.ABI = vmABI
+	reply.Hash = hash
 	return nil
 }
 
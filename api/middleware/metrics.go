@@ -0,0 +1,28 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package middleware
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	namespaceLabel = "namespace"
+	methodLabel    = "method"
+	reasonLabel    = "reason"
+)
+
+// Metrics are shared by the middlewares of all service namespaces
+type Metrics struct {
+	rejected *prometheus.CounterVec
+}
+
+func NewMetrics(r prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "middleware",
+			Name:      "rejected_calls",
+			Help:      "number of api calls rejected by service namespace, method and reason",
+		}, []string{namespaceLabel, methodLabel, reasonLabel}),
+	}
+	return m, r.Register(m.rejected)
+}
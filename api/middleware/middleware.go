@@ -0,0 +1,276 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultCost is the cost of a call to a method without a configured
+	// cost
+	defaultCost = 1
+	// otherMethod is reported for calls to methods without a configured cost,
+	// which bounds the cardinality of the metrics
+	otherMethod = "other"
+)
+
+const (
+	authorizationHeader  = "Authorization"
+	bearerPrefix         = "Bearer "
+	authenticateHeader   = "WWW-Authenticate"
+	authenticateResponse = "Bearer"
+)
+
+// Reasons reported for rejected calls
+const (
+	reasonUnauthorized  = "unauthorized"
+	reasonIPLimited     = "ip_limited"
+	reasonAPIKeyLimited = "api_key_limited"
+)
+
+var (
+	ErrCostExceedsBurst = errors.New("method cost exceeds burst")
+	ErrInvalidMaxIPs    = errors.New("max tracked IPs must be positive")
+)
+
+// BucketConfig configures a token bucket. A bucket with a rate of 0 allows
+// every call.
+type BucketConfig struct {
+	Rate  float64 `json:"rate"` // tokens per second
+	Burst int     `json:"burst"`
+}
+
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// IPLimit limits the calls from each IP without an API key
+	IPLimit BucketConfig `json:"ipLimit"`
+	// APIKeyLimit limits the calls made with each API key
+	APIKeyLimit BucketConfig `json:"apiKeyLimit"`
+	// APIKeys are accepted as bearer tokens in the Authorization header
+	APIKeys []string `json:"apiKeys"`
+	// RequireAPIKey rejects calls without a valid API key
+	RequireAPIKey bool `json:"requireAPIKey"`
+	// MethodCosts are the tokens consumed by each JSON-RPC method, keyed by
+	// the method the call is dispatched to (e.g. hypersdk.SubmitTx for calls
+	// to hypersdk.submitTx). Methods that are not included cost 1.
+	MethodCosts map[string]int `json:"methodCosts"`
+	// MaxTrackedIPs is the number of IPs to keep token buckets for
+	MaxTrackedIPs int `json:"maxTrackedIPs"`
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Enabled:     true,
+		IPLimit:     BucketConfig{Rate: 50, Burst: 100},
+		APIKeyLimit: BucketConfig{Rate: 500, Burst: 1_000},
+		MethodCosts: map[string]int{
+			"hypersdk.SubmitTx":        2,
+			"hypersdk.SubmitBundle":    4,
+			"hypersdk.ExecuteActions":  10,
+			"hypersdk.SimulateActions": 20,
+		},
+		MaxTrackedIPs: 4_096,
+	}
+}
+
+// ParseConfig returns the config under the "middleware" key of the config
+// of a service namespace. If there is no such key, the returned config is
+// disabled.
+func ParseConfig(serviceConfig []byte) (Config, error) {
+	if len(serviceConfig) == 0 {
+		return Config{}, nil
+	}
+	var namespaceConfig struct {
+		Middleware json.RawMessage `json:"middleware"`
+	}
+	if err := json.Unmarshal(serviceConfig, &namespaceConfig); err != nil {
+		return Config{}, fmt.Errorf("failed to unmarshal service config: %w", err)
+	}
+	if len(namespaceConfig.Middleware) == 0 {
+		return Config{}, nil
+	}
+	config := NewDefaultConfig()
+	if err := json.Unmarshal(namespaceConfig.Middleware, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to unmarshal middleware config %q: %w", string(namespaceConfig.Middleware), err)
+	}
+	return config, nil
+}
+
+// Middleware authenticates and rate limits the calls to the handlers of a
+// service namespace
+type Middleware struct {
+	namespace string
+	config    Config
+	metrics   *Metrics
+
+	apiKeys map[string]*rate.Limiter
+
+	l   sync.Mutex
+	ips *cache.LRU[string, *rate.Limiter]
+}
+
+func New(namespace string, config Config, metrics *Metrics) (*Middleware, error) {
+	if config.MaxTrackedIPs <= 0 {
+		return nil, ErrInvalidMaxIPs
+	}
+	for method, cost := range config.MethodCosts {
+		if exceedsBurst(config.IPLimit, cost) || exceedsBurst(config.APIKeyLimit, cost) {
+			return nil, fmt.Errorf("%w: %s costs %d", ErrCostExceedsBurst, method, cost)
+		}
+	}
+	m := &Middleware{
+		namespace: namespace,
+		config:    config,
+		metrics:   metrics,
+		apiKeys:   make(map[string]*rate.Limiter, len(config.APIKeys)),
+		ips:       &cache.LRU[string, *rate.Limiter]{Size: config.MaxTrackedIPs},
+	}
+	for _, apiKey := range config.APIKeys {
+		m.apiKeys[apiKey] = newLimiter(config.APIKeyLimit)
+	}
+	return m, nil
+}
+
+// Wrap returns [handler] behind the middleware
+func (m *Middleware) Wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := normalizeMethod(readMethod(r))
+		cost, ok := m.config.MethodCosts[method]
+		if !ok {
+			cost = defaultCost
+			method = otherMethod
+		}
+
+		limiter, ok := m.apiKeyLimiter(r)
+		switch {
+		case !ok:
+			m.reject(w, method, reasonUnauthorized)
+			return
+		case limiter != nil:
+			if !limiter.AllowN(time.Now(), cost) {
+				m.reject(w, method, reasonAPIKeyLimited)
+				return
+			}
+		case !m.ipLimiter(r).AllowN(time.Now(), cost):
+			m.reject(w, method, reasonIPLimited)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// apiKeyLimiter returns the limiter of the API key of [r], or nil if [r]
+// has no API key. It returns false if [r] is not authorized.
+func (m *Middleware) apiKeyLimiter(r *http.Request) (*rate.Limiter, bool) {
+	header := r.Header.Get(authorizationHeader)
+	if len(header) == 0 {
+		return nil, !m.config.RequireAPIKey
+	}
+	token, ok := strings.CutPrefix(header, bearerPrefix)
+	if !ok {
+		return nil, false
+	}
+	for apiKey, limiter := range m.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(token)) == 1 {
+			return limiter, true
+		}
+	}
+	return nil, false
+}
+
+func (m *Middleware) ipLimiter(r *http.Request) *rate.Limiter {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	limiter, ok := m.ips.Get(ip)
+	if !ok {
+		limiter = newLimiter(m.config.IPLimit)
+		m.ips.Put(ip, limiter)
+	}
+	return limiter
+}
+
+func (m *Middleware) reject(w http.ResponseWriter, method string, reason string) {
+	m.metrics.rejected.WithLabelValues(m.namespace, method, reason).Inc()
+	if reason == reasonUnauthorized {
+		w.Header().Set(authenticateHeader, authenticateResponse)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+}
+
+// readMethod returns the JSON-RPC method called by [r] without consuming its
+// body. Malformed requests are left for the handler to reject.
+func readMethod(r *http.Request) string {
+	if r.Body == nil || r.Method != http.MethodPost {
+		return ""
+	}
+	var (
+		buf     bytes.Buffer
+		request struct {
+			Method string `json:"method"`
+		}
+	)
+	_ = json.NewDecoder(io.TeeReader(r.Body, &buf)).Decode(&request)
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(&buf, r.Body),
+		Closer: r.Body,
+	}
+	return request.Method
+}
+
+// normalizeMethod returns the method a JSON-RPC call to [method] is dispatched
+// to. Like the avalanchego JSON codec, it uppercases the first letter after
+// the service name. Calls the codec rejects (because the method already starts
+// with an uppercase letter) return an empty method.
+func normalizeMethod(method string) string {
+	service, function, ok := strings.Cut(method, ".")
+	if !ok {
+		return method
+	}
+	firstRune, runeLen := utf8.DecodeRuneInString(function)
+	if firstRune == utf8.RuneError {
+		return method
+	}
+	if unicode.IsUpper(firstRune) {
+		return ""
+	}
+	return service + "." + string(unicode.ToUpper(firstRune)) + function[runeLen:]
+}
+
+func newLimiter(config BucketConfig) *rate.Limiter {
+	if config.Rate == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(config.Rate), config.Burst)
+}
+
+func exceedsBurst(config BucketConfig, cost int) bool {
+	return config.Rate != 0 && cost > config.Burst
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNamespace = "core"
+	testAPIKey    = "key"
+)
+
+func newTestMiddleware(t *testing.T, config Config) (*Middleware, http.Handler) {
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	m, err := New(testNamespace, config, metrics)
+	require.NoError(t, err)
+	// Echo the body to check it was not consumed by the middleware
+	return m, m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}))
+}
+
+func call(handler http.Handler, remoteAddr string, apiKey string, method string) *httptest.ResponseRecorder {
+	body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":{}}`
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.RemoteAddr = remoteAddr
+	if len(apiKey) > 0 {
+		r.Header.Set(authorizationHeader, bearerPrefix+apiKey)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestMiddlewareMethodCosts(t *testing.T) {
+	require := require.New(t)
+
+	config := NewDefaultConfig()
+	config.IPLimit = BucketConfig{Rate: 0.001, Burst: 25}
+	m, handler := newTestMiddleware(t, config)
+
+	// The body is passed to the handler
+	w := call(handler, "1.1.1.1:1", "", "hypersdk.simulateActions")
+	require.Equal(http.StatusOK, w.Code)
+	require.Contains(w.Body.String(), "hypersdk.simulateActions")
+
+	// Simulate costs more than ping
+	w = call(handler, "1.1.1.1:1", "", "hypersdk.simulateActions")
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	for i := 0; i < 5; i++ {
+		w = call(handler, "1.1.1.1:2", "", "hypersdk.ping")
+		require.Equal(http.StatusOK, w.Code)
+	}
+	w = call(handler, "1.1.1.1:2", "", "hypersdk.ping")
+	require.Equal(http.StatusTooManyRequests, w.Code)
+
+	// Each IP has its own bucket
+	w = call(handler, "2.2.2.2:1", "", "hypersdk.simulateActions")
+	require.Equal(http.StatusOK, w.Code)
+
+	require.Equal(1.0, testutil.ToFloat64(m.metrics.rejected.WithLabelValues(testNamespace, "hypersdk.SimulateActions", reasonIPLimited)))
+	require.Equal(1.0, testutil.ToFloat64(m.metrics.rejected.WithLabelValues(testNamespace, otherMethod, reasonIPLimited)))
+}
+
+func TestMiddlewareAPIKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		requireAPIKey  bool
+		apiKey         string
+		expectedCode   int
+		expectedReason string
+	}{
+		{
+			name:         "no api key",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "required api key",
+			requireAPIKey:  true,
+			expectedCode:   http.StatusUnauthorized,
+			expectedReason: reasonUnauthorized,
+		},
+		{
+			name:           "invalid api key",
+			apiKey:         "invalid",
+			expectedCode:   http.StatusUnauthorized,
+			expectedReason: reasonUnauthorized,
+		},
+		{
+			name:          "valid api key",
+			requireAPIKey: true,
+			apiKey:        testAPIKey,
+			expectedCode:  http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := NewDefaultConfig()
+			config.APIKeys = []string{testAPIKey}
+			config.RequireAPIKey = tt.requireAPIKey
+			m, handler := newTestMiddleware(t, config)
+
+			w := call(handler, "1.1.1.1:1", tt.apiKey, "hypersdk.ping")
+			require.Equal(tt.expectedCode, w.Code)
+			if len(tt.expectedReason) > 0 {
+				require.Equal(1.0, testutil.ToFloat64(m.metrics.rejected.WithLabelValues(testNamespace, otherMethod, tt.expectedReason)))
+			}
+		})
+	}
+}
+
+func TestMiddlewareAPIKeyLimit(t *testing.T) {
+	require := require.New(t)
+
+	config := NewDefaultConfig()
+	config.IPLimit = BucketConfig{Rate: 0.001, Burst: 20}
+	config.APIKeyLimit = BucketConfig{Rate: 0.001, Burst: 40}
+	config.APIKeys = []string{testAPIKey}
+	m, handler := newTestMiddleware(t, config)
+
+	// Calls with an API key are not limited by IP
+	require.Equal(http.StatusOK, call(handler, "1.1.1.1:1", "", "hypersdk.simulateActions").Code)
+	require.Equal(http.StatusOK, call(handler, "1.1.1.1:1", testAPIKey, "hypersdk.simulateActions").Code)
+	require.Equal(http.StatusOK, call(handler, "1.1.1.1:1", testAPIKey, "hypersdk.simulateActions").Code)
+	require.Equal(http.StatusTooManyRequests, call(handler, "2.2.2.2:1", testAPIKey, "hypersdk.simulateActions").Code)
+	require.Equal(1.0, testutil.ToFloat64(m.metrics.rejected.WithLabelValues(testNamespace, "hypersdk.SimulateActions", reasonAPIKeyLimited)))
+}
+
+func TestParseConfig(t *testing.T) {
+	require := require.New(t)
+
+	config, err := ParseConfig([]byte(`{"enabled":true}`))
+	require.NoError(err)
+	require.False(config.Enabled)
+
+	config, err = ParseConfig([]byte(`{"enabled":true,"middleware":{"requireAPIKey":true,"apiKeys":["key"]}}`))
+	require.NoError(err)
+	expected := NewDefaultConfig()
+	expected.RequireAPIKey = true
+	expected.APIKeys = []string{"key"}
+	require.Equal(expected, config)
+
+	_, err = New(testNamespace, Config{
+		IPLimit:       BucketConfig{Rate: 1, Burst: 1},
+		MethodCosts:   map[string]int{"hypersdk.SimulateActions": 2},
+		MaxTrackedIPs: 1,
+	}, nil)
+	require.ErrorIs(err, ErrCostExceedsBurst)
+}
+
+func TestNormalizeMethod(t *testing.T) {
+	tests := []struct {
+		method   string
+		expected string
+	}{
+		{
+			method:   "hypersdk.submitTx",
+			expected: "hypersdk.SubmitTx",
+		},
+		{
+			method:   "hypersdk.SubmitTx",
+			expected: "",
+		},
+		{
+			method:   "hypersdk.",
+			expected: "hypersdk.",
+		},
+		{
+			method:   "ping",
+			expected: "ping",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			require.Equal(t, tt.expected, normalizeMethod(tt.method))
+		})
+	}
+}
@@ -12,8 +12,21 @@ import (
 	"github.com/ava-labs/hypersdk/consts"
 )
 
+// BaseSize is the size of a [Base] without a nonce that is not part of a
+// bundle. Such a [Base] is encoded as it was before nonces and bundles were
+// added, so existing encoders and signed transactions remain valid.
 const BaseSize = consts.Uint64Len*2 + ids.IDLen
 
+// A [Base] with a nonce or a bundle is prefixed by a byte of flags that
+// indicate which optional fields follow [Base.MaxFee]. The legacy encoding
+// starts with a positive [Base.Timestamp], so its first byte never has
+// [extendedFlag] set.
+const (
+	extendedFlag byte = 1 << 7
+	nonceFlag    byte = 1 << 0
+	bundleFlag   byte = 1 << 1
+)
+
 type Base struct {
 	// Timestamp is the expiry of the transaction (inclusive). Once this time passes and the
 	// transaction is not included in a block, it is safe to regenerate it.
@@ -27,44 +40,121 @@ type Base struct {
 	//
 	// If the fee is too low to pay all fees, the transaction will be dropped.
 	MaxFee uint64 `json:"maxFee"`
+
+	// Nonce protects against replay attacks on chains that use nonce replay
+	// protection. It must be one more than the last nonce used by the sponsor of
+	// the transaction. On other chains, it must be zero.
+	Nonce uint64 `json:"nonce,omitempty"`
+
+	// Bundle is the ID of the bundle this transaction belongs to, if any. A
+	// bundled transaction is only valid when included contiguously with all
+	// other members of its bundle (see [BindBundle] and [VerifyBundle]).
+	Bundle ids.ID `json:"bundle"`
+
+	// BundleIndex is the position of the transaction in its bundle.
+	BundleIndex uint8 `json:"bundleIndex,omitempty"`
+
+	// BundleSize is the number of transactions in the bundle.
+	BundleSize uint8 `json:"bundleSize,omitempty"`
 }
 
 func (b *Base) Execute(r Rules, timestamp int64) error {
 	switch {
-	case b.Timestamp%consts.MillisecondsPerSecond != 0:
-		// TODO: make this modulus configurable
-		return fmt.Errorf("%w: timestamp=%d", ErrMisalignedTime, b.Timestamp)
+	case b.Timestamp%r.GetTimestampModulus() != 0:
+		return fmt.Errorf("%w: timestamp=%d modulus=%d", ErrMisalignedTime, b.Timestamp, r.GetTimestampModulus())
 	case b.Timestamp < timestamp: // tx: 100 block: 110
 		return ErrTimestampTooLate
 	case b.Timestamp > timestamp+r.GetValidityWindow(): // tx: 100 block 10
 		return ErrTimestampTooEarly
 	case b.ChainID != r.GetChainID():
 		return ErrInvalidChainID
+	case r.GetNonceReplayProtection() && b.Nonce == 0:
+		return ErrMissingNonce
+	case !r.GetNonceReplayProtection() && b.Nonce != 0:
+		return ErrUnexpectedNonce
 	default:
 		return nil
 	}
 }
 
-func (*Base) Size() int {
-	return BaseSize
+// Bundled returns true if the transaction is part of a bundle.
+func (b *Base) Bundled() bool {
+	return b.Bundle != ids.Empty
+}
+
+// flags returns the optional fields of the [Base] or 0 if it has none
+func (b *Base) flags() byte {
+	var flags byte
+	if b.Nonce != 0 {
+		flags |= nonceFlag
+	}
+	if b.Bundled() {
+		flags |= bundleFlag
+	}
+	return flags
+}
+
+func (b *Base) Size() int {
+	flags := b.flags()
+	if flags == 0 {
+		return BaseSize
+	}
+	size := consts.ByteLen + BaseSize
+	if flags&nonceFlag != 0 {
+		size += consts.Uint64Len
+	}
+	if flags&bundleFlag != 0 {
+		size += ids.IDLen + 2*consts.ByteLen
+	}
+	return size
 }
 
 func (b *Base) Marshal(p *codec.Packer) {
+	flags := b.flags()
+	if flags != 0 {
+		p.PackByte(extendedFlag | flags)
+	}
 	p.PackInt64(b.Timestamp)
 	p.PackID(b.ChainID)
 	p.PackUint64(b.MaxFee)
+	if flags&nonceFlag != 0 {
+		p.PackUint64(b.Nonce)
+	}
+	if flags&bundleFlag != 0 {
+		p.PackID(b.Bundle)
+		p.PackByte(b.BundleIndex)
+		p.PackByte(b.BundleSize)
+	}
 }
 
 // UnmarshalBase unmarshals a Base from packer.
 // Caller can assume packer errors are returned from the function.
+//
+// The alignment of [Base.Timestamp] depends on the [Rules] at the time of
+// execution, so it is verified by [Base.Execute].
 func UnmarshalBase(p *codec.Packer) (*Base, error) {
-	var base Base
-	base.Timestamp = p.UnpackInt64(true)
-	if base.Timestamp%consts.MillisecondsPerSecond != 0 {
-		// TODO: make this modulus configurable
-		return nil, fmt.Errorf("%w: timestamp=%d", ErrMisalignedTime, base.Timestamp)
+	var (
+		base  Base
+		flags byte
+	)
+	if b := p.Bytes(); p.Offset() < len(b) && b[p.Offset()]&extendedFlag != 0 {
+		flags = p.UnpackByte() &^ extendedFlag
+		// Each [Base] has a single encoding, so the flags must indicate at
+		// least one optional field
+		if flags == 0 || flags&^(nonceFlag|bundleFlag) != 0 {
+			return nil, fmt.Errorf("%w: invalid base flags %08b", ErrInvalidObject, flags)
+		}
 	}
+	base.Timestamp = p.UnpackInt64(true)
 	p.UnpackID(true, &base.ChainID)
 	base.MaxFee = p.UnpackUint64(true)
+	if flags&nonceFlag != 0 {
+		base.Nonce = p.UnpackUint64(true)
+	}
+	if flags&bundleFlag != 0 {
+		p.UnpackID(true, &base.Bundle)
+		base.BundleIndex = p.UnpackByte()
+		base.BundleSize = p.UnpackByte()
+	}
 	return &base, p.Err()
 }
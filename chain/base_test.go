@@ -0,0 +1,174 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain_test
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/genesis"
+)
+
+func TestBaseExecute(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	tests := []struct {
+		name        string
+		modulus     int64
+		nonces      bool
+		base        chain.Base
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			base: chain.Base{Timestamp: 2_000, ChainID: chainID},
+		},
+		{
+			name:        "misaligned time",
+			base:        chain.Base{Timestamp: 2_001, ChainID: chainID},
+			expectedErr: chain.ErrMisalignedTime,
+		},
+		{
+			name:    "sub-second modulus",
+			modulus: 100,
+			base:    chain.Base{Timestamp: 2_100, ChainID: chainID},
+		},
+		{
+			name:        "misaligned sub-second modulus",
+			modulus:     100,
+			base:        chain.Base{Timestamp: 2_150, ChainID: chainID},
+			expectedErr: chain.ErrMisalignedTime,
+		},
+		{
+			name:        "invalid chain ID",
+			base:        chain.Base{Timestamp: 2_000},
+			expectedErr: chain.ErrInvalidChainID,
+		},
+		{
+			name:        "unexpected nonce",
+			base:        chain.Base{Timestamp: 2_000, ChainID: chainID, Nonce: 1},
+			expectedErr: chain.ErrUnexpectedNonce,
+		},
+		{
+			name:   "valid nonce",
+			nonces: true,
+			base:   chain.Base{Timestamp: 2_000, ChainID: chainID, Nonce: 1},
+		},
+		{
+			name:        "missing nonce",
+			nonces:      true,
+			base:        chain.Base{Timestamp: 2_000, ChainID: chainID},
+			expectedErr: chain.ErrMissingNonce,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := genesis.NewDefaultRules()
+			rules.ChainID = chainID
+			rules.NonceReplayProtection = tt.nonces
+			if tt.modulus != 0 {
+				rules.TimestampModulus = tt.modulus
+			}
+			require.ErrorIs(t, tt.base.Execute(rules, 1_000), tt.expectedErr)
+		})
+	}
+}
+
+func TestBaseMarshal(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	bundleID := ids.GenerateTestID()
+	tests := []struct {
+		name         string
+		base         chain.Base
+		expectedSize int
+	}{
+		{
+			// Txs without a nonce or bundle keep the legacy encoding
+			name:         "legacy",
+			base:         chain.Base{Timestamp: 2_000, ChainID: chainID, MaxFee: 100},
+			expectedSize: chain.BaseSize,
+		},
+		{
+			name:         "nonce",
+			base:         chain.Base{Timestamp: 2_000, ChainID: chainID, MaxFee: 100, Nonce: 7},
+			expectedSize: consts.ByteLen + chain.BaseSize + consts.Uint64Len,
+		},
+		{
+			name:         "bundle",
+			base:         chain.Base{Timestamp: 2_000, ChainID: chainID, MaxFee: 100, Bundle: bundleID, BundleIndex: 1, BundleSize: 2},
+			expectedSize: consts.ByteLen + chain.BaseSize + ids.IDLen + 2*consts.ByteLen,
+		},
+		{
+			name:         "nonce and bundle",
+			base:         chain.Base{Timestamp: 2_000, ChainID: chainID, MaxFee: 100, Nonce: 7, Bundle: bundleID, BundleSize: 2},
+			expectedSize: consts.ByteLen + chain.BaseSize + consts.Uint64Len + ids.IDLen + 2*consts.ByteLen,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			p := codec.NewWriter(tt.base.Size(), consts.NetworkSizeLimit)
+			tt.base.Marshal(p)
+			require.NoError(p.Err())
+			require.Len(p.Bytes(), tt.expectedSize)
+			require.Equal(tt.expectedSize, tt.base.Size())
+
+			r := codec.NewReader(p.Bytes(), consts.NetworkSizeLimit)
+			unmarshaled, err := chain.UnmarshalBase(r)
+			require.NoError(err)
+			require.True(r.Empty())
+			require.Equal(&tt.base, unmarshaled)
+		})
+	}
+}
+
+func TestUnmarshalBaseLegacy(t *testing.T) {
+	require := require.New(t)
+
+	// A base encoded before nonces and bundles were added
+	base := chain.Base{Timestamp: 2_000, ChainID: ids.GenerateTestID(), MaxFee: 100}
+	p := codec.NewWriter(chain.BaseSize, consts.NetworkSizeLimit)
+	p.PackInt64(base.Timestamp)
+	p.PackID(base.ChainID)
+	p.PackUint64(base.MaxFee)
+	require.NoError(p.Err())
+
+	unmarshaled, err := chain.UnmarshalBase(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit))
+	require.NoError(err)
+	require.Equal(&base, unmarshaled)
+}
+
+func TestUnmarshalBaseInvalidFlags(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	tests := []struct {
+		name  string
+		flags byte
+	}{
+		{
+			// The legacy encoding must be used if there are no optional fields
+			name:  "no optional fields",
+			flags: 0x80,
+		},
+		{
+			name:  "unknown flag",
+			flags: 0x80 | 0x04,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := codec.NewWriter(0, consts.NetworkSizeLimit)
+			p.PackByte(tt.flags)
+			p.PackInt64(2_000)
+			p.PackID(chainID)
+			p.PackUint64(100)
+			_, err := chain.UnmarshalBase(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit))
+			require.ErrorIs(t, err, chain.ErrInvalidObject)
+		})
+	}
+}
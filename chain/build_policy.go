@@ -0,0 +1,120 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ BuildPolicy = (*DefaultBuildPolicy)(nil)
+
+// BuildPolicy decides which transactions the [Builder] attempts to include in
+// a block, how much of the block they may use, and when to stop building.
+//
+// The [Builder] remains responsible for the validity of the block: the
+// transactions returned by [BuildPolicy.Select] are only included if they
+// execute successfully and fit within the block's max units.
+//
+// A BuildPolicy is only called from a single goroutine at a time.
+//
+// The size of the batches streamed from the mempool and the preallocation of
+// the block's state view are deliberately not part of a BuildPolicy: they only
+// size the [Builder]'s buffers and do not change which transactions are
+// included, which [BuildPolicy.Select] already controls.
+type BuildPolicy interface {
+	// Select is called with each batch of transactions streamed from the
+	// mempool, after repeats are removed. It returns the transactions to
+	// execute (in order) and the transactions to restore to the mempool.
+	// Transactions that are in neither are dropped.
+	Select(ctx context.Context, bctx *BuildContext, txs []*Transaction) (execute []*Transaction, restore []*Transaction)
+	// Reserved returns the units of the block that [txs] may not consume,
+	// which leaves room for transactions (like system or priority
+	// transactions) that are allowed to consume them. [txs] is a single
+	// transaction or the members of a bundle.
+	Reserved(bctx *BuildContext, txs []*Transaction) fees.Dimensions
+	// Stop is called before streaming each batch and returns true if the
+	// [Builder] should stop adding transactions to the block.
+	Stop(bctx *BuildContext, status BuildStatus) bool
+}
+
+// FeeView is a read-only view of the fee manager of the block being built. The
+// unit prices are fixed for the block and the units consumed are updated as
+// transactions are included.
+type FeeView interface {
+	// UnitPrices are the prices paid by transactions in the block
+	UnitPrices() fees.Dimensions
+	// UnitsConsumed are the units consumed by the transactions included so far
+	UnitsConsumed() fees.Dimensions
+	// Fee returns the fee paid for [units] in the block
+	Fee(units fees.Dimensions) (uint64, error)
+}
+
+// BuildContext describes the block being built
+type BuildContext struct {
+	Parent     *ExecutionBlock
+	ParentView state.View
+	Timestamp  int64
+	Rules      Rules
+
+	Fees        FeeView
+	MaxUnits    fees.Dimensions
+	TargetUnits fees.Dimensions
+
+	// MempoolSize is the number of transactions in the mempool when building
+	// started
+	MempoolSize int
+}
+
+// BuildStatus describes the progress of a block build
+type BuildStatus struct {
+	Elapsed       time.Duration
+	TxsAttempted  int
+	TxsIncluded   int
+	UnitsConsumed fees.Dimensions
+}
+
+// DefaultBuildPolicy executes transactions in the order they are streamed
+// from the mempool, limits each batch to [Config.TargetTxsSize], and stops
+// building after [Config.TargetBuildDuration].
+type DefaultBuildPolicy struct {
+	config Config
+}
+
+func NewDefaultBuildPolicy(config Config) *DefaultBuildPolicy {
+	return &DefaultBuildPolicy{config: config}
+}
+
+func (d *DefaultBuildPolicy) Select(_ context.Context, _ *BuildContext, txs []*Transaction) ([]*Transaction, []*Transaction) {
+	totalTxsSize := 0
+	for i, tx := range txs {
+		totalTxsSize += tx.Size()
+		if totalTxsSize > d.config.TargetTxsSize {
+			return txs[:i], txs[i:]
+		}
+	}
+	return txs, nil
+}
+
+func (*DefaultBuildPolicy) Reserved(*BuildContext, []*Transaction) fees.Dimensions {
+	return fees.Dimensions{}
+}
+
+func (d *DefaultBuildPolicy) Stop(_ *BuildContext, status BuildStatus) bool {
+	return status.Elapsed >= d.config.TargetBuildDuration
+}
+
+// unreservedUnits returns the units of [maxUnits] that are not [reserved]
+func unreservedUnits(maxUnits fees.Dimensions, reserved fees.Dimensions) fees.Dimensions {
+	var limit fees.Dimensions
+	for i := range maxUnits {
+		if reserved[i] < maxUnits[i] {
+			limit[i] = maxUnits[i] - reserved[i]
+		}
+	}
+	return limit
+}
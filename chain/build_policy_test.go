@@ -0,0 +1,34 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func TestDefaultBuildPolicy(t *testing.T) {
+	require := require.New(t)
+
+	txs := signBundle(t, []*chain.TransactionData{newBundleTxData(1), newBundleTxData(2), newBundleTxData(3)})
+	config := chain.NewDefaultConfig()
+	config.TargetTxsSize = 2 * txs[0].Size()
+	policy := chain.NewDefaultBuildPolicy(config)
+	bctx := &chain.BuildContext{}
+
+	// Txs are executed in order until the batch exceeds the target size
+	execute, restore := policy.Select(context.Background(), bctx, txs)
+	require.Equal(txs[:2], execute)
+	require.Equal(txs[2:], restore)
+
+	require.Zero(policy.Reserved(bctx, txs[:1]))
+
+	require.False(policy.Stop(bctx, chain.BuildStatus{Elapsed: config.TargetBuildDuration - time.Millisecond}))
+	require.True(policy.Stop(bctx, chain.BuildStatus{Elapsed: config.TargetBuildDuration}))
+}
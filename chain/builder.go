@@ -4,10 +4,12 @@
 package chain
 
 import (
+	"cmp"
 	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
@@ -35,7 +38,11 @@ const (
 	stopBuildingThreshold   = 2_048 // units
 )
 
-var errBlockFull = errors.New("block full")
+var (
+	_ FeeView = (*fees.Manager)(nil)
+
+	errBlockFull = errors.New("block full")
+)
 
 func HandlePreExecute(log logging.Logger, err error) bool {
 	switch {
@@ -53,6 +60,11 @@ func HandlePreExecute(log logging.Logger, err error) bool {
 		return false
 	case errors.Is(err, ErrActionNotActivated):
 		return false
+	case errors.Is(err, ErrNonceTooLow):
+		return false
+	case errors.Is(err, ErrNonceTooHigh):
+		// The previous transaction of the sponsor may be included later
+		return true
 	default:
 		// If unknown error, drop
 		log.Warn("unknown PreExecute error", zap.Error(err))
@@ -68,10 +80,13 @@ type Builder struct {
 	balanceHandler  BalanceHandler
 	mempool         Mempool
 	validityWindow  ValidityWindow
+	buildPolicy     BuildPolicy
 	metrics         *chainMetrics
 	config          Config
 }
 
+// NewBuilder returns a [Builder] that uses [buildPolicy] to select
+// transactions. If [buildPolicy] is nil, [DefaultBuildPolicy] is used.
 func NewBuilder(
 	tracer trace.Tracer,
 	ruleFactory RuleFactory,
@@ -80,9 +95,13 @@ func NewBuilder(
 	balanceHandler BalanceHandler,
 	mempool Mempool,
 	validityWindow ValidityWindow,
+	buildPolicy BuildPolicy,
 	metrics *chainMetrics,
 	config Config,
 ) *Builder {
+	if buildPolicy == nil {
+		buildPolicy = NewDefaultBuildPolicy(config)
+	}
 	return &Builder{
 		tracer:          tracer,
 		ruleFactory:     ruleFactory,
@@ -91,6 +110,7 @@ func NewBuilder(
 		balanceHandler:  balanceHandler,
 		mempool:         mempool,
 		validityWindow:  validityWindow,
+		buildPolicy:     buildPolicy,
 		metrics:         metrics,
 		config:          config,
 	}
@@ -130,6 +150,16 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 
 	mempoolSize := c.mempool.Len(ctx)
 	changesEstimate := min(mempoolSize, maxViewPreallocation)
+	bctx := &BuildContext{
+		Parent:      parent,
+		ParentView:  parentView,
+		Timestamp:   nextTime,
+		Rules:       r,
+		Fees:        feeManager,
+		MaxUnits:    maxUnits,
+		TargetUnits: targetUnits,
+		MempoolSize: mempoolSize,
+	}
 
 	var (
 		ts            = tstate.New(changesEstimate)
@@ -155,11 +185,23 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 
 		// stop is used to trigger that we should stop building, assuming we are no longer executing
 		stop bool
+
+		// bundles contains the members of bundles that have been streamed
+		// but are not yet complete
+		bundles = map[ids.ID][]*Transaction{}
 	)
 
 	// Batch fetch items from mempool to unblock incoming RPC/Gossip traffic
 	c.mempool.StartStreaming(ctx)
-	for time.Since(start) < c.config.TargetBuildDuration && !stop {
+	for !stop {
+		if c.buildPolicy.Stop(bctx, BuildStatus{
+			Elapsed:       time.Since(start),
+			TxsAttempted:  txsAttempted,
+			TxsIncluded:   len(blockTransactions),
+			UnitsConsumed: feeManager.UnitsConsumed(),
+		}) {
+			break
+		}
 		prepareStreamLock.Lock()
 		txs := c.mempool.Stream(ctx, streamBatch)
 		prepareStreamLock.Unlock()
@@ -172,32 +214,33 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 		// Perform a batch repeat check
 		// IsRepeat only returns an error if we fail to fetch the full validity window of blocks.
 		// This should only happen after startup, so we add the transactions back to the mempool.
-		dup, err := c.validityWindow.IsRepeat(ctx, parent, txs, oldestAllowed)
-		if err != nil {
-			restorable = append(restorable, txs...)
-			break
+		//
+		// Nonces protect against repeats on chains that use them.
+		dup := set.NewBits()
+		if !r.GetNonceReplayProtection() {
+			var err error
+			dup, err = c.validityWindow.IsRepeat(ctx, parent, txs, oldestAllowed)
+			if err != nil {
+				restorable = append(restorable, txs...)
+				break
+			}
+		}
+
+		// Skip any duplicates before going async
+		candidates := make([]*Transaction, 0, len(txs))
+		for i, tx := range txs {
+			if !dup.Contains(i) {
+				candidates = append(candidates, tx)
+			}
 		}
+		selected, restore := c.buildPolicy.Select(ctx, bctx, candidates)
+		restorable = append(restorable, restore...)
 
 		e := executor.New(streamBatch, c.config.TransactionExecutionCores, MaxKeyDependencies, c.metrics.executorBuildRecorder)
 		pending := make(map[ids.ID]*Transaction, streamBatch)
 		var pendingLock sync.Mutex
-		totalTxsSize := 0
-		for li, ltx := range txs {
+		for i, tx := range selected {
 			txsAttempted++
-			totalTxsSize += ltx.Size()
-			if totalTxsSize > c.config.TargetTxsSize {
-				c.log.Debug("Transactions in block exceeded allotted limit ", zap.Int("size", ltx.Size()))
-				restorable = append(restorable, txs[li:]...)
-				break
-			}
-
-			i := li
-			tx := ltx
-
-			// Skip any duplicates before going async
-			if dup.Contains(i) {
-				continue
-			}
 
 			stateKeys, err := tx.StateKeys(c.balanceHandler)
 			if err != nil {
@@ -218,10 +261,48 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 				}()
 			}
 
+			// Bundles are executed once all of their members have been
+			// streamed.
+			group := []*Transaction{tx}
+			if tx.Base.Bundled() {
+				members := bundles[tx.Base.Bundle]
+				if err := verifyBundleMember(members, tx); err != nil {
+					c.log.Debug("dropping bundle member", zap.Stringer("txID", tx.ID()), zap.Error(err))
+					continue
+				}
+				members = append(members, tx)
+				if len(members) < int(tx.Base.BundleSize) {
+					bundles[tx.Base.Bundle] = members
+					continue
+				}
+				delete(bundles, tx.Base.Bundle)
+
+				// Members may be restored to the mempool out of order, so we sort
+				// them by their position in the bundle.
+				slices.SortFunc(members, func(a, b *Transaction) int {
+					return cmp.Compare(a.Base.BundleIndex, b.Base.BundleIndex)
+				})
+				if _, err := VerifyBundle(members); err != nil {
+					// Every member has been streamed, so the bundle can never
+					// verify
+					c.log.Debug("dropping bundle", zap.Stringer("bundle", tx.Base.Bundle), zap.Error(err))
+					continue
+				}
+				group = members
+				stateKeys, err = groupStateKeys(group, c.balanceHandler)
+				if err != nil {
+					continue
+				}
+			}
+
+			unitLimit := unreservedUnits(maxUnits, c.buildPolicy.Reserved(bctx, group))
+
 			// We track pending transactions because an error may cause us
 			// not to execute restorable transactions.
 			pendingLock.Lock()
-			pending[tx.ID()] = tx
+			for _, tx := range group {
+				pending[tx.ID()] = tx
+			}
 			pendingLock.Unlock()
 			e.Run(stateKeys, func() error {
 				// We use defer here instead of covering all returns because it is
@@ -229,14 +310,16 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 				var restore bool
 				defer func() {
 					pendingLock.Lock()
-					delete(pending, tx.ID())
+					for _, tx := range group {
+						delete(pending, tx.ID())
+					}
 					pendingLock.Unlock()
 
 					if !restore {
 						return
 					}
 					restorableLock.Lock()
-					restorable = append(restorable, tx)
+					restorable = append(restorable, group...)
 					restorableLock.Unlock()
 				}()
 
@@ -291,27 +374,48 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 
 				// Execute block
 				tsv := ts.NewView(stateKeys, storage)
-				if err := tx.PreExecute(ctx, feeManager, c.balanceHandler, r, tsv, nextTime); err != nil {
-					// We don't need to rollback [tsv] here because it will never
-					// be committed.
-					if HandlePreExecute(c.log, err) {
+				groupResults := make([]*Result, 0, len(group))
+				for _, tx := range group {
+					if err := tx.PreExecute(ctx, feeManager, c.balanceHandler, r, tsv, nextTime); err != nil {
+						// We don't need to rollback [tsv] here because it will never
+						// be committed.
+						if HandlePreExecute(c.log, err) {
+							restore = true
+						}
+						return nil
+					}
+					result, err := tx.Execute(
+						ctx,
+						feeManager,
+						c.balanceHandler,
+						r,
+						tsv,
+						nextTime,
+					)
+					if err != nil {
+						// Returning an error here should be avoided at all costs (can be a DoS). Rather,
+						// all units for the transaction should be consumed and a fee should be charged.
+						c.log.Warn("unexpected post-execution error", zap.Error(err))
 						restore = true
+						return err
 					}
-					return nil
+
+					// Bundles are included atomically, so the failure of any member
+					// drops the entire bundle (including the fees charged to earlier
+					// members) by never committing [tsv].
+					if len(group) > 1 && !result.Success {
+						c.log.Debug(
+							"dropping bundle: member failed",
+							zap.Stringer("bundle", tx.Base.Bundle),
+							zap.Stringer("txID", tx.ID()),
+						)
+						return nil
+					}
+					groupResults = append(groupResults, result)
 				}
-				result, err := tx.Execute(
-					ctx,
-					feeManager,
-					c.balanceHandler,
-					r,
-					tsv,
-					nextTime,
-				)
+				units, err := groupUnits(groupResults)
 				if err != nil {
-					// Returning an error here should be avoided at all costs (can be a DoS). Rather,
-					// all units for the transaction should be consumed and a fee should be charged.
-					c.log.Warn("unexpected post-execution error", zap.Error(err))
-					restore = true
+					// Should never happen
 					return err
 				}
 
@@ -319,13 +423,13 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 				defer blockLock.Unlock()
 
 				// Ensure block isn't too big
-				if ok, dimension := feeManager.Consume(result.Units, maxUnits); !ok {
+				if ok, dimension := feeManager.Consume(units, unitLimit); !ok {
 					c.log.Debug(
 						"skipping tx: too many units",
 						zap.Int("dimension", int(dimension)),
-						zap.Uint64("tx", result.Units[dimension]),
+						zap.Uint64("tx", units[dimension]),
 						zap.Uint64("block units", feeManager.LastConsumed(dimension)),
-						zap.Uint64("max block units", maxUnits[dimension]),
+						zap.Uint64("max block units", unitLimit[dimension]),
 					)
 					restore = true
 
@@ -341,10 +445,10 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 					return nil
 				}
 
-				// Update block with new transaction
+				// Update block with new transactions
 				tsv.Commit()
-				blockTransactions = append(blockTransactions, tx)
-				results = append(results, result)
+				blockTransactions = append(blockTransactions, group...)
+				results = append(results, groupResults...)
 				return nil
 			})
 		}
@@ -357,6 +461,9 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 				// If we stopped executing, make sure to add those txs back
 				restorable = append(restorable, tx)
 			}
+			for _, members := range bundles {
+				restorable = append(restorable, members...)
+			}
 			if !errors.Is(execErr, errBlockFull) {
 				// Wait for stream preparation to finish to make
 				// sure all transactions are returned to the mempool.
@@ -372,6 +479,17 @@ func (c *Builder) BuildBlock(ctx context.Context, parentView state.View, parent
 		}
 	}
 
+	// Return the members of incomplete bundles to the mempool so they can
+	// be included once the rest of their bundle arrives.
+	//
+	// If we stopped because of an execution error, these were already added to
+	// [restorable].
+	if !stop {
+		for _, members := range bundles {
+			restorable = append(restorable, members...)
+		}
+	}
+
 	// Wait for stream preparation to finish to make
 	// sure all transactions are returned to the mempool.
 	go func() {
@@ -0,0 +1,193 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// MaxBundleSize is the maximum number of transactions in a bundle.
+const MaxBundleSize = 32
+
+// A bundle is an ordered group of transactions (possibly from different
+// sponsors) that must be included contiguously in a block and that either
+// all succeed or are all excluded.
+//
+// Each member commits to the ID of its bundle in [Base.Bundle]. The ID is
+// the hash of the unsigned bytes of all members (with [Base.Bundle] unset),
+// so a member can't be included without the exact set of transactions it was
+// signed with. Each member also records its position in the bundle in
+// [Base.BundleIndex] and the number of members in [Base.BundleSize], which are
+// covered by the bundle ID. The size lets block builders tell a bundle that is
+// missing members from one that can never verify.
+
+// BindBundle computes the ID of the bundle formed by [txs] and sets it on each
+// transaction. It must be called before any member of the bundle is signed.
+func BindBundle(txs []*TransactionData) (ids.ID, error) {
+	for i, tx := range txs {
+		tx.Base.BundleIndex = uint8(i)
+		tx.Base.BundleSize = uint8(len(txs))
+	}
+	bundleID, err := computeBundleID(txs)
+	if err != nil {
+		return ids.Empty, err
+	}
+	for _, tx := range txs {
+		tx.Base.Bundle = bundleID
+		tx.unsignedBytes = nil
+	}
+	return bundleID, nil
+}
+
+// VerifyBundle returns the ID of the bundle formed by [txs] or an error if
+// [txs] is not a complete bundle in the order it was bound.
+func VerifyBundle(txs []*Transaction) (ids.ID, error) {
+	data := make([]*TransactionData, len(txs))
+	for i, tx := range txs {
+		data[i] = &tx.TransactionData
+	}
+	bundleID, err := computeBundleID(data)
+	if err != nil {
+		return ids.Empty, err
+	}
+	for i, tx := range txs {
+		if tx.Base.Bundle != bundleID || int(tx.Base.BundleIndex) != i || int(tx.Base.BundleSize) != len(txs) {
+			return ids.Empty, fmt.Errorf("%w: tx=%s bundle=%s expected=%s", ErrInvalidBundle, tx.ID(), tx.Base.Bundle, bundleID)
+		}
+	}
+	return bundleID, nil
+}
+
+func computeBundleID(txs []*TransactionData) (ids.ID, error) {
+	if len(txs) < 2 || len(txs) > MaxBundleSize {
+		return ids.Empty, fmt.Errorf("%w: size=%d", ErrInvalidBundle, len(txs))
+	}
+	p := codec.NewWriter(len(txs)*ids.IDLen, consts.NetworkSizeLimit)
+	for _, tx := range txs {
+		digest, err := tx.bundleDigest()
+		if err != nil {
+			return ids.Empty, err
+		}
+		p.PackID(digest)
+	}
+	return utils.ToID(p.Bytes()), p.Err()
+}
+
+// bundleDigest returns the hash of the unsigned bytes of [t] without its
+// bundle ID. The bundle index and size are kept, so the digest commits to the
+// position of [t] in the bundle and to the number of members.
+func (t *TransactionData) bundleDigest() (ids.ID, error) {
+	// We use [Base.Bundle] as a marker in [Base.Marshal], so we encode the
+	// index and size manually.
+	base := *t.Base
+	base.Bundle = ids.Empty
+	actionsSize, err := t.Actions.Size()
+	if err != nil {
+		return ids.Empty, err
+	}
+	p := codec.NewWriter(base.Size()+2*consts.ByteLen+actionsSize, consts.NetworkSizeLimit)
+	base.Marshal(p)
+	p.PackByte(t.Base.BundleIndex)
+	p.PackByte(t.Base.BundleSize)
+	if err := t.Actions.MarshalInto(p); err != nil {
+		return ids.Empty, err
+	}
+	if err := p.Err(); err != nil {
+		return ids.Empty, err
+	}
+	return utils.ToID(p.Bytes()), nil
+}
+
+// verifyBundleMember returns an error if [tx] can't be a member of the bundle
+// whose other streamed members are [members]. Such a transaction can never be
+// included, but doesn't prevent the rest of the bundle from being included.
+func verifyBundleMember(members []*Transaction, tx *Transaction) error {
+	if tx.Base.BundleSize < 2 || tx.Base.BundleSize > MaxBundleSize || tx.Base.BundleIndex >= tx.Base.BundleSize {
+		return fmt.Errorf("%w: tx=%s index=%d size=%d", ErrInvalidBundle, tx.ID(), tx.Base.BundleIndex, tx.Base.BundleSize)
+	}
+	for _, member := range members {
+		if member.Base.BundleSize != tx.Base.BundleSize || member.Base.BundleIndex == tx.Base.BundleIndex {
+			return fmt.Errorf("%w: tx=%s conflicts with member %s", ErrInvalidBundle, tx.ID(), member.ID())
+		}
+	}
+	return nil
+}
+
+// groupStateKeys returns the union of the state keys of [txs], which are
+// executed together as a single unit.
+func groupStateKeys(txs []*Transaction, bh BalanceHandler) (state.Keys, error) {
+	if len(txs) == 1 {
+		return txs[0].StateKeys(bh)
+	}
+	stateKeys := make(state.Keys)
+	for _, tx := range txs {
+		txStateKeys, err := tx.StateKeys(bh)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range txStateKeys {
+			if !stateKeys.Add(k, v) {
+				return nil, ErrInvalidKeyValue
+			}
+		}
+	}
+	return stateKeys, nil
+}
+
+// groupUnits returns the units consumed by all [results] of a group.
+func groupUnits(results []*Result) (fees.Dimensions, error) {
+	var (
+		units fees.Dimensions
+		err   error
+	)
+	for _, result := range results {
+		units, err = fees.Add(units, result.Units)
+		if err != nil {
+			return fees.Dimensions{}, err
+		}
+	}
+	return units, nil
+}
+
+// groupTxs splits [txs] into groups that must be executed as a single unit:
+// each bundle forms one group and every other transaction forms its own
+// group. It returns an error if the members of a bundle are not contiguous or
+// do not form a complete bundle.
+func groupTxs(txs []*Transaction) ([][]*Transaction, error) {
+	var (
+		groups = make([][]*Transaction, 0, len(txs))
+		seen   = map[ids.ID]struct{}{}
+	)
+	for i := 0; i < len(txs); {
+		tx := txs[i]
+		if !tx.Base.Bundled() {
+			groups = append(groups, txs[i:i+1])
+			i++
+			continue
+		}
+		bundleID := tx.Base.Bundle
+		if _, ok := seen[bundleID]; ok {
+			return nil, fmt.Errorf("%w: bundle %s is not contiguous", ErrInvalidBundle, bundleID)
+		}
+		seen[bundleID] = struct{}{}
+		j := i + 1
+		for j < len(txs) && txs[j].Base.Bundle == bundleID {
+			j++
+		}
+		if _, err := VerifyBundle(txs[i:j]); err != nil {
+			return nil, err
+		}
+		groups = append(groups, txs[i:j])
+		i = j
+	}
+	return groups, nil
+}
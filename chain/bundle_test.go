@@ -0,0 +1,448 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/genesis"
+	"github.com/ava-labs/hypersdk/internal/mempool"
+	"github.com/ava-labs/hypersdk/internal/validitywindow"
+	"github.com/ava-labs/hypersdk/internal/workers"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/state/metadata"
+	"github.com/ava-labs/hypersdk/utils"
+
+	internalfees "github.com/ava-labs/hypersdk/internal/fees"
+)
+
+var (
+	_ chain.Action = (*bundleAction)(nil)
+	_ chain.AuthVM = (*bundleAuthVM)(nil)
+
+	errBundleActionFailed = errors.New("bundle action failed")
+)
+
+// bundleAction succeeds unless [Fail] is set
+type bundleAction struct {
+	ID   uint64 `serialize:"true" json:"id"`
+	Fail bool   `serialize:"true" json:"fail"`
+}
+
+func (*bundleAction) GetTypeID() uint8 {
+	return 1
+}
+
+func (*bundleAction) ComputeUnits(chain.Rules) uint64 {
+	return 1
+}
+
+func (*bundleAction) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{}
+}
+
+func (b *bundleAction) Execute(context.Context, chain.Rules, state.Mutable, int64, codec.Address, ids.ID) (codec.Typed, error) {
+	if b.Fail {
+		return nil, errBundleActionFailed
+	}
+	return nil, nil
+}
+
+func (*bundleAction) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func unmarshalBundleAction(p *codec.Packer) (chain.Action, error) {
+	var action bundleAction
+	err := codec.LinearCodec.UnmarshalFrom(p.Packer, &action)
+	return &action, err
+}
+
+type bundleAuthVM struct{}
+
+func (*bundleAuthVM) Logger() logging.Logger {
+	return logging.NoLog{}
+}
+
+func (*bundleAuthVM) GetAuthBatchVerifier(uint8, int, int) (chain.AuthBatchVerifier, bool) {
+	return nil, false
+}
+
+type bundleBlockIndex struct {
+	blocks map[ids.ID]*chain.ExecutionBlock
+}
+
+func (i *bundleBlockIndex) GetExecutionBlock(_ context.Context, blkID ids.ID) (validitywindow.ExecutionBlock[*chain.Transaction], error) {
+	blk, ok := i.blocks[blkID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return blk, nil
+}
+
+// bundleTestChain builds and executes blocks on top of a genesis block that
+// funds [factories]
+type bundleTestChain struct {
+	chain     *chain.Chain
+	mempool   *mempool.Mempool[*chain.Transaction]
+	db        merkledb.MerkleDB
+	genesis   *chain.ExecutionBlock
+	rules     chain.Rules
+	factories []chain.AuthFactory
+	bh        chain.BalanceHandler
+}
+
+const bundleTestBalance = 1_000_000_000
+
+// bundleTestChainID is shared so blocks built by one test chain can be executed
+// by another
+var bundleTestChainID = ids.GenerateTestID()
+
+func newBundleTestChain(t *testing.T, factories []chain.AuthFactory) *bundleTestChain {
+	r := require.New(t)
+	ctx := context.Background()
+
+	rules := genesis.NewDefaultRules()
+	rules.ChainID = bundleTestChainID
+	ruleFactory := &genesis.ImmutableRuleFactory{Rules: rules}
+	actionCodec := codec.NewTypeParser[chain.Action]()
+	r.NoError(actionCodec.Register(&bundleAction{}, unmarshalBundleAction))
+	authCodec := codec.NewTypeParser[chain.Auth]()
+	r.NoError(authCodec.Register(&auth.ED25519{}, auth.UnmarshalED25519))
+	parser := chaintest.NewParser(ruleFactory, actionCodec, authCodec, codec.NewTypeParser[codec.Typed]())
+
+	db, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		BranchFactor:                merkledb.BranchFactor16,
+		RootGenConcurrency:          1,
+		HistoryLength:               100,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.KiB,
+		IntermediateWriteBatchSize:  units.KiB,
+		Tracer:                      trace.Noop,
+	})
+	r.NoError(err)
+
+	bh := &testBalanceHandler{}
+	mu := state.NewSimpleMutable(db)
+	for _, factory := range factories {
+		r.NoError(bh.AddBalance(ctx, factory.Address(), mu, bundleTestBalance))
+	}
+	r.NoError(mu.Commit(ctx))
+	root, err := db.GetMerkleRoot(ctx)
+	r.NoError(err)
+	genesisBlk, err := chain.NewGenesisBlock(root)
+	r.NoError(err)
+
+	metadataManager := metadata.NewDefaultManager()
+	mu = state.NewSimpleMutable(db)
+	r.NoError(mu.Insert(ctx, chain.HeightKey(metadataManager.HeightPrefix()), binary.BigEndian.AppendUint64(nil, 0)))
+	r.NoError(mu.Insert(ctx, chain.TimestampKey(metadataManager.TimestampPrefix()), binary.BigEndian.AppendUint64(nil, 0)))
+	feeManager := internalfees.NewManager(nil)
+	for i := fees.Dimension(0); i < fees.FeeDimensions; i++ {
+		feeManager.SetUnitPrice(i, rules.GetMinUnitPrice()[i])
+	}
+	r.NoError(mu.Insert(ctx, chain.FeeKey(metadataManager.FeePrefix()), feeManager.Bytes()))
+	r.NoError(mu.Commit(ctx))
+
+	index := &bundleBlockIndex{blocks: map[ids.ID]*chain.ExecutionBlock{genesisBlk.ID(): genesisBlk}}
+	mp := mempool.New[*chain.Transaction](trace.Noop, 100, 100)
+	c, err := chain.NewChain(
+		trace.Noop,
+		prometheus.NewRegistry(),
+		parser,
+		mp,
+		logging.NoLog{},
+		ruleFactory,
+		metadataManager,
+		bh,
+		workers.NewSerial(),
+		&bundleAuthVM{},
+		validitywindow.NewTimeValidityWindow(logging.NoLog{}, trace.Noop, index, func(int64) int64 {
+			return rules.GetTimestampModulus()
+		}),
+		nil,
+		chain.NewDefaultConfig(),
+	)
+	r.NoError(err)
+	return &bundleTestChain{
+		chain:     c,
+		mempool:   mp,
+		db:        db,
+		genesis:   genesisBlk,
+		rules:     rules,
+		factories: factories,
+		bh:        bh,
+	}
+}
+
+func newBundleTestFactories(t *testing.T, n int) []chain.AuthFactory {
+	factories := make([]chain.AuthFactory, n)
+	for i := range factories {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(t, err)
+		factories[i] = auth.NewED25519Factory(priv)
+	}
+	return factories
+}
+
+// newTx returns unsigned tx data that is valid in the next block
+func (c *bundleTestChain) newTx(id uint64, fail bool) *chain.TransactionData {
+	return chain.NewTxData(
+		&chain.Base{
+			Timestamp: utils.UnixRMilliModulus(-1, c.rules.GetValidityWindow(), c.rules.GetTimestampModulus()),
+			ChainID:   c.rules.GetChainID(),
+			MaxFee:    math.MaxUint64,
+		},
+		[]chain.Action{&bundleAction{ID: id, Fail: fail}},
+	)
+}
+
+// sign signs [txs] with the factories of the chain in turn
+func (c *bundleTestChain) sign(t *testing.T, txs ...*chain.TransactionData) []*chain.Transaction {
+	signed := make([]*chain.Transaction, len(txs))
+	for i, tx := range txs {
+		var err error
+		signed[i], err = tx.Sign(c.factories[i%len(c.factories)])
+		require.NoError(t, err)
+	}
+	return signed
+}
+
+// newBlock returns a block of [txs] built on the genesis block
+func (c *bundleTestChain) newBlock(t *testing.T, txs []*chain.Transaction) *chain.ExecutionBlock {
+	root, err := c.db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+	// Blocks must be built at least [MinBlockGap] after their parent
+	blk, err := chain.NewStatelessBlock(c.genesis.ID(), time.Now().UnixMilli(), 1, txs, root)
+	require.NoError(t, err)
+	executionBlk, err := chain.NewExecutionBlock(blk)
+	require.NoError(t, err)
+	return executionBlk
+}
+
+func newBundleTxData(value uint64) *chain.TransactionData {
+	return chain.NewTxData(
+		&chain.Base{
+			Timestamp: 1724315246000,
+			ChainID:   [32]byte{1, 2, 3, 4, 5, 6, 7},
+			MaxFee:    1234567,
+		},
+		[]chain.Action{
+			&mockTransferAction{
+				To:    codec.Address{1, 2, 3, 4},
+				Value: value,
+			},
+		},
+	)
+}
+
+func signBundle(t *testing.T, txs []*chain.TransactionData) []*chain.Transaction {
+	signed := make([]*chain.Transaction, len(txs))
+	for i, tx := range txs {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(t, err)
+		signed[i], err = tx.Sign(auth.NewED25519Factory(priv))
+		require.NoError(t, err)
+	}
+	return signed
+}
+
+func TestVerifyBundle(t *testing.T) {
+	require := require.New(t)
+
+	txs := []*chain.TransactionData{newBundleTxData(1), newBundleTxData(2), newBundleTxData(3)}
+	bundleID, err := chain.BindBundle(txs)
+	require.NoError(err)
+	for i, tx := range txs {
+		require.Equal(bundleID, tx.Base.Bundle)
+		require.Equal(uint8(i), tx.Base.BundleIndex)
+	}
+	signed := signBundle(t, txs)
+
+	verifiedID, err := chain.VerifyBundle(signed)
+	require.NoError(err)
+	require.Equal(bundleID, verifiedID)
+
+	// Members can't be executed without the rest of the bundle
+	_, err = chain.VerifyBundle(signed[:2])
+	require.ErrorIs(err, chain.ErrInvalidBundle)
+	_, err = chain.VerifyBundle(signed[:1])
+	require.ErrorIs(err, chain.ErrInvalidBundle)
+
+	// Members must be in the order they were bound
+	_, err = chain.VerifyBundle([]*chain.Transaction{signed[1], signed[0], signed[2]})
+	require.ErrorIs(err, chain.ErrInvalidBundle)
+
+	// Members can't be replaced with another transaction that claims the same
+	// bundle
+	forged := newBundleTxData(4)
+	forged.Base.Bundle = bundleID
+	forged.Base.BundleIndex = 2
+	_, err = chain.VerifyBundle(append(signed[:2:2], signBundle(t, []*chain.TransactionData{forged})...))
+	require.ErrorIs(err, chain.ErrInvalidBundle)
+}
+
+// Bundles are built contiguously in the order they were bound, regardless of
+// the order their members are streamed from the mempool, and the built block
+// is accepted by the processor.
+func TestBuildBlockBundleContiguous(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	factories := newBundleTestFactories(t, 3)
+	builder := newBundleTestChain(t, factories)
+
+	bundle := []*chain.TransactionData{builder.newTx(0, false), builder.newTx(1, false), builder.newTx(2, false)}
+	bundleID, err := chain.BindBundle(bundle)
+	r.NoError(err)
+	members := builder.sign(t, bundle...)
+	others := builder.sign(t, builder.newTx(3, false), builder.newTx(4, false))
+	builder.mempool.Add(ctx, []*chain.Transaction{members[2], others[0], members[0], others[1], members[1]})
+
+	blk, executed, _, err := builder.chain.BuildBlock(ctx, builder.db, builder.genesis)
+	r.NoError(err)
+	txs := blk.StatelessBlock.Txs
+	r.Len(txs, 5)
+	start := -1
+	for i, tx := range txs {
+		if tx.Base.Bundle == bundleID {
+			start = i
+			break
+		}
+	}
+	r.NotEqual(-1, start)
+	r.LessOrEqual(start+len(members), len(txs))
+	for i, member := range members {
+		r.Equal(member.ID(), txs[start+i].ID())
+	}
+	for _, result := range executed.Results {
+		r.True(result.Success)
+	}
+
+	verifier := newBundleTestChain(t, factories)
+	parsed, err := verifier.chain.ParseBlock(ctx, blk.Bytes())
+	r.NoError(err)
+	_, _, err = verifier.chain.Execute(ctx, verifier.db, parsed)
+	r.NoError(err)
+}
+
+// The failure of any member drops the entire bundle, including the fees of
+// the other members
+func TestBuildBlockBundleMemberFails(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	c := newBundleTestChain(t, newBundleTestFactories(t, 2))
+	bundle := []*chain.TransactionData{c.newTx(0, false), c.newTx(1, true)}
+	_, err := chain.BindBundle(bundle)
+	r.NoError(err)
+	members := c.sign(t, bundle...)
+	// Unbundled txs are included even if they fail
+	failed := c.sign(t, c.newTx(2, true))[0]
+	c.mempool.Add(ctx, append(members, failed))
+
+	blk, executed, view, err := c.chain.BuildBlock(ctx, c.db, c.genesis)
+	r.NoError(err)
+	r.Len(blk.StatelessBlock.Txs, 1)
+	r.Equal(failed.ID(), blk.StatelessBlock.Txs[0].ID())
+	r.False(executed.Results[0].Success)
+
+	// The bundle is not returned to the mempool
+	r.Never(func() bool {
+		return c.mempool.Len(ctx) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	balance, err := c.bh.GetBalance(ctx, members[0].Sponsor(), view)
+	r.NoError(err)
+	r.Equal(uint64(bundleTestBalance)-executed.Results[0].Fee, balance)
+}
+
+// Bundles that can never verify are dropped, while bundles missing members are
+// returned to the mempool
+func TestBuildBlockBundleInvalid(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	c := newBundleTestChain(t, newBundleTestFactories(t, 2))
+
+	incomplete := []*chain.TransactionData{c.newTx(0, false), c.newTx(1, false)}
+	_, err := chain.BindBundle(incomplete)
+	r.NoError(err)
+	waiting := c.sign(t, incomplete[0])[0]
+
+	forged := []*chain.TransactionData{c.newTx(2, false), c.newTx(3, false)}
+	for i, tx := range forged {
+		tx.Base.Bundle = ids.GenerateTestID()
+		tx.Base.BundleIndex = uint8(i)
+		tx.Base.BundleSize = 2
+	}
+	forged[1].Base.Bundle = forged[0].Base.Bundle
+	c.mempool.Add(ctx, append(c.sign(t, forged...), waiting))
+
+	blk, _, _, err := c.chain.BuildBlock(ctx, c.db, c.genesis)
+	r.NoError(err)
+	r.Empty(blk.StatelessBlock.Txs)
+
+	// Restored transactions are returned to the mempool asynchronously
+	r.Eventually(func() bool {
+		return c.mempool.Has(ctx, waiting.ID())
+	}, time.Second, 10*time.Millisecond)
+	r.Equal(1, c.mempool.Len(ctx))
+}
+
+// Blocks that include bundles partially or not contiguously are rejected
+func TestExecuteBlockInvalidBundle(t *testing.T) {
+	c := newBundleTestChain(t, newBundleTestFactories(t, 3))
+	bundle := []*chain.TransactionData{c.newTx(0, false), c.newTx(1, false)}
+	_, err := chain.BindBundle(bundle)
+	require.NoError(t, err)
+	members := c.sign(t, bundle...)
+	other := c.sign(t, c.newTx(2, false))[0]
+
+	tests := []struct {
+		name string
+		txs  []*chain.Transaction
+	}{
+		{
+			name: "partial bundle",
+			txs:  []*chain.Transaction{members[0], other},
+		},
+		{
+			name: "non-contiguous bundle",
+			txs:  []*chain.Transaction{members[0], other, members[1]},
+		},
+		{
+			name: "reordered bundle",
+			txs:  []*chain.Transaction{members[1], members[0]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := c.chain.Execute(context.Background(), c.db, c.newBlock(t, tt.txs))
+			require.ErrorIs(t, err, chain.ErrInvalidBundle)
+		})
+	}
+}
@@ -39,6 +39,7 @@ func NewChain(
 	authVerifiers workers.Workers,
 	authVM AuthVM,
 	validityWindow ValidityWindow,
+	buildPolicy BuildPolicy,
 	config Config,
 ) (*Chain, error) {
 	metrics, err := newMetrics(registerer)
@@ -54,6 +55,7 @@ func NewChain(
 			balanceHandler,
 			mempool,
 			validityWindow,
+			buildPolicy,
 			metrics,
 			config,
 		),
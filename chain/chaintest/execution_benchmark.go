@@ -0,0 +1,530 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chaintest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/internal/mempool"
+	"github.com/ava-labs/hypersdk/internal/validitywindow"
+	"github.com/ava-labs/hypersdk/internal/workers"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+
+	internalfees "github.com/ava-labs/hypersdk/internal/fees"
+)
+
+const (
+	// benchmarkBuildDuration is long enough for blocks to be limited by
+	// their units rather than by the time spent building them
+	benchmarkBuildDuration = 10 * time.Second
+	authVerificationJobs   = 100
+)
+
+var (
+	ErrNoTxsToBenchmark     = errors.New("no txs to benchmark")
+	ErrDuplicateTx          = errors.New("duplicate tx")
+	ErrTxsNotIncluded       = errors.New("txs were not included in blocks")
+	ErrBenchmarkNotPrepared = errors.New("benchmark was not prepared")
+)
+
+// AuthEngine creates batch verifiers for an auth type. It is implemented by
+// vm.AuthEngine.
+type AuthEngine interface {
+	GetBatchVerifier(cores int, count int) chain.AuthBatchVerifier
+}
+
+// ExecutionConfig is the parallelism used to verify and execute blocks
+type ExecutionConfig struct {
+	TransactionExecutionCores int `json:"transactionExecutionCores"`
+	StateFetchConcurrency     int `json:"stateFetchConcurrency"`
+	AuthVerificationCores     int `json:"authVerificationCores"`
+}
+
+func (c ExecutionConfig) String() string {
+	return fmt.Sprintf("exec=%d/fetch=%d/auth=%d", c.TransactionExecutionCores, c.StateFetchConcurrency, c.AuthVerificationCores)
+}
+
+// ExecutionMatrix returns every combination of the given parallelism values
+func ExecutionMatrix(executionCores []int, stateFetchConcurrency []int, authVerificationCores []int) []ExecutionConfig {
+	matrix := make([]ExecutionConfig, 0, len(executionCores)*len(stateFetchConcurrency)*len(authVerificationCores))
+	for _, e := range executionCores {
+		for _, f := range stateFetchConcurrency {
+			for _, a := range authVerificationCores {
+				matrix = append(matrix, ExecutionConfig{
+					TransactionExecutionCores: e,
+					StateFetchConcurrency:     f,
+					AuthVerificationCores:     a,
+				})
+			}
+		}
+	}
+	return matrix
+}
+
+// ExecutionBenchmark measures the throughput of [chain.Processor] without
+// running a node. It signs [NumTxs] txs, builds them into blocks with
+// [chain.Builder] on an in-memory merkledb and then re-executes these blocks
+// from genesis with each [ExecutionConfig].
+type ExecutionBenchmark struct {
+	// Parser registers the actions and auth of the txs. Its rules are used to
+	// build and execute blocks.
+	Parser          chain.Parser
+	MetadataManager chain.MetadataManager
+	BalanceHandler  chain.BalanceHandler
+	// AuthEngines enables batch verification of the auth types they are
+	// registered for
+	AuthEngines map[uint8]AuthEngine
+
+	// NumAccounts accounts are funded with AccountBalance at genesis and
+	// send the txs in turn
+	NumAccounts    int
+	AccountBalance uint64
+	NewAuthFactory func() (chain.AuthFactory, error)
+
+	// GenerateActions returns the actions of the [i]th tx, sent by [sender].
+	// [accounts] are all the funded accounts. Unless the chain uses nonce
+	// replay protection, txs of the same sender must have different actions
+	// because they are signed with the same timestamp.
+	GenerateActions func(i int, sender codec.Address, accounts []codec.Address) ([]chain.Action, error)
+	NumTxs          int
+
+	// BuildPolicy is used to build blocks. If nil, [chain.DefaultBuildPolicy]
+	// is used.
+	BuildPolicy chain.BuildPolicy
+
+	accounts []codec.Address
+	blocks   [][]byte
+	build    BuildResult
+}
+
+type BuildResult struct {
+	Blocks   int           `json:"blocks"`
+	Txs      int           `json:"txs"`
+	Duration time.Duration `json:"duration"`
+}
+
+// ExecutionResult is the time spent verifying and executing all blocks with
+// [Config]
+type ExecutionResult struct {
+	Config ExecutionConfig `json:"config"`
+	Txs    int             `json:"txs"`
+	Blocks int             `json:"blocks"`
+
+	// Total is the time spent in all phases below
+	Total        time.Duration `json:"total"`
+	TxsPerSecond float64       `json:"txsPerSecond"`
+	// Execute is the time spent in [chain.Chain.Execute], which includes
+	// WaitSignatures and WaitRoot
+	Execute        time.Duration `json:"execute"`
+	WaitSignatures time.Duration `json:"waitSignatures"`
+	WaitRoot       time.Duration `json:"waitRoot"`
+	// Root is the time spent computing the state root after executing a block
+	Root time.Duration `json:"root"`
+	// Commit is the time spent writing the state of accepted blocks
+	Commit time.Duration `json:"commit"`
+
+	Allocs     uint64 `json:"allocs"`
+	AllocBytes uint64 `json:"allocBytes"`
+}
+
+type ExecutionReport struct {
+	Build   BuildResult       `json:"build"`
+	Results []ExecutionResult `json:"results"`
+}
+
+// Run prepares the blocks of the benchmark and executes them with every
+// config of [matrix]
+func (e *ExecutionBenchmark) Run(ctx context.Context, matrix []ExecutionConfig) (*ExecutionReport, error) {
+	if err := e.Prepare(ctx); err != nil {
+		return nil, err
+	}
+	report := &ExecutionReport{Build: e.build}
+	for _, config := range matrix {
+		result, err := e.Execute(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to execute with %s", err, config)
+		}
+		report.Results = append(report.Results, *result)
+	}
+	return report, nil
+}
+
+// Benchmark runs a sub-benchmark of [b] for every config of [matrix] that
+// reports allocations and txs/s
+func (e *ExecutionBenchmark) Benchmark(b *testing.B, matrix []ExecutionConfig) {
+	ctx := context.Background()
+	if err := e.Prepare(ctx); err != nil {
+		b.Fatal(err)
+	}
+	for _, config := range matrix {
+		b.Run(config.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			var total time.Duration
+			for i := 0; i < b.N; i++ {
+				result, err := e.Execute(ctx, config)
+				if err != nil {
+					b.Fatal(err)
+				}
+				total += result.Total
+			}
+			b.ReportMetric(float64(e.build.Txs*b.N)/total.Seconds(), "txs/s")
+			b.ReportMetric(0, "ns/op")
+		})
+	}
+}
+
+// Prepare generates and signs the txs of the benchmark and builds them into
+// blocks. It is a no-op if the blocks were already built.
+func (e *ExecutionBenchmark) Prepare(ctx context.Context) error {
+	if e.blocks != nil {
+		return nil
+	}
+	if e.NumTxs <= 0 {
+		return ErrNoTxsToBenchmark
+	}
+
+	factories := make([]chain.AuthFactory, e.NumAccounts)
+	e.accounts = make([]codec.Address, e.NumAccounts)
+	for i := range factories {
+		factory, err := e.NewAuthFactory()
+		if err != nil {
+			return err
+		}
+		factories[i] = factory
+		e.accounts[i] = factory.Address()
+	}
+
+	// Sign all txs before building so that it is not measured
+	rules := e.Parser.Rules(time.Now().UnixMilli())
+	txs := make([]*chain.Transaction, e.NumTxs)
+	txIDs := set.NewSet[ids.ID](e.NumTxs)
+	for i := range txs {
+		factory := factories[i%len(factories)]
+		actions, err := e.GenerateActions(i, factory.Address(), e.accounts)
+		if err != nil {
+			return err
+		}
+		base := &chain.Base{
+			Timestamp: utils.UnixRMilliModulus(-1, rules.GetValidityWindow(), rules.GetTimestampModulus()),
+			ChainID:   rules.GetChainID(),
+			MaxFee:    math.MaxUint64,
+		}
+		if rules.GetNonceReplayProtection() {
+			base.Nonce = uint64(i/len(factories)) + 1
+		}
+		tx, err := chain.NewTxData(base, actions).Sign(factory)
+		if err != nil {
+			return err
+		}
+		if txIDs.Contains(tx.ID()) {
+			return fmt.Errorf("%w: tx %d (%s)", ErrDuplicateTx, i, tx.ID())
+		}
+		txIDs.Add(tx.ID())
+		txs[i] = tx
+	}
+
+	db, genesis, err := e.newGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	mp := mempool.New[*chain.Transaction](trace.Noop, len(txs), len(txs))
+	mp.Add(ctx, txs)
+
+	config := chain.NewDefaultConfig()
+	config.TargetBuildDuration = benchmarkBuildDuration
+	c, index, err := e.newChain(mp, e.BuildPolicy, config, workers.NewSerial())
+	if err != nil {
+		return err
+	}
+	index.add(genesis)
+
+	var (
+		start  = time.Now()
+		parent = genesis
+		blocks [][]byte
+		built  int
+	)
+	for built < len(txs) {
+		// Blocks are built with the current time, so we wait for the minimum
+		// gap to pass. This also lets the builder return the txs that did not
+		// fit in the previous block to the mempool.
+		minGap := e.Parser.Rules(parent.Tmstmp).GetMinBlockGap()
+		time.Sleep(time.Until(time.UnixMilli(parent.Tmstmp + minGap)))
+
+		blk, _, view, err := c.BuildBlock(ctx, db, parent)
+		if err != nil {
+			return err
+		}
+		if len(blk.Txs()) == 0 {
+			break
+		}
+		if err := view.CommitToDB(ctx); err != nil {
+			return err
+		}
+		if err := c.AcceptBlock(ctx, blk); err != nil {
+			return err
+		}
+		index.add(blk)
+		blocks = append(blocks, blk.Bytes())
+		built += len(blk.Txs())
+		parent = blk
+	}
+	if built != len(txs) {
+		return fmt.Errorf("%w: %d of %d", ErrTxsNotIncluded, len(txs)-built, len(txs))
+	}
+
+	e.blocks = blocks
+	e.build = BuildResult{
+		Blocks:   len(blocks),
+		Txs:      built,
+		Duration: time.Since(start),
+	}
+	return nil
+}
+
+// Execute verifies and executes the prepared blocks from genesis with
+// [config]
+func (e *ExecutionBenchmark) Execute(ctx context.Context, config ExecutionConfig) (*ExecutionResult, error) {
+	if e.blocks == nil {
+		return nil, ErrBenchmarkNotPrepared
+	}
+
+	db, genesis, err := e.newGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainConfig := chain.NewDefaultConfig()
+	chainConfig.TransactionExecutionCores = config.TransactionExecutionCores
+	chainConfig.StateFetchConcurrency = config.StateFetchConcurrency
+	authVerifiers := workers.NewParallel(config.AuthVerificationCores, authVerificationJobs)
+	defer authVerifiers.Stop()
+	c, index, err := e.newChain(mempool.New[*chain.Transaction](trace.Noop, 1, 1), nil, chainConfig, authVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	index.add(genesis)
+
+	blocks := make([]*chain.ExecutionBlock, len(e.blocks))
+	for i, b := range e.blocks {
+		blk, err := c.ParseBlock(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = blk
+	}
+
+	result := &ExecutionResult{
+		Config: config,
+		Blocks: len(blocks),
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for _, blk := range blocks {
+		start := time.Now()
+		if err := c.AsyncVerify(ctx, blk); err != nil {
+			return nil, err
+		}
+		_, view, err := c.Execute(ctx, db, blk)
+		if err != nil {
+			return nil, err
+		}
+		executed := time.Now()
+		if _, err := view.GetMerkleRoot(ctx); err != nil {
+			return nil, err
+		}
+		rooted := time.Now()
+		if err := view.CommitToDB(ctx); err != nil {
+			return nil, err
+		}
+		if err := c.AcceptBlock(ctx, blk); err != nil {
+			return nil, err
+		}
+		index.add(blk)
+		committed := time.Now()
+
+		result.Txs += len(blk.Txs())
+		result.Execute += executed.Sub(start)
+		result.Root += rooted.Sub(executed)
+		result.Commit += committed.Sub(rooted)
+	}
+	runtime.ReadMemStats(&after)
+
+	result.Total = result.Execute + result.Root + result.Commit
+	result.TxsPerSecond = float64(result.Txs) / result.Total.Seconds()
+	result.Allocs = after.Mallocs - before.Mallocs
+	result.AllocBytes = after.TotalAlloc - before.TotalAlloc
+	result.WaitSignatures, err = gatherDuration(c.registry, "chain_wait_signatures_sum")
+	if err != nil {
+		return nil, err
+	}
+	result.WaitRoot, err = gatherDuration(c.registry, "chain_wait_root_sum")
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newGenesis returns a new state with the funded accounts of the benchmark
+// and the genesis block built on it
+func (e *ExecutionBenchmark) newGenesis(ctx context.Context) (merkledb.MerkleDB, *chain.ExecutionBlock, error) {
+	db, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		BranchFactor:                merkledb.BranchFactor16,
+		RootGenConcurrency:          uint(runtime.NumCPU()),
+		HistoryLength:               100,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.KiB,
+		IntermediateWriteBatchSize:  units.KiB,
+		Tracer:                      trace.Noop,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mu := state.NewSimpleMutable(db)
+	for _, account := range e.accounts {
+		if err := e.BalanceHandler.AddBalance(ctx, account, mu, e.AccountBalance); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := mu.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	root, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	genesis, err := chain.NewGenesisBlock(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mu = state.NewSimpleMutable(db)
+	if err := mu.Insert(ctx, chain.HeightKey(e.MetadataManager.HeightPrefix()), binary.BigEndian.AppendUint64(nil, 0)); err != nil {
+		return nil, nil, err
+	}
+	if err := mu.Insert(ctx, chain.TimestampKey(e.MetadataManager.TimestampPrefix()), binary.BigEndian.AppendUint64(nil, 0)); err != nil {
+		return nil, nil, err
+	}
+	feeManager := internalfees.NewManager(nil)
+	minUnitPrice := e.Parser.Rules(0).GetMinUnitPrice()
+	for i := fees.Dimension(0); i < fees.FeeDimensions; i++ {
+		feeManager.SetUnitPrice(i, minUnitPrice[i])
+	}
+	if err := mu.Insert(ctx, chain.FeeKey(e.MetadataManager.FeePrefix()), feeManager.Bytes()); err != nil {
+		return nil, nil, err
+	}
+	return db, genesis, mu.Commit(ctx)
+}
+
+type benchmarkChain struct {
+	*chain.Chain
+	registry *prometheus.Registry
+}
+
+func (e *ExecutionBenchmark) newChain(mp chain.Mempool, buildPolicy chain.BuildPolicy, config chain.Config, authVerifiers workers.Workers) (*benchmarkChain, *blockIndex, error) {
+	index := &blockIndex{blocks: make(map[ids.ID]*chain.ExecutionBlock)}
+	registry := prometheus.NewRegistry()
+	c, err := chain.NewChain(
+		trace.Noop,
+		registry,
+		e.Parser,
+		mp,
+		logging.NoLog{},
+		&benchmarkRuleFactory{parser: e.Parser},
+		e.MetadataManager,
+		e.BalanceHandler,
+		authVerifiers,
+		&benchmarkAuthVM{engines: e.AuthEngines},
+		validitywindow.NewTimeValidityWindow(logging.NoLog{}, trace.Noop, index, func(timestamp int64) int64 {
+			return e.Parser.Rules(timestamp).GetTimestampModulus()
+		}),
+		buildPolicy,
+		config,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &benchmarkChain{Chain: c, registry: registry}, index, nil
+}
+
+func gatherDuration(registry *prometheus.Registry, name string) (time.Duration, error) {
+	families, err := registry.Gather()
+	if err != nil {
+		return 0, err
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		var sum float64
+		for _, m := range family.GetMetric() {
+			sum += m.GetGauge().GetValue()
+		}
+		return time.Duration(sum), nil
+	}
+	return 0, nil
+}
+
+type benchmarkRuleFactory struct {
+	parser chain.Parser
+}
+
+func (r *benchmarkRuleFactory) GetRules(t int64) chain.Rules {
+	return r.parser.Rules(t)
+}
+
+type benchmarkAuthVM struct {
+	engines map[uint8]AuthEngine
+}
+
+func (*benchmarkAuthVM) Logger() logging.Logger {
+	return logging.NoLog{}
+}
+
+func (a *benchmarkAuthVM) GetAuthBatchVerifier(authTypeID uint8, cores int, count int) (chain.AuthBatchVerifier, bool) {
+	engine, ok := a.engines[authTypeID]
+	if !ok {
+		return nil, false
+	}
+	return engine.GetBatchVerifier(cores, count), true
+}
+
+// blockIndex provides the ancestors of blocks to the validity window
+type blockIndex struct {
+	blocks map[ids.ID]*chain.ExecutionBlock
+}
+
+func (i *blockIndex) add(blk *chain.ExecutionBlock) {
+	i.blocks[blk.ID()] = blk
+}
+
+func (i *blockIndex) GetExecutionBlock(_ context.Context, blkID ids.ID) (validitywindow.ExecutionBlock[*chain.Transaction], error) {
+	blk, ok := i.blocks[blkID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return blk, nil
+}
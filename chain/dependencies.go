@@ -41,6 +41,13 @@ type Rules interface {
 	GetMinBlockGap() int64      // in milliseconds
 	GetMinEmptyBlockGap() int64 // in milliseconds
 	GetValidityWindow() int64   // in milliseconds
+	GetTimestampModulus() int64 // in milliseconds
+
+	// GetNonceReplayProtection returns true if transactions are protected
+	// against replays by the nonce of their sponsor instead of by their ID
+	// within [GetValidityWindow]. The [BalanceHandler] of the chain must
+	// implement [NonceHandler].
+	GetNonceReplayProtection() bool
 
 	GetMaxActionsPerTx() uint8
 
@@ -101,6 +108,23 @@ type BalanceHandler interface {
 	GetBalance(ctx context.Context, addr codec.Address, im state.Immutable) (uint64, error)
 }
 
+// NonceHandler tracks the last nonce used by each sponsor. It is implemented by
+// the [BalanceHandler] of chains that use nonce replay protection.
+type NonceHandler interface {
+	// SponsorNonceStateKeys is a full enumeration of all database keys that
+	// could be touched to verify and increment the nonce of [addr]. Like
+	// [BalanceHandler.SponsorStateKeys], keys must be suffixed with their max
+	// number of chunks.
+	SponsorNonceStateKeys(addr codec.Address) state.Keys
+
+	// GetNonce returns the last nonce used by [addr].
+	// If [addr] never used a nonce, this should return 0 and no error.
+	GetNonce(ctx context.Context, addr codec.Address, im state.Immutable) (uint64, error)
+
+	// SetNonce sets the last nonce used by [addr] to [nonce].
+	SetNonce(ctx context.Context, addr codec.Address, mu state.Mutable, nonce uint64) error
+}
+
 type Object interface {
 	// GetTypeID uniquely identifies each supported [Action]. We use IDs to avoid
 	// reflection.
@@ -160,6 +184,16 @@ type Action interface {
 	) (codec.Typed, error)
 }
 
+// ComputeUnitsReporter is implemented by the outputs of actions whose
+// compute is only known after execution (e.g. contract calls charged by the
+// fuel they consume). [Action.ComputeUnits] must be an upper bound of the
+// reported units. The fee of the compute units charged but not consumed is
+// refunded to the sponsor.
+type ComputeUnitsReporter interface {
+	// ComputeUnitsConsumed is the compute units consumed by the action
+	ComputeUnitsConsumed() uint64
+}
+
 type Auth interface {
 	Object
 	Marshaler
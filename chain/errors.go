@@ -51,6 +51,13 @@ var (
 	ErrInvalidActor         = errors.New("invalid actor")
 	ErrInvalidSponsor       = errors.New("invalid sponsor")
 	ErrTooManyActions       = errors.New("too many actions")
+	ErrMissingNonce         = errors.New("missing nonce")
+	ErrUnexpectedNonce      = errors.New("unexpected nonce")
+	ErrNonceTooLow          = errors.New("nonce too low")
+	ErrNonceTooHigh         = errors.New("nonce too high")
+	ErrNoncesNotSupported   = errors.New("nonces not supported")
+	ErrInvalidBundle        = errors.New("invalid bundle")
+	ErrBundleFailed         = errors.New("bundle failed")
 
 	// Execution Correctness
 	ErrInvalidBalance  = errors.New("invalid balance")
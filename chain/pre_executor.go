@@ -53,16 +53,20 @@ func (p *PreExecutor) PreExecute(
 	}
 
 	// Find repeats
-	oldestAllowed := now - r.GetValidityWindow()
-	if oldestAllowed < 0 {
-		oldestAllowed = 0
-	}
-	repeatErrs, err := p.validityWindow.IsRepeat(ctx, parentBlk, []*Transaction{tx}, oldestAllowed)
-	if err != nil {
-		return err
-	}
-	if repeatErrs.BitLen() > 0 {
-		return ErrDuplicateTx
+	//
+	// Nonces protect against repeats on chains that use them.
+	if !r.GetNonceReplayProtection() {
+		oldestAllowed := now - r.GetValidityWindow()
+		if oldestAllowed < 0 {
+			oldestAllowed = 0
+		}
+		repeatErrs, err := p.validityWindow.IsRepeat(ctx, parentBlk, []*Transaction{tx}, oldestAllowed)
+		if err != nil {
+			return err
+		}
+		if repeatErrs.BitLen() > 0 {
+			return ErrDuplicateTx
+		}
 	}
 
 	// Ensure state keys are valid
@@ -86,8 +90,9 @@ func (p *PreExecutor) PreExecute(
 	//
 	// Note, [PreExecute] ensures that the pending transaction does not have
 	// an expiry time further ahead than [ValidityWindow]. This ensures anything
-	// added to the [Mempool] is immediately executable.
-	if err := tx.PreExecute(ctx, nextFeeManager, p.balanceHandler, r, view, now); err != nil {
+	// added to the [Mempool] is immediately executable, except for transactions
+	// that use a nonce that follows others in the [Mempool].
+	if err := tx.preExecute(ctx, nextFeeManager, p.balanceHandler, r, view, now, true); err != nil {
 		return err
 	}
 	return nil
@@ -169,8 +169,10 @@ func (p *Processor) Execute(
 		return nil, nil, ErrTimestampTooEarly
 	}
 
-	if err := p.validityWindow.VerifyExpiryReplayProtection(ctx, b, parentTimestamp); err != nil {
-		return nil, nil, err
+	if !r.GetNonceReplayProtection() {
+		if err := p.validityWindow.VerifyExpiryReplayProtection(ctx, b, parentTimestamp); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Compute next unit prices to use
@@ -310,12 +312,23 @@ func (p *Processor) executeTxs(
 		results = make([]*Result, numTxs)
 	)
 
+	// Bundles are executed as a single unit, so we group transactions before
+	// scheduling them
+	groups, err := groupTxs(b.StatelessBlock.Txs)
+	if err != nil {
+		f.Stop()
+		e.Stop()
+		return nil, nil, err
+	}
+
 	// Fetch required keys and execute transactions
-	for li, ltx := range b.StatelessBlock.Txs {
-		i := li
-		tx := ltx
+	offset := 0
+	for _, lgroup := range groups {
+		start := offset
+		group := lgroup
+		offset += len(group)
 
-		stateKeys, err := tx.StateKeys(p.balanceHandler)
+		stateKeys, err := groupStateKeys(group, p.balanceHandler)
 		if err != nil {
 			f.Stop()
 			e.Stop()
@@ -323,46 +336,56 @@ func (p *Processor) executeTxs(
 		}
 
 		// Ensure we don't consume too many units
-		units, err := tx.Units(p.balanceHandler, r)
-		if err != nil {
-			f.Stop()
-			e.Stop()
-			return nil, nil, err
-		}
-		if ok, d := feeManager.Consume(units, r.GetMaxBlockUnits()); !ok {
-			f.Stop()
-			e.Stop()
-			return nil, nil, fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
+		for _, tx := range group {
+			units, err := tx.Units(p.balanceHandler, r)
+			if err != nil {
+				f.Stop()
+				e.Stop()
+				return nil, nil, err
+			}
+			if ok, d := feeManager.Consume(units, r.GetMaxBlockUnits()); !ok {
+				f.Stop()
+				e.Stop()
+				return nil, nil, fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
+			}
 		}
 
 		// Prefetch state keys from disk
-		txID := tx.ID()
-		if err := f.Fetch(ctx, txID, stateKeys); err != nil {
+		//
+		// Groups are keyed by the ID of their first transaction.
+		groupID := group[0].ID()
+		if err := f.Fetch(ctx, groupID, stateKeys); err != nil {
 			return nil, nil, err
 		}
 		e.Run(stateKeys, func() error {
 			// Wait for stateKeys to be read from disk
-			storage, err := f.Get(txID)
+			storage, err := f.Get(groupID)
 			if err != nil {
 				return err
 			}
 
-			// Execute transaction
+			// Execute transactions
 			//
-			// It is critical we explicitly set the scope before each transaction is
+			// It is critical we explicitly set the scope before each group is
 			// processed
 			tsv := ts.NewView(stateKeys, storage)
-
-			// Ensure we have enough funds to pay fees
-			if err := tx.PreExecute(ctx, feeManager, p.balanceHandler, r, tsv, t); err != nil {
-				return err
-			}
-
-			result, err := tx.Execute(ctx, feeManager, p.balanceHandler, r, tsv, t)
-			if err != nil {
-				return err
+			for i, tx := range group {
+				// Ensure we have enough funds to pay fees
+				if err := tx.PreExecute(ctx, feeManager, p.balanceHandler, r, tsv, t); err != nil {
+					return err
+				}
+
+				result, err := tx.Execute(ctx, feeManager, p.balanceHandler, r, tsv, t)
+				if err != nil {
+					return err
+				}
+
+				// All members of a bundle must succeed
+				if len(group) > 1 && !result.Success {
+					return fmt.Errorf("%w: bundle=%s tx=%s", ErrBundleFailed, tx.Base.Bundle, tx.ID())
+				}
+				results[start+i] = result
 			}
-			results[i] = result
 
 			// Commit results to parent [TState]
 			tsv.Commit()
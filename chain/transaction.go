@@ -104,6 +104,8 @@ func (t *TransactionData) Expiry() int64 { return t.Base.Timestamp }
 
 func (t *TransactionData) MaxFee() uint64 { return t.Base.MaxFee }
 
+func (t *TransactionData) Nonce() uint64 { return t.Base.Nonce }
+
 func (t *TransactionData) Marshal(p *codec.Packer) error {
 	if len(t.unsignedBytes) > 0 {
 		p.PackFixedBytes(t.unsignedBytes)
@@ -186,6 +188,9 @@ func (t *Transaction) Size() int { return t.size }
 
 func (t *Transaction) ID() ids.ID { return t.id }
 
+// GossipID implements the avalanchego gossip.Gossipable interface
+func (t *Transaction) GossipID() ids.ID { return t.id }
+
 func (t *Transaction) StateKeys(bh BalanceHandler) (state.Keys, error) {
 	if t.stateKeys != nil {
 		return t.stateKeys, nil
@@ -205,6 +210,17 @@ func (t *Transaction) StateKeys(bh BalanceHandler) (state.Keys, error) {
 			return nil, ErrInvalidKeyValue
 		}
 	}
+	if t.Base.Nonce != 0 {
+		nh, ok := bh.(NonceHandler)
+		if !ok {
+			return nil, ErrNoncesNotSupported
+		}
+		for k, v := range nh.SponsorNonceStateKeys(t.Auth.Sponsor()) {
+			if !stateKeys.Add(k, v) {
+				return nil, ErrInvalidKeyValue
+			}
+		}
+	}
 
 	// Cache keys if called again
 	t.stateKeys = stateKeys
@@ -269,10 +285,28 @@ func (t *Transaction) PreExecute(
 	r Rules,
 	im state.Immutable,
 	timestamp int64,
+) error {
+	return t.preExecute(ctx, feeManager, bh, r, im, timestamp, false)
+}
+
+// preExecute allows a nonce that is higher than the next nonce of the sponsor
+// if [allowFutureNonce] is true. This is used to accept transactions into the
+// mempool before their predecessors are included.
+func (t *Transaction) preExecute(
+	ctx context.Context,
+	feeManager *internalfees.Manager,
+	bh BalanceHandler,
+	r Rules,
+	im state.Immutable,
+	timestamp int64,
+	allowFutureNonce bool,
 ) error {
 	if err := t.Base.Execute(r, timestamp); err != nil {
 		return err
 	}
+	if err := t.verifyNonce(ctx, bh, im, allowFutureNonce); err != nil {
+		return err
+	}
 	if len(t.Actions) > int(r.GetMaxActionsPerTx()) {
 		return ErrTooManyActions
 	}
@@ -303,6 +337,28 @@ func (t *Transaction) PreExecute(
 	return bh.CanDeduct(ctx, t.Auth.Sponsor(), im, fee)
 }
 
+func (t *Transaction) verifyNonce(ctx context.Context, bh BalanceHandler, im state.Immutable, allowFuture bool) error {
+	if t.Base.Nonce == 0 {
+		return nil
+	}
+	nh, ok := bh.(NonceHandler)
+	if !ok {
+		return ErrNoncesNotSupported
+	}
+	last, err := nh.GetNonce(ctx, t.Auth.Sponsor(), im)
+	if err != nil {
+		return err
+	}
+	switch {
+	case t.Base.Nonce <= last:
+		return fmt.Errorf("%w: nonce=%d last=%d", ErrNonceTooLow, t.Base.Nonce, last)
+	case t.Base.Nonce > last+1 && !allowFuture:
+		return fmt.Errorf("%w: nonce=%d last=%d", ErrNonceTooHigh, t.Base.Nonce, last)
+	default:
+		return nil
+	}
+}
+
 // Execute after knowing a transaction can pay a fee. Attempt
 // to charge the fee in as many cases as possible.
 //
@@ -331,14 +387,21 @@ func (t *Transaction) Execute(
 		// immediately before).
 		return nil, fmt.Errorf("failed to deduct tx fee: %w", err)
 	}
+	if t.Base.Nonce != 0 {
+		// [PreExecute] ensures that [bh] is a [NonceHandler]
+		if err := bh.(NonceHandler).SetNonce(ctx, t.Auth.Sponsor(), ts, t.Base.Nonce); err != nil {
+			return nil, fmt.Errorf("failed to set tx nonce: %w", err)
+		}
+	}
 
 	// We create a temp state checkpoint to ensure we don't commit failed actions to state.
 	//
 	// We should favor reverting over returning an error because the caller won't be charged
 	// for a transaction that returns an error.
 	var (
-		actionStart   = ts.OpIndex()
-		actionOutputs = [][]byte{}
+		actionStart     = ts.OpIndex()
+		actionOutputs   = [][]byte{}
+		unconsumedUnits = uint64(0)
 	)
 	for i, action := range t.Actions {
 		actionOutput, err := action.Execute(ctx, r, ts, timestamp, t.Auth.Actor(), CreateActionID(t.ID(), uint8(i)))
@@ -346,6 +409,11 @@ func (t *Transaction) Execute(
 			ts.Rollback(ctx, actionStart)
 			return &Result{false, utils.ErrBytes(err), actionOutputs, units, fee}, nil
 		}
+		if reporter, ok := actionOutput.(ComputeUnitsReporter); ok {
+			if charged, consumed := action.ComputeUnits(r), reporter.ComputeUnitsConsumed(); consumed < charged {
+				unconsumedUnits += charged - consumed
+			}
+		}
 
 		var encodedOutput []byte
 		if actionOutput == nil {
@@ -361,6 +429,23 @@ func (t *Transaction) Execute(
 
 		actionOutputs = append(actionOutputs, encodedOutput)
 	}
+
+	// Refund the compute units that were charged but not consumed. [units]
+	// is left unchanged because blocks are limited by the units that may be
+	// consumed.
+	if unconsumedUnits > 0 {
+		chargedUnits := units
+		chargedUnits[fees.Compute] -= min(unconsumedUnits, chargedUnits[fees.Compute])
+		chargedFee, err := feeManager.Fee(chargedUnits)
+		if err != nil {
+			// Should never happen
+			return nil, fmt.Errorf("failed to calculate tx fee: %w", err)
+		}
+		if err := bh.AddBalance(ctx, t.Auth.Sponsor(), ts, fee-chargedFee); err != nil {
+			return nil, fmt.Errorf("failed to refund tx fee: %w", err)
+		}
+		fee = chargedFee
+	}
 	return &Result{
 		Success: true,
 		Error:   []byte{},
@@ -559,6 +644,11 @@ func EstimateUnits(r Rules, actions Actions, authFactory AuthFactory) (fees.Dime
 		writesOp           = math.NewUint64Operator(0)
 	)
 
+	if r.GetNonceReplayProtection() {
+		// Txs with a nonce use the extended [Base] encoding
+		bandwidth += consts.ByteLen + consts.Uint64Len
+	}
+
 	// Calculate over action/auth
 	bandwidth += consts.Uint8Len
 	for i, action := range actions {
@@ -5,9 +5,12 @@ package chain_test
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/stretchr/testify/require"
 
@@ -17,8 +20,14 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/genesis"
+	"github.com/ava-labs/hypersdk/keys"
 	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/state/tstate"
 	"github.com/ava-labs/hypersdk/utils"
+
+	internalfees "github.com/ava-labs/hypersdk/internal/fees"
 )
 
 var (
@@ -252,3 +261,171 @@ func TestSignRawActionBytesTx(t *testing.T) {
 	require.NoError(err)
 	require.Equal(signedTx.Bytes(), rawSignedTxBytes)
 }
+
+var (
+	_ chain.Action               = (*computeAction)(nil)
+	_ chain.ComputeUnitsReporter = (*computeOutput)(nil)
+	_ chain.BalanceHandler       = (*testBalanceHandler)(nil)
+)
+
+// computeAction charges [Charged] compute units and consumes [Consumed]
+type computeAction struct {
+	abstractMockAction
+	Charged  uint64 `serialize:"true" json:"charged"`
+	Consumed uint64 `serialize:"true" json:"consumed"`
+}
+
+func (*computeAction) GetTypeID() uint8 {
+	return 123
+}
+
+func (c *computeAction) ComputeUnits(chain.Rules) uint64 {
+	return c.Charged
+}
+
+func (*computeAction) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{}
+}
+
+func (c *computeAction) Execute(context.Context, chain.Rules, state.Mutable, int64, codec.Address, ids.ID) (codec.Typed, error) {
+	return &computeOutput{Consumed: c.Consumed}, nil
+}
+
+type computeOutput struct {
+	Consumed uint64 `serialize:"true" json:"consumed"`
+}
+
+func (*computeOutput) GetTypeID() uint8 {
+	return 123
+}
+
+func (c *computeOutput) ComputeUnitsConsumed() uint64 {
+	return c.Consumed
+}
+
+// testBalanceHandler stores the balance of each address at the address
+type testBalanceHandler struct{}
+
+func (*testBalanceHandler) key(addr codec.Address) []byte {
+	return keys.EncodeChunks(addr[:], 1)
+}
+
+func (b *testBalanceHandler) SponsorStateKeys(addr codec.Address) state.Keys {
+	return state.Keys{string(b.key(addr)): state.Read | state.Write}
+}
+
+func (b *testBalanceHandler) CanDeduct(ctx context.Context, addr codec.Address, im state.Immutable, amount uint64) error {
+	balance, err := b.GetBalance(ctx, addr, im)
+	if err != nil {
+		return err
+	}
+	if balance < amount {
+		return errors.New("insufficient balance")
+	}
+	return nil
+}
+
+func (b *testBalanceHandler) Deduct(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error {
+	if err := b.CanDeduct(ctx, addr, mu, amount); err != nil {
+		return err
+	}
+	balance, err := b.GetBalance(ctx, addr, mu)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, b.key(addr), binary.BigEndian.AppendUint64(nil, balance-amount))
+}
+
+func (b *testBalanceHandler) AddBalance(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error {
+	balance, err := b.GetBalance(ctx, addr, mu)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, b.key(addr), binary.BigEndian.AppendUint64(nil, balance+amount))
+}
+
+func (b *testBalanceHandler) GetBalance(ctx context.Context, addr codec.Address, im state.Immutable) (uint64, error) {
+	value, err := im.GetValue(ctx, b.key(addr))
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// Compute units charged but not consumed are refunded to the sponsor
+func TestExecuteRefundsUnconsumedComputeUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		charged  uint64
+		consumed uint64
+		refund   uint64
+	}{
+		{
+			name:     "consumed less than charged",
+			charged:  10,
+			consumed: 4,
+			refund:   6,
+		},
+		{
+			name:     "consumed all charged",
+			charged:  10,
+			consumed: 10,
+		},
+		{
+			name:     "consumed more than charged",
+			charged:  10,
+			consumed: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			ctx := context.Background()
+
+			rules := genesis.NewDefaultRules()
+			feeManager := internalfees.NewManager(nil)
+			for i := fees.Dimension(0); i < fees.FeeDimensions; i++ {
+				feeManager.SetUnitPrice(i, 1)
+			}
+
+			priv, err := ed25519.GeneratePrivateKey()
+			r.NoError(err)
+			factory := auth.NewED25519Factory(priv)
+			txData := chain.TransactionData{
+				Base: &chain.Base{
+					Timestamp: 1_000,
+					MaxFee:    consts.MaxUint64,
+				},
+				Actions: []chain.Action{
+					&computeAction{Charged: tt.charged, Consumed: tt.consumed},
+				},
+			}
+			tx, err := txData.Sign(factory)
+			r.NoError(err)
+
+			bh := &testBalanceHandler{}
+			store := chaintest.NewInMemoryStore()
+			const balance = 1_000_000
+			r.NoError(bh.AddBalance(ctx, tx.Sponsor(), store, balance))
+
+			units, err := tx.Units(bh, rules)
+			r.NoError(err)
+			maxFee, err := feeManager.Fee(units)
+			r.NoError(err)
+
+			tsv := tstate.New(1).NewView(bh.SponsorStateKeys(tx.Sponsor()), store.Storage)
+			result, err := tx.Execute(ctx, feeManager, bh, rules, tsv, 1_000)
+			r.NoError(err)
+			r.True(result.Success)
+			r.Equal(units, result.Units)
+			r.Equal(maxFee-tt.refund, result.Fee)
+
+			remaining, err := bh.GetBalance(ctx, tx.Sponsor(), tsv)
+			r.NoError(err)
+			r.Equal(balance-result.Fee, remaining)
+		})
+	}
+}
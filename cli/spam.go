@@ -15,6 +15,11 @@ import (
 // BuildSpammer prompts the user for the spammer parameters. If [defaults], the default values are used once the
 // chain and root key are selected. Otherwise, the user is prompted for all parameters.
 func (h *Handler) BuildSpammer(sh throughput.SpamHelper, defaults bool) (*throughput.Spammer, error) {
+	return h.BuildSpammerWithProfile(sh, defaults, throughput.DefaultProfile())
+}
+
+// BuildSpammerWithProfile is [BuildSpammer] with a workload [profile]
+func (h *Handler) BuildSpammerWithProfile(sh throughput.SpamHelper, defaults bool, profile *throughput.Profile) (*throughput.Spammer, error) {
 	// Select chain
 	chains, err := h.GetChains()
 	if err != nil {
@@ -50,7 +55,7 @@ func (h *Handler) BuildSpammer(sh throughput.SpamHelper, defaults bool) (*throug
 	}
 
 	if defaults {
-		sc := throughput.NewDefaultConfig(uris, authFactory)
+		sc := throughput.NewDefaultConfig(uris, authFactory).WithProfile(profile)
 		return throughput.NewSpammer(sc, sh)
 	}
 	// Collect parameters
@@ -97,16 +102,39 @@ func (h *Handler) BuildSpammer(sh throughput.SpamHelper, defaults bool) (*throug
 		txsPerSecondStep,
 		numClients,
 		numAccounts,
-	)
+	).WithProfile(profile)
 
 	return throughput.NewSpammer(sc, sh)
 }
 
 func (h *Handler) Spam(ctx context.Context, sh throughput.SpamHelper, defaults bool) error {
-	spammer, err := h.BuildSpammer(sh, defaults)
+	return h.SpamWithProfile(ctx, sh, defaults, "", "")
+}
+
+// SpamWithProfile runs the spammer with the workload profile at [profilePath]
+// and writes the report of the run to [reportPath]. The default profile is
+// used if [profilePath] is empty and no report is written if [reportPath] is
+// empty.
+func (h *Handler) SpamWithProfile(ctx context.Context, sh throughput.SpamHelper, defaults bool, profilePath string, reportPath string) error {
+	profile := throughput.DefaultProfile()
+	if profilePath != "" {
+		var err error
+		profile, err = throughput.LoadProfile(profilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	spammer, err := h.BuildSpammerWithProfile(sh, defaults, profile)
 	if err != nil {
 		return err
 	}
 
-	return spammer.Spam(ctx, sh, false, h.c.Symbol())
+	if err := spammer.Spam(ctx, sh, false, h.c.Symbol()); err != nil {
+		return err
+	}
+	if reportPath == "" {
+		return nil
+	}
+	return spammer.Report().WriteFile(reportPath)
 }
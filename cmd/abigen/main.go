@@ -14,45 +14,75 @@ import (
 	"github.com/ava-labs/hypersdk/abi"
 )
 
+const (
+	langGo         = "go"
+	langTypeScript = "typescript"
+	langRust       = "rust"
+)
+
 var (
 	packageName string
+	lang        string
 	rootCmd     = &cobra.Command{
-		Use:   "abigen <input_abi.json> <output_file.go>",
-		Short: "Generate Go structs from ABI JSON",
+		Use:   "abigen <input_abi.json> <output_file>",
+		Short: "Generate Go, TypeScript or Rust types from ABI JSON",
 		Args:  cobra.ExactArgs(2),
 		RunE:  run,
 	}
+	checkCmd = &cobra.Command{
+		Use:   "check <previous_abi.json> <next_abi.json>",
+		Short: "Report breaking changes between two ABIs",
+		Args:  cobra.ExactArgs(2),
+		RunE:  check,
+		// Breaking changes are not usage errors
+		SilenceUsage: true,
+	}
 )
 
 func init() {
-	rootCmd.Flags().StringVarP(&packageName, "package", "p", "", "Package name for generated code (overrides default)")
+	rootCmd.Flags().StringVarP(&packageName, "package", "p", "", "Package name for generated Go code (overrides default)")
+	rootCmd.Flags().StringVarP(&lang, "lang", "l", langGo, "Language of the generated code (go, typescript or rust)")
+	rootCmd.AddCommand(checkCmd)
 }
 
-func run(_ *cobra.Command, args []string) error {
-	inputFile := args[0]
-	outputFile := args[1]
-
-	// Read the input ABI JSON file
-	abiData, err := os.ReadFile(inputFile)
+func readABI(file string) (abi.ABI, error) {
+	abiData, err := os.ReadFile(file)
 	if err != nil {
-		return fmt.Errorf("error reading input file: %w", err)
+		return abi.ABI{}, fmt.Errorf("error reading input file: %w", err)
 	}
 
-	// Parse the ABI JSON
 	var vmABI abi.ABI
-	err = json.Unmarshal(abiData, &vmABI)
-	if err != nil {
-		return fmt.Errorf("error parsing ABI JSON: %w", err)
+	if err := json.Unmarshal(abiData, &vmABI); err != nil {
+		return abi.ABI{}, fmt.Errorf("error parsing ABI JSON: %w", err)
 	}
+	return vmABI, nil
+}
+
+func run(_ *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile := args[1]
 
-	if packageName == "" {
-		packageName = filepath.Base(filepath.Dir(outputFile))
+	vmABI, err := readABI(inputFile)
+	if err != nil {
+		return err
 	}
 
-	// Generate Go structs
-	generatedCode, err := abi.GenerateGoStructs(vmABI, packageName)
+	var generatedCode string
+	switch lang {
+	case langGo:
+		if packageName == "" {
+			packageName = filepath.Base(filepath.Dir(outputFile))
+		}
+		generatedCode, err = abi.GenerateGoStructs(vmABI, packageName)
+	case langTypeScript:
+		generatedCode, err = abi.GenerateTypeScript(vmABI)
+	case langRust:
+		generatedCode, err = abi.GenerateRust(vmABI)
+	default:
+		return fmt.Errorf("unsupported language %q", lang)
+	}
 	if err != nil {
-		return fmt.Errorf("error generating Go structs: %w", err)
+		return fmt.Errorf("error generating %s code: %w", lang, err)
 	}
 
 	// Create the directory for the output file if it doesn't exist
@@ -67,7 +97,38 @@ func run(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("error writing output file: %w", err)
 	}
 
-	fmt.Printf("Successfully generated Go structs in %s\n", outputFile)
+	fmt.Printf("Successfully generated %s code in %s\n", lang, outputFile)
+	return nil
+}
+
+func check(_ *cobra.Command, args []string) error {
+	previous, err := readABI(args[0])
+	if err != nil {
+		return err
+	}
+	next, err := readABI(args[1])
+	if err != nil {
+		return err
+	}
+
+	previousHash, err := previous.Hash()
+	if err != nil {
+		return fmt.Errorf("error hashing %s: %w", args[0], err)
+	}
+	nextHash, err := next.Hash()
+	if err != nil {
+		return fmt.Errorf("error hashing %s: %w", args[1], err)
+	}
+	fmt.Printf("%s: %s\n%s: %s\n", args[0], previousHash, args[1], nextHash)
+
+	errs := abi.CheckCompatibility(previous, next)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("found %d breaking changes", len(errs))
+	}
+	fmt.Println("No breaking changes")
 	return nil
 }
 
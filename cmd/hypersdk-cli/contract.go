@@ -0,0 +1,156 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/abi"
+	"github.com/ava-labs/hypersdk/abi/dynamic"
+	"github.com/ava-labs/hypersdk/api/jsonrpc"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+var contractCmd = &cobra.Command{
+	Use:   "contract",
+	Short: "Encode and decode contract calls with the ABI published by the contract",
+}
+
+var contractABICmd = &cobra.Command{
+	Use:   "abi [address]",
+	Short: "Print the functions of a contract",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contractABI, err := getContractABI(cmd, args[0])
+		if err != nil {
+			return err
+		}
+
+		return printValue(cmd, contractABIWrapper{ABI: contractABI})
+	},
+}
+
+var contractCallDataCmd = &cobra.Command{
+	Use:   "calldata [address] [function] [json arguments]",
+	Short: "Encode the call data of a contract function from JSON arguments",
+	Args:  cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contractABI, err := getContractABI(cmd, args[0])
+		if err != nil {
+			return err
+		}
+
+		var jsonArgs string
+		if len(args) == 3 {
+			jsonArgs = args[2]
+		}
+		callData, err := dynamic.MarshalCallData(contractABI, args[1], jsonArgs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal call data: %w", err)
+		}
+
+		return printValue(cmd, contractCallDataCmdResponse{
+			CallData: codec.ToHex(callData),
+		})
+	},
+}
+
+var contractResultCmd = &cobra.Command{
+	Use:   "result [address] [function] [value]",
+	Short: "Decode the value returned by a contract function into JSON",
+	Long: `Decode the value returned by a contract function into JSON.
+
+[value] is the hex encoded value field of the output of a contract call, not
+the serialized output itself.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contractABI, err := getContractABI(cmd, args[0])
+		if err != nil {
+			return err
+		}
+
+		value, err := codec.LoadHex(args[2], -1)
+		if err != nil {
+			return fmt.Errorf("failed to decode value: %w", err)
+		}
+		result, err := dynamic.UnmarshalCallResult(contractABI, args[1], value)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal result: %w", err)
+		}
+
+		return printValue(cmd, contractResultCmdResponse{
+			Result: result,
+		})
+	},
+}
+
+func getContractABI(cmd *cobra.Command, addressStr string) (abi.ContractABI, error) {
+	address, err := codec.StringToAddress(addressStr)
+	if err != nil {
+		return abi.ContractABI{}, fmt.Errorf("failed to convert contract to address: %w", err)
+	}
+
+	endpoint, err := getConfigValue(cmd, "endpoint", true)
+	if err != nil {
+		return abi.ContractABI{}, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+	client := jsonrpc.NewJSONRPCClient(endpoint)
+
+	contractABI, err := client.GetContractABI(context.Background(), address)
+	if err != nil {
+		return abi.ContractABI{}, fmt.Errorf("failed to get contract ABI: %w", err)
+	}
+	return contractABI, nil
+}
+
+type contractABIWrapper struct {
+	ABI abi.ContractABI
+}
+
+func (c contractABIWrapper) String() string {
+	var result strings.Builder
+	for _, function := range c.ABI.Functions {
+		args := make([]string, len(function.Args))
+		for i, arg := range function.Args {
+			args[i] = fmt.Sprintf("%s: %s", arg.Name, arg.Type)
+		}
+		result.WriteString(fmt.Sprintf("%s(%s)", function.Name, strings.Join(args, ", ")))
+		if function.Output != "" {
+			result.WriteString(" -> " + function.Output)
+		}
+		result.WriteString("\n")
+	}
+	for _, typ := range c.ABI.Types {
+		result.WriteString(fmt.Sprintf("---\n%s\n", typ.Name))
+		for _, field := range typ.Fields {
+			result.WriteString(fmt.Sprintf("  %s: %s\n", field.Name, field.Type))
+		}
+	}
+	return result.String()
+}
+
+type contractCallDataCmdResponse struct {
+	CallData string `json:"calldata"`
+}
+
+func (r contractCallDataCmdResponse) String() string {
+	return r.CallData
+}
+
+type contractResultCmdResponse struct {
+	Result string `json:"result"`
+}
+
+func (r contractResultCmdResponse) String() string {
+	return r.Result
+}
+
+func init() {
+	contractCmd.AddCommand(contractABICmd, contractCallDataCmd, contractResultCmd)
+	rootCmd.AddCommand(contractCmd)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigInt is an arbitrary precision integer that LinearCodec can serialize,
+// which it cannot do for *big.Int. It is serialized as its sign followed by
+// the length-prefixed big-endian bytes of its absolute value and is encoded
+// in JSON as a decimal string.
+type BigInt struct {
+	Negative bool   `serialize:"true"`
+	Abs      []byte `serialize:"true"`
+}
+
+// NewBigInt returns the canonical BigInt representation of [v]. A nil [v] is
+// treated as zero.
+func NewBigInt(v *big.Int) BigInt {
+	if v == nil {
+		return BigInt{Abs: []byte{}}
+	}
+	return BigInt{
+		Negative: v.Sign() < 0,
+		Abs:      v.Bytes(),
+	}
+}
+
+// Int returns [b] as a *big.Int
+func (b BigInt) Int() *big.Int {
+	v := new(big.Int).SetBytes(b.Abs)
+	if b.Negative {
+		v.Neg(v)
+	}
+	return v
+}
+
+// String implements fmt.Stringer.
+func (b BigInt) String() string {
+	return b.Int().String()
+}
+
+// MarshalText returns the decimal representation of b.
+func (b BigInt) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText parses a decimal integer
+func (b *BigInt) UnmarshalText(input []byte) error {
+	v, ok := new(big.Int).SetString(string(input), 10)
+	if !ok {
+		return fmt.Errorf("%w: invalid integer %q", ErrInvalidSize, input)
+	}
+	*b = NewBigInt(v)
+	return nil
+}
+
+// PackBigInt packs [v] in the same layout as LinearCodec serializes BigInt
+func (p *Packer) PackBigInt(v *big.Int) {
+	b := NewBigInt(v)
+	p.Packer.PackBool(b.Negative)
+	p.Packer.PackBytes(b.Abs)
+}
+
+// UnpackBigInt unpacks an integer whose absolute value is at most [limit]
+// bytes. Only the canonical encoding of the integer is accepted.
+func (p *Packer) UnpackBigInt(limit int) *big.Int {
+	b := BigInt{
+		Negative: p.Packer.UnpackBool(),
+		Abs:      p.Packer.UnpackLimitedBytes(uint32(limit)),
+	}
+	if p.Errored() {
+		return new(big.Int)
+	}
+	if (len(b.Abs) > 0 && b.Abs[0] == 0) || (b.Negative && len(b.Abs) == 0) {
+		p.addErr(fmt.Errorf("%w: non-canonical integer", ErrInvalidSize))
+		return new(big.Int)
+	}
+	return b.Int()
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigInt(t *testing.T) {
+	for _, s := range []string{"0", "1", "-1", "255", "-256", "123456789012345678901234567890"} {
+		t.Run(s, func(t *testing.T) {
+			require := require.New(t)
+
+			v, ok := new(big.Int).SetString(s, 10)
+			require.True(ok)
+			b := NewBigInt(v)
+			require.Zero(v.Cmp(b.Int()))
+
+			// JSON is a decimal string
+			jsonBytes, err := json.Marshal(b)
+			require.NoError(err)
+			require.Equal(`"`+s+`"`, string(jsonBytes))
+			var parsed BigInt
+			require.NoError(json.Unmarshal(jsonBytes, &parsed))
+			require.Equal(b, parsed)
+
+			// PackBigInt matches LinearCodec
+			p := NewWriter(0, 1024)
+			p.PackBigInt(v)
+			require.NoError(p.Err())
+			linear := wrappers.Packer{MaxSize: 1024}
+			require.NoError(LinearCodec.MarshalInto(b, &linear))
+			require.Equal(linear.Bytes, p.Bytes())
+
+			r := NewReader(p.Bytes(), 1024)
+			require.Zero(v.Cmp(r.UnpackBigInt(32)))
+			require.NoError(r.Err())
+			require.True(r.Empty())
+		})
+	}
+}
+
+func TestUnpackBigIntNonCanonical(t *testing.T) {
+	for name, bytes := range map[string][]byte{
+		"leading zero":  {0, 0, 0, 0, 2, 0, 1},
+		"negative zero": {1, 0, 0, 0, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := NewReader(bytes, 1024)
+			r.UnpackBigInt(32)
+			require.ErrorIs(t, r.Err(), ErrInvalidSize)
+		})
+	}
+}
@@ -26,5 +26,6 @@ func BytesLenSize(msgSize int) int {
 }
 
 func StringLen(msg string) int {
-	return consts.IntLen + len(msg)
+	// strings are prefixed with a uint16 length
+	return consts.Uint16Len + len(msg)
 }
@@ -31,6 +31,8 @@ var (
 	hideTxs               bool
 	checkAllChains        bool
 	spamDefaults          bool
+	spamProfile           string
+	spamReport            string
 	prometheusBaseURI     string
 	prometheusOpenBrowser bool
 	prometheusFile        string
@@ -149,6 +151,18 @@ func init() {
 		false,
 		"use default spam parameters",
 	)
+	runSpamCmd.PersistentFlags().StringVar(
+		&spamProfile,
+		"profile",
+		"",
+		"path of a JSON workload profile",
+	)
+	runSpamCmd.PersistentFlags().StringVar(
+		&spamReport,
+		"report",
+		"",
+		"path to write the JSON report of the run",
+	)
 
 	// spam
 	spamCmd.AddCommand(
@@ -29,6 +29,6 @@ var runSpamCmd = &cobra.Command{
 	},
 	RunE: func(_ *cobra.Command, args []string) error {
 		ctx := context.Background()
-		return handler.Root().Spam(ctx, &throughput.SpamHelper{KeyType: args[0]}, spamDefaults)
+		return handler.Root().SpamWithProfile(ctx, &throughput.SpamHelper{KeyType: args[0]}, spamDefaults, spamProfile, spamReport)
 	},
 }
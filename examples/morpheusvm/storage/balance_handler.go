@@ -11,7 +11,10 @@ import (
 	"github.com/ava-labs/hypersdk/state"
 )
 
-var _ (chain.BalanceHandler) = (*BalanceHandler)(nil)
+var (
+	_ (chain.BalanceHandler) = (*BalanceHandler)(nil)
+	_ (chain.NonceHandler)   = (*BalanceHandler)(nil)
+)
 
 type BalanceHandler struct{}
 
@@ -60,3 +63,17 @@ func (*BalanceHandler) AddBalance(
 func (*BalanceHandler) GetBalance(ctx context.Context, addr codec.Address, im state.Immutable) (uint64, error) {
 	return GetBalance(ctx, im, addr)
 }
+
+func (*BalanceHandler) SponsorNonceStateKeys(addr codec.Address) state.Keys {
+	return state.Keys{
+		string(NonceKey(addr)): state.All,
+	}
+}
+
+func (*BalanceHandler) GetNonce(ctx context.Context, addr codec.Address, im state.Immutable) (uint64, error) {
+	return GetNonce(ctx, im, addr)
+}
+
+func (*BalanceHandler) SetNonce(ctx context.Context, addr codec.Address, mu state.Mutable, nonce uint64) error {
+	return SetNonce(ctx, mu, addr, nonce)
+}
@@ -28,10 +28,18 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 //
 // 0x3/ (balance)
 //   -> [owner] => balance
+// 0x4/ (nonce)
+//   -> [sponsor] => last nonce
 
-const balancePrefix byte = metadata.DefaultMinimumPrefix
+const (
+	balancePrefix byte = metadata.DefaultMinimumPrefix
+	noncePrefix   byte = balancePrefix + 1
+)
 
-const BalanceChunks uint16 = 1
+const (
+	BalanceChunks uint16 = 1
+	NonceChunks   uint16 = 1
+)
 
 // [balancePrefix] + [address]
 func BalanceKey(addr codec.Address) (k []byte) {
@@ -163,3 +171,50 @@ func SubBalance(
 	}
 	return nbal, setBalance(ctx, mu, key, nbal)
 }
+
+// [noncePrefix] + [address]
+func NonceKey(addr codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)
+	k[0] = noncePrefix
+	copy(k[1:], addr[:])
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], NonceChunks)
+	return
+}
+
+// If the nonce is 0, then [addr] never sponsored a transaction with a nonce
+func GetNonce(
+	ctx context.Context,
+	im state.Immutable,
+	addr codec.Address,
+) (uint64, error) {
+	return innerGetNonce(im.GetValue(ctx, NonceKey(addr)))
+}
+
+// Used to serve RPC queries
+func GetNonceFromState(
+	ctx context.Context,
+	f ReadState,
+	addr codec.Address,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{NonceKey(addr)})
+	return innerGetNonce(values[0], errs[0])
+}
+
+func innerGetNonce(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return database.ParseUInt64(v)
+}
+
+func SetNonce(
+	ctx context.Context,
+	mu state.Mutable,
+	addr codec.Address,
+	nonce uint64,
+) error {
+	return mu.Insert(ctx, NonceKey(addr), binary.BigEndian.AppendUint64(nil, nonce))
+}
@@ -9,6 +9,8 @@ package throughput
 import (
 	"context"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"sync/atomic"
 
 	"golang.org/x/exp/rand"
@@ -32,7 +34,14 @@ type SpamHelper struct {
 	sent atomic.Int64
 }
 
-var _ throughput.SpamHelper = &SpamHelper{}
+// transferAction is the name of [actions.Transfer] in workload profiles
+const transferAction = "transfer"
+
+var (
+	ErrUnknownAction = errors.New("unknown action")
+
+	_ throughput.WorkloadHelper = &SpamHelper{}
+)
 
 func (sh *SpamHelper) CreateAccount() (*auth.PrivateKey, error) {
 	pk, err := vm.AuthProvider.GeneratePrivateKey(sh.KeyType)
@@ -81,6 +90,20 @@ func (sh *SpamHelper) GetActions() []chain.Action {
 	return sh.GetTransfer(sh.pks[pkIndex].Address, 1, sh.uniqueBytes())
 }
 
+func (sh *SpamHelper) GetAction(name string, target codec.Address) (chain.Action, error) {
+	switch name {
+	case transferAction:
+		// transfers 1 unit to [target], contending on its balance
+		return &actions.Transfer{
+			To:    target,
+			Value: 1,
+			Memo:  sh.uniqueBytes(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, name)
+	}
+}
+
 func (sh *SpamHelper) uniqueBytes() []byte {
 	return binary.BigEndian.AppendUint64(nil, uint64(sh.sent.Add(1)))
 }
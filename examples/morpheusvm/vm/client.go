@@ -65,6 +65,21 @@ func (cli *JSONRPCClient) Balance(ctx context.Context, addr codec.Address) (uint
 	return resp.Amount, err
 }
 
+// Nonce returns the last nonce used by [addr]. The next transaction sponsored
+// by [addr] must use the following nonce.
+func (cli *JSONRPCClient) Nonce(ctx context.Context, addr codec.Address) (uint64, error) {
+	resp := new(NonceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nonce",
+		&NonceArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Nonce, err
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr codec.Address,
@@ -0,0 +1,120 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/genesis"
+	"github.com/ava-labs/hypersdk/state/metadata"
+)
+
+func newTransferBenchmark(numTxs int, nonces bool) *chaintest.ExecutionBenchmark {
+	engines := make(map[uint8]chaintest.AuthEngine)
+	for typeID, engine := range auth.Engines() {
+		engines[typeID] = engine
+	}
+	g := genesis.NewDefaultGenesis(nil)
+	g.Rules.ChainID = ids.GenerateTestID()
+	if nonces {
+		g.Rules.NonceReplayProtection = true
+		g.Rules.SponsorStateKeysMaxChunks = []uint16{storage.BalanceChunks, storage.NonceChunks}
+	}
+	return &chaintest.ExecutionBenchmark{
+		Parser:          NewParser(g),
+		MetadataManager: metadata.NewDefaultManager(),
+		BalanceHandler:  &storage.BalanceHandler{},
+		AuthEngines:     engines,
+		NumAccounts:     100,
+		AccountBalance:  1_000_000_000_000,
+		NewAuthFactory: func() (chain.AuthFactory, error) {
+			priv, err := ed25519.GeneratePrivateKey()
+			if err != nil {
+				return nil, err
+			}
+			return auth.NewED25519Factory(priv), nil
+		},
+		GenerateActions: func(i int, _ codec.Address, accounts []codec.Address) ([]chain.Action, error) {
+			return []chain.Action{&actions.Transfer{
+				To:    accounts[(i+1)%len(accounts)],
+				Value: uint64(i + 1),
+			}}, nil
+		},
+		NumTxs: numTxs,
+	}
+}
+
+func TestExecutionBenchmark(t *testing.T) {
+	for _, nonces := range []bool{false, true} {
+		t.Run(fmt.Sprintf("nonces=%t", nonces), func(t *testing.T) {
+			require := require.New(t)
+
+			matrix := chaintest.ExecutionMatrix([]int{1, 4}, []int{1}, []int{1, 4})
+			report, err := newTransferBenchmark(500, nonces).Run(context.Background(), matrix)
+			require.NoError(err)
+			require.Equal(500, report.Build.Txs)
+			require.Len(report.Results, len(matrix))
+			for i, result := range report.Results {
+				require.Equal(matrix[i], result.Config)
+				require.Equal(500, result.Txs)
+				require.Equal(report.Build.Blocks, result.Blocks)
+				require.Positive(result.TxsPerSecond)
+				require.Positive(result.Allocs)
+			}
+		})
+	}
+}
+
+// maxTxsBuildPolicy selects at most [maxTxs] txs for each block
+type maxTxsBuildPolicy struct {
+	*chain.DefaultBuildPolicy
+	maxTxs int
+
+	bctx     *chain.BuildContext
+	selected int
+}
+
+func (p *maxTxsBuildPolicy) Select(_ context.Context, bctx *chain.BuildContext, txs []*chain.Transaction) ([]*chain.Transaction, []*chain.Transaction) {
+	if p.bctx != bctx {
+		p.bctx = bctx
+		p.selected = 0
+	}
+	n := min(len(txs), p.maxTxs-p.selected)
+	p.selected += n
+	return txs[:n], txs[n:]
+}
+
+func TestExecutionBenchmarkBuildPolicy(t *testing.T) {
+	require := require.New(t)
+
+	benchmark := newTransferBenchmark(200, false)
+	benchmark.BuildPolicy = &maxTxsBuildPolicy{
+		DefaultBuildPolicy: chain.NewDefaultBuildPolicy(chain.NewDefaultConfig()),
+		maxTxs:             50,
+	}
+	matrix := chaintest.ExecutionMatrix([]int{1}, []int{1}, []int{1})
+	report, err := benchmark.Run(context.Background(), matrix)
+	require.NoError(err)
+	require.Equal(200, report.Build.Txs)
+	require.Equal(4, report.Build.Blocks)
+}
+
+func BenchmarkExecution(b *testing.B) {
+	newTransferBenchmark(10_000, false).Benchmark(
+		b,
+		chaintest.ExecutionMatrix([]int{1, 4, 16}, []int{4, 16}, []int{1, 4, 16}),
+	)
+}
@@ -63,3 +63,25 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	reply.Amount = balance
 	return err
 }
+
+type NonceArgs struct {
+	Address codec.Address `json:"address"`
+}
+
+type NonceReply struct {
+	Nonce uint64 `json:"nonce"`
+}
+
+// Nonce returns the last nonce used by a sponsor on chains that use nonce
+// replay protection
+func (j *JSONRPCServer) Nonce(req *http.Request, args *NonceArgs, reply *NonceReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.Nonce")
+	defer span.End()
+
+	nonce, err := storage.GetNonceFromState(ctx, j.vm.ReadState, args.Address)
+	if err != nil {
+		return err
+	}
+	reply.Nonce = nonce
+	return nil
+}
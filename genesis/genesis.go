@@ -14,6 +14,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/state"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
@@ -85,6 +86,14 @@ func (DefaultGenesisFactory) Load(genesisBytes []byte, _ []byte, networkID uint3
 	}
 	genesis.Rules.NetworkID = networkID
 	genesis.Rules.ChainID = chainID
+	if genesis.Rules.TimestampModulus == 0 {
+		// Genesis files written before the modulus was configurable use the
+		// previous fixed modulus
+		genesis.Rules.TimestampModulus = consts.MillisecondsPerSecond
+	}
+	if err := genesis.Rules.Verify(); err != nil {
+		return nil, nil, err
+	}
 
 	return genesis, &ImmutableRuleFactory{genesis.Rules}, nil
 }
@@ -4,6 +4,10 @@
 package genesis
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
 	"github.com/ava-labs/avalanchego/ids"
 
 	"github.com/ava-labs/hypersdk/chain"
@@ -12,6 +16,8 @@ import (
 	hconsts "github.com/ava-labs/hypersdk/consts"
 )
 
+var ErrInvalidRules = errors.New("invalid rules")
+
 var (
 	_ chain.Rules       = (*Rules)(nil)
 	_ chain.RuleFactory = (*ImmutableRuleFactory)(nil)
@@ -32,10 +38,16 @@ type Rules struct {
 	MaxBlockUnits              fees.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
 
 	// Tx Parameters
-	ValidityWindow      int64 `json:"validityWindow"` // ms
+	ValidityWindow      int64 `json:"validityWindow"`   // ms
+	TimestampModulus    int64 `json:"timestampModulus"` // ms
 	MaxActionsPerTx     uint8 `json:"maxActionsPerTx"`
 	MaxOutputsPerAction uint8 `json:"maxOutputsPerAction"`
 
+	// NonceReplayProtection requires every transaction to use the next nonce of
+	// its sponsor. [SponsorStateKeysMaxChunks] must then include the nonce keys
+	// of the sponsor.
+	NonceReplayProtection bool `json:"nonceReplayProtection"`
+
 	// Tx Fee Parameters
 	BaseComputeUnits          uint64   `json:"baseUnits"`
 	StorageKeyReadUnits       uint64   `json:"storageKeyReadUnits"`
@@ -45,6 +57,9 @@ type Rules struct {
 	StorageKeyWriteUnits      uint64   `json:"storageKeyWriteUnits"`
 	StorageValueWriteUnits    uint64   `json:"storageValueWriteUnits"` // per chunk
 	SponsorStateKeysMaxChunks []uint16 `json:"sponsorStateKeysMaxChunks"`
+
+	// Custom holds VM-specific parameters returned by FetchCustom as raw JSON
+	Custom map[string]json.RawMessage `json:"custom,omitempty"`
 }
 
 func NewDefaultRules() *Rules {
@@ -61,6 +76,7 @@ func NewDefaultRules() *Rules {
 
 		// Tx Parameters
 		ValidityWindow:      60 * hconsts.MillisecondsPerSecond, // ms
+		TimestampModulus:    hconsts.MillisecondsPerSecond,      // ms
 		MaxActionsPerTx:     16,
 		MaxOutputsPerAction: 1,
 
@@ -80,6 +96,18 @@ func NewDefaultRules() *Rules {
 	}
 }
 
+// Verify returns an error if the rules can't be used by a chain
+func (r *Rules) Verify() error {
+	switch {
+	case r.TimestampModulus <= 0:
+		return fmt.Errorf("%w: timestampModulus must be positive", ErrInvalidRules)
+	case r.ValidityWindow < r.TimestampModulus:
+		return fmt.Errorf("%w: validityWindow must be at least timestampModulus", ErrInvalidRules)
+	default:
+		return nil
+	}
+}
+
 func (r *Rules) GetNetworkID() uint32 { return r.NetworkID }
 
 func (r *Rules) GetChainID() ids.ID { return r.ChainID }
@@ -96,6 +124,14 @@ func (r *Rules) GetValidityWindow() int64 {
 	return r.ValidityWindow
 }
 
+func (r *Rules) GetTimestampModulus() int64 {
+	return r.TimestampModulus
+}
+
+func (r *Rules) GetNonceReplayProtection() bool {
+	return r.NonceReplayProtection
+}
+
 func (r *Rules) GetMaxActionsPerTx() uint8 {
 	return r.MaxActionsPerTx
 }
@@ -148,8 +184,9 @@ func (r *Rules) GetWindowTargetUnits() fees.Dimensions {
 	return r.WindowTargetUnits
 }
 
-func (*Rules) FetchCustom(string) (any, bool) {
-	return nil, false
+func (r *Rules) FetchCustom(key string) (any, bool) {
+	value, ok := r.Custom[key]
+	return value, ok
 }
 
 type ImmutableRuleFactory struct {
@@ -0,0 +1,352 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+var _ Builder = (*Adaptive)(nil)
+
+const (
+	// verifyLatencyWeight is the weight of each observed verify latency in
+	// the moving average used by [Adaptive]
+	verifyLatencyWeight = 0.2
+
+	reasonLabel = "reason"
+	actionLabel = "action"
+)
+
+// Reasons reported by [Adaptive] for each build decision
+const (
+	// ReasonEmpty means there are no pending txs to build with
+	ReasonEmpty = "empty"
+	// ReasonFull means the pending txs fill enough of a block to build as
+	// soon as the block gap allows
+	ReasonFull = "full"
+	// ReasonFees means the pending txs pay enough fees to build as soon as
+	// the block gap allows
+	ReasonFees = "fees"
+	// ReasonLatency means the builder waits past the block gap, in
+	// proportion to recent verify latency, for more txs to arrive
+	ReasonLatency = "latency"
+	// ReasonGap means the builder builds as soon as the block gap allows
+	// because there is no reason to wait
+	ReasonGap = "gap"
+)
+
+// Actions taken by [Adaptive] for each build decision
+const (
+	ActionBuild = "build" // notify the engine now
+	ActionWait  = "wait"  // notify the engine later
+	ActionSkip  = "skip"  // do not notify the engine
+)
+
+type AdaptiveConfig struct {
+	Enabled bool `json:"enabled"`
+	// FullnessThreshold is the fraction of [BuildState.MaxBlockUnits] (in
+	// any dimension) the pending txs must consume to build without waiting.
+	// Set to 0 to disable.
+	FullnessThreshold float64 `json:"fullnessThreshold"`
+	// FeeThreshold is the sum of the max fees of the pending txs required
+	// to build without waiting. Set to 0 to disable.
+	FeeThreshold uint64 `json:"feeThreshold"`
+	// VerifyLatencyFactor is multiplied by the average verify latency to
+	// determine how long to wait past the block gap.
+	VerifyLatencyFactor float64 `json:"verifyLatencyFactor"`
+	// MaxDelay is the longest the builder waits past the block gap
+	MaxDelay time.Duration `json:"maxDelay"`
+}
+
+func DefaultAdaptiveConfig() *AdaptiveConfig {
+	return &AdaptiveConfig{
+		Enabled:             false,
+		FullnessThreshold:   0.5,
+		FeeThreshold:        0,
+		VerifyLatencyFactor: 1,
+		MaxDelay:            250 * time.Millisecond,
+	}
+}
+
+// BuildState describes the preferred block and the txs pending in the
+// mempool
+type BuildState struct {
+	PreferredTimestamp int64
+	MinBlockGap        int64 // ms
+	MaxBlockUnits      fees.Dimensions
+
+	PendingTxs   int
+	PendingUnits fees.Dimensions
+	PendingFees  uint64 // sum of max fees
+}
+
+// GetBuildState function accepts the current timestamp and returns the
+// [BuildState] to decide when to build with.
+// If it fails to get the preferred block, it should return a non-nil error.
+type GetBuildState func(ctx context.Context, now int64) (*BuildState, error)
+
+// Adaptive tells the engine when to build blocks based on the depth (in
+// units) and fees of the pending txs and on recent verify latency.
+//
+// Like [Time], Adaptive never notifies the engine before the block gap of
+// the preferred block has elapsed. Deep or valuable mempools are built as
+// soon as the gap allows, while shallow mempools wait for more txs for up
+// to [AdaptiveConfig.MaxDelay].
+type Adaptive struct {
+	engineCh      chan<- common.Message
+	logger        logging.Logger
+	config        *AdaptiveConfig
+	getBuildState GetBuildState
+	metrics       *adaptiveMetrics
+
+	timer *timer.Timer
+
+	l               sync.Mutex
+	lastQueue       int64
+	scheduled       int64 // 0 if not waiting
+	scheduledReason string
+	verifyLatency   float64 // ns
+}
+
+func NewAdaptive(
+	engineCh chan<- common.Message,
+	logger logging.Logger,
+	registerer prometheus.Registerer,
+	config *AdaptiveConfig,
+	getBuildState GetBuildState,
+) (*Adaptive, error) {
+	metrics, err := newAdaptiveMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+	b := &Adaptive{
+		engineCh:      engineCh,
+		logger:        logger,
+		config:        config,
+		getBuildState: getBuildState,
+		metrics:       metrics,
+	}
+	b.timer = timer.NewTimer(b.handleTimerNotify)
+	return b, nil
+}
+
+func (b *Adaptive) Run() {
+	b.Queue(context.TODO()) // start building loop (may not be an initial trigger)
+	b.timer.Dispatch()      // this blocks
+}
+
+func (b *Adaptive) handleTimerNotify() {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	// The notification may have been sent by [Queue] after the timer fired
+	if b.scheduled == 0 {
+		return
+	}
+	b.scheduled = 0
+	b.scheduledReason = ""
+	b.notify()
+	b.logger.Debug("trigger to notify")
+}
+
+// ObserveVerify records the time spent verifying a block
+func (b *Adaptive) ObserveVerify(t time.Duration) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if b.verifyLatency == 0 {
+		b.verifyLatency = float64(t)
+	} else {
+		b.verifyLatency = verifyLatencyWeight*float64(t) + (1-verifyLatencyWeight)*b.verifyLatency
+	}
+	b.metrics.verifyLatency.Set(b.verifyLatency / float64(time.Millisecond))
+}
+
+// decide returns the time (in ms) to notify the engine and the reason for
+// it. If the engine should not be notified, it returns -1.
+//
+// Assumes [b.l] is held.
+func (b *Adaptive) decide(state *BuildState) (int64, string) {
+	if state.PendingTxs == 0 {
+		return -1, ReasonEmpty
+	}
+	earliest := max(b.lastQueue+minBuildGap, state.PreferredTimestamp+state.MinBlockGap)
+	if b.config.FullnessThreshold > 0 && fullness(state.PendingUnits, state.MaxBlockUnits) >= b.config.FullnessThreshold {
+		return earliest, ReasonFull
+	}
+	if b.config.FeeThreshold > 0 && state.PendingFees >= b.config.FeeThreshold {
+		return earliest, ReasonFees
+	}
+	delay := min(b.config.MaxDelay, time.Duration(b.config.VerifyLatencyFactor*b.verifyLatency))
+	if delay <= 0 {
+		return earliest, ReasonGap
+	}
+	return earliest + delay.Milliseconds(), ReasonLatency
+}
+
+func (b *Adaptive) Queue(ctx context.Context) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	// The engine will be notified as soon as the block gap allows, so there
+	// is nothing to reconsider.
+	if b.scheduled > 0 && b.scheduledReason != ReasonLatency {
+		b.logger.Debug("already waiting to notify to build")
+		return
+	}
+
+	now := time.Now().UnixMilli()
+	state, err := b.getBuildState(ctx, now)
+	if err != nil {
+		// unable to retrieve block.
+		b.logger.Warn("unable to get build state", zap.Error(err))
+		return
+	}
+	b.metrics.pendingTxs.Set(float64(state.PendingTxs))
+	b.metrics.pendingFullness.Set(fullness(state.PendingUnits, state.MaxBlockUnits))
+	b.metrics.pendingFees.Set(float64(state.PendingFees))
+
+	next, reason := b.decide(state)
+	switch {
+	case next < 0:
+		b.record(reason, ActionSkip, 0)
+		b.logger.Debug("skipping notify to build", zap.String("reason", reason))
+	case next <= now:
+		if b.scheduled > 0 {
+			b.timer.Cancel()
+			b.scheduled = 0
+			b.scheduledReason = ""
+		}
+		b.record(reason, ActionBuild, 0)
+		b.notify()
+		b.logger.Debug("notifying to build without waiting",
+			zap.String("reason", reason),
+			zap.Int("txs", state.PendingTxs),
+		)
+	case b.scheduled > 0 && b.scheduled <= next:
+		// Keep the earlier notification
+		b.record(reason, ActionWait, b.scheduled-now)
+	default:
+		sleepDur := time.Duration(next-now) * time.Millisecond
+		b.scheduled = next
+		b.scheduledReason = reason
+		b.timer.SetTimeoutIn(sleepDur)
+		b.record(reason, ActionWait, next-now)
+		b.logger.Debug("waiting to notify to build",
+			zap.String("reason", reason),
+			zap.Duration("t", sleepDur),
+		)
+	}
+}
+
+// record reports a build decision and the delay (in ms) until the engine is
+// notified
+//
+// Assumes [b.l] is held.
+func (b *Adaptive) record(reason string, action string, delay int64) {
+	b.metrics.decisions.WithLabelValues(reason, action).Inc()
+	b.metrics.buildDelay.Set(float64(delay))
+}
+
+// notify notifies the engine to build a block
+//
+// Assumes [b.l] is held.
+func (b *Adaptive) notify() {
+	select {
+	case b.engineCh <- common.PendingTxs:
+		b.lastQueue = time.Now().UnixMilli()
+	default:
+		b.logger.Debug("dropping message to consensus engine")
+	}
+}
+
+func (b *Adaptive) Force(context.Context) error {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.notify()
+	return nil
+}
+
+func (b *Adaptive) Done() {
+	b.timer.Stop()
+}
+
+// fullness returns the largest fraction of [maxUnits] consumed by [units] in
+// any dimension
+func fullness(units fees.Dimensions, maxUnits fees.Dimensions) float64 {
+	var f float64
+	for i := range maxUnits {
+		if maxUnits[i] == 0 {
+			continue
+		}
+		f = max(f, float64(units[i])/float64(maxUnits[i]))
+	}
+	return f
+}
+
+type adaptiveMetrics struct {
+	decisions       *prometheus.CounterVec
+	pendingTxs      prometheus.Gauge
+	pendingFullness prometheus.Gauge
+	pendingFees     prometheus.Gauge
+	verifyLatency   prometheus.Gauge
+	buildDelay      prometheus.Gauge
+}
+
+func newAdaptiveMetrics(r prometheus.Registerer) (*adaptiveMetrics, error) {
+	m := &adaptiveMetrics{
+		decisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "builder",
+			Name:      "decisions",
+			Help:      "number of build decisions by reason and action",
+		}, []string{reasonLabel, actionLabel}),
+		pendingTxs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "builder",
+			Name:      "pending_txs",
+			Help:      "number of txs pending at the last build decision",
+		}),
+		pendingFullness: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "builder",
+			Name:      "pending_fullness",
+			Help:      "largest fraction of max block units consumed by pending txs at the last build decision",
+		}),
+		pendingFees: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "builder",
+			Name:      "pending_fees",
+			Help:      "sum of the max fees of pending txs at the last build decision",
+		}),
+		verifyLatency: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "builder",
+			Name:      "verify_latency_ms",
+			Help:      "moving average of block verify latency",
+		}),
+		buildDelay: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "builder",
+			Name:      "build_delay_ms",
+			Help:      "delay until the engine is notified at the last build decision",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		r.Register(m.decisions),
+		r.Register(m.pendingTxs),
+		r.Register(m.pendingFullness),
+		r.Register(m.pendingFees),
+		r.Register(m.verifyLatency),
+		r.Register(m.buildDelay),
+	)
+	return m, errs.Err
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+func TestAdaptiveDecide(t *testing.T) {
+	const (
+		preferred   = int64(1_000)
+		minBlockGap = int64(100)
+		earliest    = preferred + minBlockGap
+	)
+	maxBlockUnits := fees.Dimensions{100, 100, 100, 100, 100}
+
+	tests := []struct {
+		name           string
+		config         *AdaptiveConfig
+		verifyLatency  time.Duration
+		pendingTxs     int
+		pendingUnits   fees.Dimensions
+		pendingFees    uint64
+		expectedNext   int64
+		expectedReason string
+	}{
+		{
+			name:           "empty",
+			config:         DefaultAdaptiveConfig(),
+			verifyLatency:  50 * time.Millisecond,
+			expectedNext:   -1,
+			expectedReason: ReasonEmpty,
+		},
+		{
+			name:           "full in any dimension",
+			config:         DefaultAdaptiveConfig(),
+			verifyLatency:  50 * time.Millisecond,
+			pendingTxs:     10,
+			pendingUnits:   fees.Dimensions{10, 60, 10, 10, 10},
+			expectedNext:   earliest,
+			expectedReason: ReasonFull,
+		},
+		{
+			name: "fees",
+			config: &AdaptiveConfig{
+				FullnessThreshold:   0.5,
+				FeeThreshold:        1_000,
+				VerifyLatencyFactor: 1,
+				MaxDelay:            250 * time.Millisecond,
+			},
+			verifyLatency:  50 * time.Millisecond,
+			pendingTxs:     1,
+			pendingUnits:   fees.Dimensions{1, 1, 1, 1, 1},
+			pendingFees:    1_000,
+			expectedNext:   earliest,
+			expectedReason: ReasonFees,
+		},
+		{
+			name:           "wait for verify latency",
+			config:         DefaultAdaptiveConfig(),
+			verifyLatency:  50 * time.Millisecond,
+			pendingTxs:     1,
+			pendingUnits:   fees.Dimensions{1, 1, 1, 1, 1},
+			expectedNext:   earliest + 50,
+			expectedReason: ReasonLatency,
+		},
+		{
+			name:           "wait at most max delay",
+			config:         DefaultAdaptiveConfig(),
+			verifyLatency:  time.Second,
+			pendingTxs:     1,
+			pendingUnits:   fees.Dimensions{1, 1, 1, 1, 1},
+			expectedNext:   earliest + 250,
+			expectedReason: ReasonLatency,
+		},
+		{
+			name:           "no verify latency",
+			config:         DefaultAdaptiveConfig(),
+			pendingTxs:     1,
+			pendingUnits:   fees.Dimensions{1, 1, 1, 1, 1},
+			expectedNext:   earliest,
+			expectedReason: ReasonGap,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			b, err := NewAdaptive(
+				make(chan common.Message, 1),
+				logging.NoLog{},
+				prometheus.NewRegistry(),
+				tt.config,
+				nil,
+			)
+			require.NoError(err)
+			if tt.verifyLatency > 0 {
+				b.ObserveVerify(tt.verifyLatency)
+			}
+			next, reason := b.decide(&BuildState{
+				PreferredTimestamp: preferred,
+				MinBlockGap:        minBlockGap,
+				MaxBlockUnits:      maxBlockUnits,
+				PendingTxs:         tt.pendingTxs,
+				PendingUnits:       tt.pendingUnits,
+				PendingFees:        tt.pendingFees,
+			})
+			require.Equal(tt.expectedNext, next)
+			require.Equal(tt.expectedReason, reason)
+		})
+	}
+}
+
+func TestAdaptiveQueue(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	engineCh := make(chan common.Message, 1)
+	state := &BuildState{
+		PreferredTimestamp: time.Now().UnixMilli() - 100, // the block gap has elapsed
+		MinBlockGap:        100,
+		MaxBlockUnits:      fees.Dimensions{100, 100, 100, 100, 100},
+	}
+	b, err := NewAdaptive(
+		engineCh,
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		DefaultAdaptiveConfig(),
+		func(context.Context, int64) (*BuildState, error) {
+			return state, nil
+		},
+	)
+	require.NoError(err)
+	go b.timer.Dispatch()
+	defer b.Done()
+
+	// Do not notify the engine without pending txs
+	b.Queue(ctx)
+	require.Empty(engineCh)
+	require.Equal(1.0, testutil.ToFloat64(b.metrics.decisions.WithLabelValues(ReasonEmpty, ActionSkip)))
+
+	// Wait for more txs when blocks are slow to verify
+	b.ObserveVerify(100 * time.Millisecond)
+	state.PendingTxs = 1
+	state.PendingUnits = fees.Dimensions{1, 1, 1, 1, 1}
+	b.Queue(ctx)
+	require.Empty(engineCh)
+	require.Equal(1.0, testutil.ToFloat64(b.metrics.decisions.WithLabelValues(ReasonLatency, ActionWait)))
+
+	// Notify the engine without waiting once the pending txs fill a block
+	state.PendingTxs = 100
+	state.PendingUnits = fees.Dimensions{100, 1, 1, 1, 1}
+	b.Queue(ctx)
+	require.Len(engineCh, 1)
+	require.Equal(1.0, testutil.ToFloat64(b.metrics.decisions.WithLabelValues(ReasonFull, ActionBuild)))
+	<-engineCh
+
+	// The cancelled notification is never sent
+	time.Sleep(200 * time.Millisecond)
+	require.Empty(engineCh)
+}
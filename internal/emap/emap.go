@@ -45,19 +45,27 @@ func NewEMap[T Item]() *EMap[T] {
 
 // Add adds a list of txs to the EMap.
 func (e *EMap[T]) Add(items []T) {
+	e.AddBucketed(items, 1)
+}
+
+// AddBucketed adds a list of txs to the EMap, grouping them into buckets of
+// [bucketSize]. Txs are added to the bucket of their timestamp rounded up to
+// a multiple of [bucketSize], so they are never evicted before their
+// timestamp.
+func (e *EMap[T]) AddBucketed(items []T, bucketSize int64) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	for _, item := range items {
-		e.add(item.ID(), item.Expiry())
+		e.add(item.ID(), item.Expiry(), bucketSize)
 	}
 }
 
 // Add adds an id with a timestampt [t] to the EMap. If the timestamp
 // is genesis(0) or the id has been seen already, add returns. The id is
-// added to a bucket with timestamp [t]. If no bucket exists, add creates a
+// added to the bucket of [t]. If no bucket exists, add creates a
 // new bucket and pushes it to the binaryHeap.
-func (e *EMap[T]) add(id ids.ID, t int64) {
+func (e *EMap[T]) add(id ids.ID, t int64, bucketSize int64) {
 	// Assume genesis txs can't be placed in seen tracker
 	if t == 0 {
 		return
@@ -69,6 +77,10 @@ func (e *EMap[T]) add(id ids.ID, t int64) {
 	}
 	e.seen.Add(id)
 
+	if r := t % bucketSize; r != 0 {
+		t += bucketSize - r
+	}
+
 	// Check if bucket with time already exists
 	if b, ok := e.times[t]; ok {
 		b.items = append(b.items, id)
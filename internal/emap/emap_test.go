@@ -186,7 +186,7 @@ func TestSetMin(t *testing.T) {
 	endT := int64(6)
 	for n := startT; n < endT; n++ {
 		id := ids.GenerateTestID()
-		e.add(id, n)
+		e.add(id, n, 1)
 		_, okSeen := e.seen[id]
 		pushedIds = append(pushedIds, id)
 		require.True(okSeen, "Id not set in seen list")
@@ -231,7 +231,7 @@ func TestSetMinPopsAll(t *testing.T) {
 
 	for n := startT; n < endT; n++ {
 		id := ids.GenerateTestID()
-		e.add(id, n)
+		e.add(id, n, 1)
 		_, okSeen := e.seen[id]
 		pushedIds = append(pushedIds, id)
 		require.True(okSeen, "Id not set in seen list")
@@ -244,3 +244,20 @@ func TestSetMinPopsAll(t *testing.T) {
 
 	require.Equal(emptyEmap, e, "EMap not empty")
 }
+
+func TestBucketedEmapSetMin(t *testing.T) {
+	require := require.New(t)
+	e := NewEMap[*TestTx]()
+	txs := []*TestTx{
+		{id: ids.GenerateTestID(), t: 1_050},
+		{id: ids.GenerateTestID(), t: 1_100},
+		{id: ids.GenerateTestID(), t: 1_150},
+	}
+	e.AddBucketed(txs, 100)
+	require.Len(e.times, 2)
+
+	// Buckets are evicted once all of their ids expired
+	require.Empty(e.SetMin(1_100))
+	require.ElementsMatch([]ids.ID{txs[0].id, txs[1].id}, e.SetMin(1_101))
+	require.Equal([]ids.ID{txs[2].id}, e.SetMin(1_201))
+}
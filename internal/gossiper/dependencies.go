@@ -24,6 +24,14 @@ type Serializer[T any] interface {
 	Unmarshal(b []byte) ([]T, error)
 }
 
+// PullMempool is the view of the mempool used to serve and reconcile pull
+// gossip requests
+type PullMempool[T any] interface {
+	Has(ctx context.Context, itemID ids.ID) bool
+	Iterate(ctx context.Context, f func(T) bool)
+	Len(ctx context.Context) int
+}
+
 type Submitter[T any] interface {
 	Submit(context.Context, []T) []error
 }
@@ -32,10 +40,12 @@ type ValidatorSet interface {
 	NodeID() ids.NodeID
 	Proposers(ctx context.Context, diff int, depth int) (set.Set[ids.NodeID], error)
 	IsValidator(ctx context.Context, nodeID ids.NodeID) (bool, error)
+	Weights(ctx context.Context) (map[ids.NodeID]uint64, error)
 }
 
 type Tx interface {
 	ID() ids.ID
+	GossipID() ids.ID
 	Expiry() int64
 	Size() int
 }
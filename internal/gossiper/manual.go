@@ -116,6 +116,7 @@ func (g *Manual[T]) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		g.metrics.invalidMessagesReceived(nodeID, false)
 		return nil
 	}
 	g.metrics.txsReceived.Add(float64(len(txs)))
@@ -127,6 +128,8 @@ func (g *Manual[T]) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg
 			numErrs++
 		}
 	}
+	// Manual gossip does not track validators, so peers are not labeled
+	g.metrics.peerTxsReceived(nodeID, false, len(txs), 0, numErrs)
 	g.log.Info(
 		"tx gossip received",
 		zap.Int("txs", len(txs)),
@@ -4,14 +4,31 @@
 package gossiper
 
 import (
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	peerLabel = "peer"
+
+	// otherPeers labels the per-peer metrics of peers that are not
+	// validators. Any node can connect to us, so labeling every peer would
+	// allow the cardinality of the metrics to grow without bound.
+	otherPeers = "other"
+)
+
 type metrics struct {
 	txsReceived     prometheus.Counter
 	seenTxsReceived prometheus.Counter
 	txsGossiped     prometheus.Counter
+
+	// Per-peer metrics are labeled by the node ID of the sender if it is a
+	// validator and by [otherPeers] otherwise
+	peerTxs         *prometheus.CounterVec
+	peerSeenTxs     *prometheus.CounterVec
+	peerInvalidTxs  *prometheus.CounterVec
+	peerInvalidMsgs *prometheus.CounterVec
 }
 
 func newMetrics(r prometheus.Registerer) (*metrics, error) {
@@ -31,12 +48,56 @@ func newMetrics(r prometheus.Registerer) (*metrics, error) {
 			Name:      "seen_txs_received",
 			Help:      "number of txs received over gossip that were already seen",
 		}),
+		peerTxs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gossiper",
+			Name:      "peer_txs_received",
+			Help:      "number of txs received over gossip from each peer",
+		}, []string{peerLabel}),
+		peerSeenTxs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gossiper",
+			Name:      "peer_seen_txs_received",
+			Help:      "number of txs received over gossip from each peer that were already seen",
+		}, []string{peerLabel}),
+		peerInvalidTxs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gossiper",
+			Name:      "peer_invalid_txs_received",
+			Help:      "number of txs received over gossip from each peer that could not be added to the mempool",
+		}, []string{peerLabel}),
+		peerInvalidMsgs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gossiper",
+			Name:      "peer_invalid_msgs_received",
+			Help:      "number of gossip messages received from each peer that could not be parsed",
+		}, []string{peerLabel}),
 	}
 	errs := wrappers.Errs{}
 	errs.Add(
 		r.Register(m.txsReceived),
 		r.Register(m.txsGossiped),
 		r.Register(m.seenTxsReceived),
+		r.Register(m.peerTxs),
+		r.Register(m.peerSeenTxs),
+		r.Register(m.peerInvalidTxs),
+		r.Register(m.peerInvalidMsgs),
 	)
 	return m, errs.Err
 }
+
+// peerTxsReceived records [txs] received from [nodeID], of which [seen] were
+// already seen and [invalid] could not be submitted
+func (m *metrics) peerTxsReceived(nodeID ids.NodeID, isValidator bool, txs int, seen int, invalid int) {
+	peer := peerLabelValue(nodeID, isValidator)
+	m.peerTxs.WithLabelValues(peer).Add(float64(txs))
+	m.peerSeenTxs.WithLabelValues(peer).Add(float64(seen))
+	m.peerInvalidTxs.WithLabelValues(peer).Add(float64(invalid))
+}
+
+func (m *metrics) invalidMessagesReceived(nodeID ids.NodeID, isValidator bool) {
+	m.peerInvalidMsgs.WithLabelValues(peerLabelValue(nodeID, isValidator)).Inc()
+}
+
+func peerLabelValue(nodeID ids.NodeID, isValidator bool) string {
+	if !isValidator {
+		return otherPeers
+	}
+	return nodeID.String()
+}
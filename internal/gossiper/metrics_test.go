@@ -0,0 +1,37 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerMetricsCardinality(t *testing.T) {
+	r := require.New(t)
+
+	m, err := newMetrics(prometheus.NewRegistry())
+	r.NoError(err)
+
+	validator := ids.GenerateTestNodeID()
+	m.peerTxsReceived(validator, true, 3, 1, 1)
+	m.invalidMessagesReceived(validator, true)
+	for i := 0; i < 100; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		m.peerTxsReceived(nodeID, false, 2, 0, 1)
+		m.invalidMessagesReceived(nodeID, false)
+	}
+
+	// Peers that are not validators share a single label
+	for _, vec := range []*prometheus.CounterVec{m.peerTxs, m.peerSeenTxs, m.peerInvalidTxs, m.peerInvalidMsgs} {
+		r.Equal(2, testutil.CollectAndCount(vec))
+	}
+	r.Equal(3.0, testutil.ToFloat64(m.peerTxs.WithLabelValues(validator.String())))
+	r.Equal(200.0, testutil.ToFloat64(m.peerTxs.WithLabelValues(otherPeers)))
+	r.Equal(100.0, testutil.ToFloat64(m.peerInvalidMsgs.WithLabelValues(otherPeers)))
+}
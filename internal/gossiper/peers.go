@@ -0,0 +1,157 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	_ PeerSelector = (*ProposerPeerSelector)(nil)
+	_ PeerSelector = (*StakeWeightedPeerSelector)(nil)
+
+	ErrUnknownPeerSelection = errors.New("unknown peer selection")
+	errNoPeers              = errors.New("no peers to gossip to")
+)
+
+type PeerSelection string
+
+const (
+	// ProposerPeerSelection gossips to the next proposers
+	ProposerPeerSelection PeerSelection = "proposers"
+	// StakeWeightedPeerSelection gossips to a random sample of validators,
+	// weighted by stake
+	StakeWeightedPeerSelection PeerSelection = "stakeWeighted"
+	// PushPullPeerSelection only gossips to the next proposer and relies on
+	// pull gossip (see [PullConfig]) to spread txs to the rest of the network,
+	// so pull gossip must be enabled
+	PushPullPeerSelection PeerSelection = "pushPull"
+)
+
+// PeerSelector picks the peers that a batch of txs is gossiped to. The
+// returned set never contains the local node.
+type PeerSelector interface {
+	SelectPeers(ctx context.Context) (set.Set[ids.NodeID], error)
+}
+
+// NewPeerSelector returns the [PeerSelector] configured by [cfg.PeerSelection]
+func NewPeerSelector(cfg *ProposerConfig, validatorSet ValidatorSet) (PeerSelector, error) {
+	switch cfg.PeerSelection {
+	case ProposerPeerSelection, "":
+		return NewProposerPeerSelector(validatorSet, cfg.GossipProposerDiff, cfg.GossipProposerDepth), nil
+	case StakeWeightedPeerSelection:
+		return NewStakeWeightedPeerSelector(validatorSet, cfg.StakeWeightedFanout), nil
+	case PushPullPeerSelection:
+		return NewProposerPeerSelector(validatorSet, 1, 1), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPeerSelection, cfg.PeerSelection)
+	}
+}
+
+// ProposerPeerSelector selects the proposers of the next [depth] blocks,
+// starting [diff] blocks from the preferred block
+type ProposerPeerSelector struct {
+	validatorSet ValidatorSet
+	diff         int
+	depth        int
+}
+
+func NewProposerPeerSelector(validatorSet ValidatorSet, diff int, depth int) *ProposerPeerSelector {
+	return &ProposerPeerSelector{
+		validatorSet: validatorSet,
+		diff:         diff,
+		depth:        depth,
+	}
+}
+
+func (p *ProposerPeerSelector) SelectPeers(ctx context.Context) (set.Set[ids.NodeID], error) {
+	proposers, err := p.validatorSet.Proposers(ctx, p.diff, p.depth)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to fetch proposers", err)
+	}
+	if proposers.Len() == 0 {
+		return nil, errNoPeers
+	}
+	recipients := set.NewSet[ids.NodeID](len(proposers))
+	for proposer := range proposers {
+		// Don't gossip to self
+		if proposer == p.validatorSet.NodeID() {
+			continue
+		}
+		recipients.Add(proposer)
+	}
+	return recipients, nil
+}
+
+// StakeWeightedPeerSelector selects [fanout] validators at random, weighted by
+// stake. This spreads txs to validators that are not about to propose, which
+// is useful for nodes (like non-validator RPC nodes) that can't rely on their
+// peers to forward gossip.
+type StakeWeightedPeerSelector struct {
+	validatorSet ValidatorSet
+	fanout       int
+}
+
+func NewStakeWeightedPeerSelector(validatorSet ValidatorSet, fanout int) *StakeWeightedPeerSelector {
+	return &StakeWeightedPeerSelector{
+		validatorSet: validatorSet,
+		fanout:       fanout,
+	}
+}
+
+func (s *StakeWeightedPeerSelector) SelectPeers(ctx context.Context) (set.Set[ids.NodeID], error) {
+	weights, err := s.validatorSet.Weights(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to fetch validator weights", err)
+	}
+	var (
+		nodeID     = s.validatorSet.NodeID()
+		candidates = make([]ids.NodeID, 0, len(weights))
+		stake      = make([]uint64, 0, len(weights))
+	)
+	for candidate, weight := range weights {
+		// Don't gossip to self
+		if candidate == nodeID || weight == 0 {
+			continue
+		}
+		candidates = append(candidates, candidate)
+		stake = append(stake, weight)
+	}
+	if len(candidates) == 0 {
+		return nil, errNoPeers
+	}
+
+	// [sampler.WeightedWithoutReplacement] samples units of stake, so it may
+	// return the same validator more than once. Instead, we remove each
+	// selected validator before sampling the next one.
+	var (
+		fanout     = min(s.fanout, len(candidates))
+		recipients = set.NewSet[ids.NodeID](fanout)
+		weighted   = sampler.NewWeighted()
+	)
+	for recipients.Len() < fanout {
+		if err := weighted.Initialize(stake); err != nil {
+			return nil, err
+		}
+		var total uint64
+		for _, weight := range stake {
+			total += weight
+		}
+		i, ok := weighted.Sample(rand.Uint64N(total)) //nolint:gosec
+		if !ok {
+			return nil, errNoPeers
+		}
+		recipients.Add(candidates[i])
+		candidates = append(candidates[:i], candidates[i+1:]...)
+		stake = append(stake[:i], stake[i+1:]...)
+	}
+	return recipients, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/stretchr/testify/require"
+)
+
+type testValidatorSet struct {
+	nodeID    ids.NodeID
+	proposers set.Set[ids.NodeID]
+	weights   map[ids.NodeID]uint64
+}
+
+func (v *testValidatorSet) NodeID() ids.NodeID {
+	return v.nodeID
+}
+
+func (v *testValidatorSet) Proposers(context.Context, int, int) (set.Set[ids.NodeID], error) {
+	return v.proposers, nil
+}
+
+func (v *testValidatorSet) IsValidator(_ context.Context, nodeID ids.NodeID) (bool, error) {
+	_, ok := v.weights[nodeID]
+	return ok, nil
+}
+
+func (v *testValidatorSet) Weights(context.Context) (map[ids.NodeID]uint64, error) {
+	return v.weights, nil
+}
+
+func newTestValidatorSet(numValidators int) *testValidatorSet {
+	v := &testValidatorSet{
+		nodeID:    ids.GenerateTestNodeID(),
+		proposers: set.Set[ids.NodeID]{},
+		weights:   map[ids.NodeID]uint64{},
+	}
+	v.proposers.Add(v.nodeID)
+	v.weights[v.nodeID] = 100
+	for i := 0; i < numValidators; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		v.weights[nodeID] = uint64(i + 1)
+		if i < 2 {
+			v.proposers.Add(nodeID)
+		}
+	}
+	return v
+}
+
+func TestNewPeerSelector(t *testing.T) {
+	tests := []struct {
+		selection   PeerSelection
+		expected    PeerSelector
+		expectedErr error
+	}{
+		{
+			selection: "",
+			expected:  &ProposerPeerSelector{},
+		},
+		{
+			selection: ProposerPeerSelection,
+			expected:  &ProposerPeerSelector{},
+		},
+		{
+			selection: StakeWeightedPeerSelection,
+			expected:  &StakeWeightedPeerSelector{},
+		},
+		{
+			selection: PushPullPeerSelection,
+			expected:  &ProposerPeerSelector{},
+		},
+		{
+			selection:   "unknown",
+			expectedErr: ErrUnknownPeerSelection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.selection), func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := DefaultProposerConfig()
+			cfg.PeerSelection = tt.selection
+			selector, err := NewPeerSelector(cfg, newTestValidatorSet(1))
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.IsType(tt.expected, selector)
+			}
+		})
+	}
+}
+
+func TestProposerPeerSelector(t *testing.T) {
+	require := require.New(t)
+
+	validators := newTestValidatorSet(4)
+	peers, err := NewProposerPeerSelector(validators, 4, 1).SelectPeers(context.Background())
+	require.NoError(err)
+	require.Equal(2, peers.Len())
+	require.False(peers.Contains(validators.nodeID))
+	for peer := range peers {
+		require.True(validators.proposers.Contains(peer))
+	}
+}
+
+func TestStakeWeightedPeerSelector(t *testing.T) {
+	require := require.New(t)
+
+	validators := newTestValidatorSet(10)
+	validators.weights[ids.GenerateTestNodeID()] = 0
+	selector := NewStakeWeightedPeerSelector(validators, 4)
+	for i := 0; i < 100; i++ {
+		peers, err := selector.SelectPeers(context.Background())
+		require.NoError(err)
+		require.Equal(4, peers.Len())
+		require.False(peers.Contains(validators.nodeID))
+		for peer := range peers {
+			require.Positive(validators.weights[peer])
+		}
+	}
+
+	// The fanout is capped by the number of other validators
+	peers, err := NewStakeWeightedPeerSelector(validators, 100).SelectPeers(context.Background())
+	require.NoError(err)
+	require.Equal(10, peers.Len())
+
+	_, err = NewStakeWeightedPeerSelector(newTestValidatorSet(0), 4).SelectPeers(context.Background())
+	require.ErrorIs(err, errNoPeers)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"go.uber.org/zap"
+)
+
+var _ p2p.Handler = (*Private[Tx])(nil)
+
+// PrivateConfig lists the peers that private txs are forwarded to and
+// accepted from. If empty, private txs are only included by this node.
+type PrivateConfig struct {
+	Peers []ids.NodeID `json:"peers"`
+}
+
+// Private forwards private txs directly to an allowlist of peers, instead of
+// gossiping them. Private txs received from peers in the allowlist are passed
+// to [submitter], which must add them to the private lane of the mempool so
+// they are not gossiped further.
+type Private[T Tx] struct {
+	p2p.NoOpHandler
+
+	ready      Ready
+	log        logging.Logger
+	serializer Serializer[T]
+	submitter  Submitter[T]
+	client     *p2p.Client
+	peers      set.Set[ids.NodeID]
+}
+
+func NewPrivate[T Tx](
+	ready Ready,
+	log logging.Logger,
+	serializer Serializer[T],
+	submitter Submitter[T],
+	client *p2p.Client,
+	cfg *PrivateConfig,
+) *Private[T] {
+	return &Private[T]{
+		ready:      ready,
+		log:        log,
+		serializer: serializer,
+		submitter:  submitter,
+		client:     client,
+		peers:      set.Of(cfg.Peers...),
+	}
+}
+
+// Forward sends [txs] to the allowlisted peers
+func (p *Private[T]) Forward(ctx context.Context, txs []T) error {
+	if len(txs) == 0 || p.peers.Len() == 0 {
+		return nil
+	}
+	b, err := p.serializer.Marshal(txs)
+	if err != nil {
+		return err
+	}
+	return p.client.AppGossip(ctx, common.SendConfig{NodeIDs: p.peers}, b)
+}
+
+func (p *Private[T]) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) {
+	if !p.ready.IsReady() {
+		p.log.Debug("ignoring private txs because vm is not ready")
+		return
+	}
+	if !p.peers.Contains(nodeID) {
+		p.log.Debug("ignoring private txs from peer not in allowlist", zap.Stringer("nodeID", nodeID))
+		return
+	}
+	txs, err := p.serializer.Unmarshal(msg)
+	if err != nil {
+		p.log.Warn("received invalid private txs", zap.Stringer("nodeID", nodeID), zap.Error(err))
+		return
+	}
+	var invalid int
+	for _, err := range p.submitter.Submit(ctx, txs) {
+		if err != nil {
+			invalid++
+		}
+	}
+	p.log.Debug("received private txs",
+		zap.Stringer("nodeID", nodeID),
+		zap.Int("txs", len(txs)),
+		zap.Int("invalid", invalid),
+	)
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+type testReady bool
+
+func (r testReady) IsReady() bool {
+	return bool(r)
+}
+
+func TestPrivateAppGossip(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx     = context.Background()
+		peer    = ids.GenerateTestNodeID()
+		other   = ids.GenerateTestNodeID()
+		mempool = &testPullMempool{}
+		tx      = &testTx{id: ids.GenerateTestID()}
+	)
+	private := NewPrivate[*testTx](
+		testReady(true),
+		logging.NoLog{},
+		testSerializer{},
+		mempool,
+		nil,
+		&PrivateConfig{Peers: []ids.NodeID{peer}},
+	)
+	msg, err := testSerializer{}.Marshal([]*testTx{tx})
+	require.NoError(err)
+
+	// Private txs are only accepted from peers in the allowlist
+	private.AppGossip(ctx, other, msg)
+	require.Empty(mempool.txs)
+	private.AppGossip(ctx, peer, msg)
+	require.Equal([]*testTx{tx}, mempool.txs)
+
+	// Nothing is sent if there are no txs
+	require.NoError(private.Forward(ctx, nil))
+}
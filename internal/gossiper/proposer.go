@@ -5,8 +5,6 @@ package gossiper
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,7 +14,6 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/prometheus/client_golang/prometheus"
@@ -37,6 +34,7 @@ type Proposer[T Tx] struct {
 	serializer           Serializer[T]
 	submitter            Submitter[T]
 	validatorSet         ValidatorSet
+	peerSelector         PeerSelector
 	targetGossipDuration time.Duration
 
 	cfg        *ProposerConfig
@@ -59,14 +57,19 @@ type Proposer[T Tx] struct {
 }
 
 type ProposerConfig struct {
-	GossipProposerDiff  int
-	GossipProposerDepth int
-	GossipMinLife       int64 // ms
-	GossipMaxSize       int
-	GossipMinDelay      int64 // ms
-	NoGossipBuilderDiff int
-	VerifyTimeout       int64 // ms
-	SeenCacheSize       int
+	GossipProposerDiff  int   `json:"gossipProposerDiff"`
+	GossipProposerDepth int   `json:"gossipProposerDepth"`
+	GossipMinLife       int64 `json:"gossipMinLife"` // ms
+	GossipMaxSize       int   `json:"gossipMaxSize"`
+	GossipMinDelay      int64 `json:"gossipMinDelay"` // ms
+	NoGossipBuilderDiff int   `json:"noGossipBuilderDiff"`
+	VerifyTimeout       int64 `json:"verifyTimeout"` // ms
+	SeenCacheSize       int   `json:"seenCacheSize"`
+
+	// PeerSelection is used by [NewPeerSelector] to pick the strategy
+	// used to select gossip recipients
+	PeerSelection       PeerSelection `json:"peerSelection"`
+	StakeWeightedFanout int           `json:"stakeWeightedFanout"`
 }
 
 func DefaultProposerConfig() *ProposerConfig {
@@ -79,6 +82,8 @@ func DefaultProposerConfig() *ProposerConfig {
 		NoGossipBuilderDiff: 1,
 		VerifyTimeout:       proposer.MaxVerifyDelay.Milliseconds(),
 		SeenCacheSize:       2_500_000,
+		PeerSelection:       ProposerPeerSelection,
+		StakeWeightedFanout: 4,
 	}
 }
 
@@ -90,6 +95,7 @@ func NewProposer[T Tx](
 	serializer Serializer[T],
 	submitter Submitter[T],
 	validatorSet ValidatorSet,
+	peerSelector PeerSelector,
 	targetGossipDuration time.Duration,
 	cfg *ProposerConfig,
 	stop <-chan struct{},
@@ -106,6 +112,7 @@ func NewProposer[T Tx](
 		serializer:           serializer,
 		submitter:            submitter,
 		validatorSet:         validatorSet,
+		peerSelector:         peerSelector,
 		targetGossipDuration: targetGossipDuration,
 		cfg:                  cfg,
 		stop:                 stop,
@@ -196,6 +203,17 @@ func (g *Proposer[T]) Force(ctx context.Context) error {
 }
 
 func (g *Proposer[T]) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	// Mark incoming gossip as held by [nodeID], if it is a validator. Only
+	// validators are labeled in the per-peer metrics.
+	isValidator, err := g.validatorSet.IsValidator(ctx, nodeID)
+	if err != nil {
+		g.log.Warn(
+			"unable to determine if nodeID is validator",
+			zap.Stringer("peerID", nodeID),
+			zap.Error(err),
+		)
+	}
+
 	txs, err := g.serializer.Unmarshal(msg)
 	if err != nil {
 		g.log.Warn(
@@ -203,6 +221,7 @@ func (g *Proposer[T]) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, ms
 			zap.Stringer("peerID", nodeID),
 			zap.Error(err),
 		)
+		g.metrics.invalidMessagesReceived(nodeID, isValidator)
 		return nil
 	}
 	g.metrics.txsReceived.Add(float64(len(txs)))
@@ -217,16 +236,6 @@ func (g *Proposer[T]) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, ms
 	}
 	g.metrics.seenTxsReceived.Add(float64(seen))
 
-	// Mark incoming gossip as held by [nodeID], if it is a validator
-	isValidator, err := g.validatorSet.IsValidator(ctx, nodeID)
-	if err != nil {
-		g.log.Warn(
-			"unable to determine if nodeID is validator",
-			zap.Stringer("peerID", nodeID),
-			zap.Error(err),
-		)
-	}
-
 	// Submit incoming gossip to mempool
 	start := time.Now()
 	numErrs := 0
@@ -235,6 +244,7 @@ func (g *Proposer[T]) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, ms
 			numErrs++
 		}
 	}
+	g.metrics.peerTxsReceived(nodeID, isValidator, len(txs), seen, numErrs)
 	g.log.Debug(
 		"tx gossip received",
 		zap.Int("txs", len(txs)),
@@ -345,25 +355,10 @@ func (g *Proposer[T]) sendTxs(ctx context.Context, txs []T) error {
 		return err
 	}
 
-	// Select next set of proposers and send gossip to them
-	proposers, err := g.validatorSet.Proposers(
-		ctx,
-		g.cfg.GossipProposerDiff,
-		g.cfg.GossipProposerDepth,
-	)
+	// Select next set of peers and send gossip to them
+	recipients, err := g.peerSelector.SelectPeers(ctx)
 	if err != nil {
-		return fmt.Errorf("%w: unable to fetch proposers", err)
-	}
-	if proposers.Len() == 0 {
-		return errors.New("no proposers to gossip to")
-	}
-	recipients := set.NewSet[ids.NodeID](len(proposers))
-	for proposer := range proposers {
-		// Don't gossip to self
-		if proposer == g.validatorSet.NodeID() {
-			continue
-		}
-		recipients.Add(proposer)
+		return err
 	}
 	return g.client.AppGossip(ctx, common.SendConfig{NodeIDs: recipients}, b)
 }
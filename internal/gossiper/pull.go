@@ -0,0 +1,234 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var (
+	_ gossip.Set[Tx]        = (*pullSet[Tx])(nil)
+	_ gossip.Marshaller[Tx] = (*pullMarshaller[Tx])(nil)
+	_ p2p.Throttler         = (*countingThrottler)(nil)
+
+	errUnexpectedTxCount = errors.New("unexpected number of txs in pull gossip response")
+)
+
+// PullConfig configures pull gossip. When enabled, a node periodically sends a
+// bloom filter of the txs in its mempool to random peers, which respond with
+// the txs that are not in the filter. Pull gossip is disabled by default.
+type PullConfig struct {
+	Enabled            bool          `json:"enabled"`
+	Frequency          time.Duration `json:"frequency"`
+	PollSize           int           `json:"pollSize"`
+	TargetResponseSize int           `json:"targetResponseSize"`
+
+	BloomMinTargetElements              int     `json:"bloomMinTargetElements"`
+	BloomTargetFalsePositiveProbability float64 `json:"bloomTargetFalsePositiveProbability"`
+	BloomResetFalsePositiveProbability  float64 `json:"bloomResetFalsePositiveProbability"`
+
+	// Each peer may send at most [ThrottlingLimit] requests per
+	// [ThrottlingPeriod]
+	ThrottlingPeriod time.Duration `json:"throttlingPeriod"`
+	ThrottlingLimit  int           `json:"throttlingLimit"`
+}
+
+func DefaultPullConfig() *PullConfig {
+	return &PullConfig{
+		Enabled:                             false,
+		Frequency:                           time.Second,
+		PollSize:                            1,
+		TargetResponseSize:                  20 * units.KiB,
+		BloomMinTargetElements:              8 * 1024,
+		BloomTargetFalsePositiveProbability: 0.01,
+		BloomResetFalsePositiveProbability:  0.05,
+		ThrottlingPeriod:                    time.Second,
+		ThrottlingLimit:                     10,
+	}
+}
+
+// Pull reconciles the mempool with the mempools of peers. It periodically
+// requests the txs it is missing and serves the requests of peers.
+type Pull[T Tx] struct {
+	log        logging.Logger
+	cfg        *PullConfig
+	set        *pullSet[T]
+	marshaller *pullMarshaller[T]
+	metrics    gossip.Metrics
+	throttled  prometheus.Counter
+
+	done chan struct{}
+}
+
+func NewPull[T Tx](
+	log logging.Logger,
+	registerer prometheus.Registerer,
+	mempool PullMempool[T],
+	serializer Serializer[T],
+	submitter Submitter[T],
+	cfg *PullConfig,
+) (*Pull[T], error) {
+	gossipMetrics, err := gossip.NewMetrics(registerer, "pull")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull gossip metrics: %w", err)
+	}
+	bloom, err := gossip.NewBloomFilter(
+		registerer,
+		"pull_bloom",
+		cfg.BloomMinTargetElements,
+		cfg.BloomTargetFalsePositiveProbability,
+		cfg.BloomResetFalsePositiveProbability,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull gossip bloom filter: %w", err)
+	}
+	throttled := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "pull",
+		Name:      "requests_throttled",
+		Help:      "number of pull gossip requests dropped by the throttler",
+	})
+	if err := registerer.Register(throttled); err != nil {
+		return nil, err
+	}
+	return &Pull[T]{
+		log: log,
+		cfg: cfg,
+		set: &pullSet[T]{
+			log:       log,
+			mempool:   mempool,
+			submitter: submitter,
+			bloom:     bloom,
+		},
+		marshaller: &pullMarshaller[T]{serializer: serializer},
+		metrics:    gossipMetrics,
+		throttled:  throttled,
+		done:       make(chan struct{}),
+	}, nil
+}
+
+// Handler returns the [p2p.Handler] that serves pull gossip requests from
+// peers. Requests from a peer that exceed the configured rate are dropped.
+func (p *Pull[T]) Handler() p2p.Handler {
+	return p2p.NewThrottlerHandler(
+		gossip.NewHandler[T](p.log, p.marshaller, p.set, p.metrics, p.cfg.TargetResponseSize),
+		&countingThrottler{
+			Throttler: p2p.NewSlidingWindowThrottler(p.cfg.ThrottlingPeriod, p.cfg.ThrottlingLimit),
+			throttled: p.throttled,
+		},
+		p.log,
+	)
+}
+
+// Run sends pull gossip requests with [client] every [PullConfig.Frequency]
+// until [stop] is closed.
+func (p *Pull[T]) Run(client *p2p.Client, stop <-chan struct{}) {
+	defer close(p.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+	gossip.Every(
+		ctx,
+		p.log,
+		gossip.NewPullGossiper[T](p.log, p.marshaller, p.set, client, p.metrics, p.cfg.PollSize),
+		p.cfg.Frequency,
+	)
+}
+
+func (p *Pull[T]) Done() {
+	<-p.done
+}
+
+// pullSet exposes the mempool as a [gossip.Set]. Txs received in pull gossip
+// responses are submitted like txs received over push gossip.
+type pullSet[T Tx] struct {
+	log       logging.Logger
+	mempool   PullMempool[T]
+	submitter Submitter[T]
+
+	// bloomLock serializes resets of [bloom], which are not safe to perform
+	// concurrently with other operations
+	bloomLock sync.Mutex
+	bloom     *gossip.BloomFilter
+}
+
+func (s *pullSet[T]) Add(tx T) error {
+	return s.submitter.Submit(context.TODO(), []T{tx})[0]
+}
+
+func (s *pullSet[T]) Has(gossipID ids.ID) bool {
+	return s.mempool.Has(context.TODO(), gossipID)
+}
+
+func (s *pullSet[T]) Iterate(f func(T) bool) {
+	s.mempool.Iterate(context.TODO(), f)
+}
+
+// GetFilter adds the current contents of the mempool to the bloom filter and
+// returns it. Txs may be added to the mempool without going through [Add]
+// (e.g. over RPC), so the filter is refreshed on every request. Txs that
+// leave the mempool stay in the filter until it is reset.
+func (s *pullSet[T]) GetFilter() ([]byte, []byte) {
+	s.bloomLock.Lock()
+	defer s.bloomLock.Unlock()
+
+	if _, err := gossip.ResetBloomFilterIfNeeded(s.bloom, 2*s.mempool.Len(context.TODO())); err != nil {
+		s.log.Warn("failed to reset pull gossip bloom filter", zap.Error(err))
+	}
+	s.mempool.Iterate(context.TODO(), func(tx T) bool {
+		if !s.bloom.Has(tx) {
+			s.bloom.Add(tx)
+		}
+		return true
+	})
+	return s.bloom.Marshal()
+}
+
+// pullMarshaller encodes each tx as a batch of one with [Serializer]
+type pullMarshaller[T Tx] struct {
+	serializer Serializer[T]
+}
+
+func (m *pullMarshaller[T]) MarshalGossip(tx T) ([]byte, error) {
+	return m.serializer.Marshal([]T{tx})
+}
+
+func (m *pullMarshaller[T]) UnmarshalGossip(b []byte) (T, error) {
+	txs, err := m.serializer.Unmarshal(b)
+	if err != nil {
+		return *new(T), err
+	}
+	if len(txs) != 1 {
+		return *new(T), fmt.Errorf("%w: %d", errUnexpectedTxCount, len(txs))
+	}
+	return txs[0], nil
+}
+
+// countingThrottler records the requests dropped by [p2p.Throttler]
+type countingThrottler struct {
+	p2p.Throttler
+	throttled prometheus.Counter
+}
+
+func (c *countingThrottler) Handle(nodeID ids.NodeID) bool {
+	if c.Throttler.Handle(nodeID) {
+		return true
+	}
+	c.throttled.Inc()
+	return false
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossiper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p/gossip"
+	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testTx struct {
+	id ids.ID
+}
+
+func (t *testTx) ID() ids.ID       { return t.id }
+func (t *testTx) GossipID() ids.ID { return t.id }
+func (*testTx) Expiry() int64      { return 0 }
+func (*testTx) Size() int          { return ids.IDLen }
+
+type testPullMempool struct {
+	txs []*testTx
+}
+
+func (m *testPullMempool) Has(_ context.Context, id ids.ID) bool {
+	for _, tx := range m.txs {
+		if tx.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *testPullMempool) Iterate(_ context.Context, f func(*testTx) bool) {
+	for _, tx := range m.txs {
+		if !f(tx) {
+			return
+		}
+	}
+}
+
+func (m *testPullMempool) Len(context.Context) int {
+	return len(m.txs)
+}
+
+func (m *testPullMempool) Submit(_ context.Context, txs []*testTx) []error {
+	m.txs = append(m.txs, txs...)
+	return make([]error, len(txs))
+}
+
+type testSerializer struct{}
+
+func (testSerializer) Marshal(txs []*testTx) ([]byte, error) {
+	b := make([]byte, 0, len(txs)*ids.IDLen)
+	for _, tx := range txs {
+		b = append(b, tx.id[:]...)
+	}
+	return b, nil
+}
+
+func (testSerializer) Unmarshal(b []byte) ([]*testTx, error) {
+	txs := make([]*testTx, 0, len(b)/ids.IDLen)
+	for ; len(b) >= ids.IDLen; b = b[ids.IDLen:] {
+		txs = append(txs, &testTx{id: ids.ID(b[:ids.IDLen])})
+	}
+	return txs, nil
+}
+
+func newTestPull(t *testing.T, mempool *testPullMempool, cfg *PullConfig) *Pull[*testTx] {
+	pull, err := NewPull[*testTx](
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		mempool,
+		testSerializer{},
+		mempool,
+		cfg,
+	)
+	require.NoError(t, err)
+	return pull
+}
+
+func TestPullSetFilter(t *testing.T) {
+	require := require.New(t)
+
+	mempool := &testPullMempool{}
+	pull := newTestPull(t, mempool, DefaultPullConfig())
+
+	// Txs added directly to the mempool are included in the filter
+	known := &testTx{id: ids.GenerateTestID()}
+	mempool.txs = append(mempool.txs, known)
+	// Txs received over pull gossip are submitted to the mempool
+	received := &testTx{id: ids.GenerateTestID()}
+	require.NoError(pull.set.Add(received))
+	require.True(pull.set.Has(received.id))
+
+	filterBytes, saltBytes := pull.set.GetFilter()
+	filter, err := bloom.Parse(filterBytes)
+	require.NoError(err)
+	for _, tx := range []*testTx{known, received} {
+		require.True(bloom.Contains(filter, tx.id[:], saltBytes))
+	}
+	missing := ids.GenerateTestID()
+	require.False(bloom.Contains(filter, missing[:], saltBytes))
+}
+
+func TestPullMarshaller(t *testing.T) {
+	require := require.New(t)
+
+	marshaller := &pullMarshaller[*testTx]{serializer: testSerializer{}}
+	tx := &testTx{id: ids.GenerateTestID()}
+	b, err := marshaller.MarshalGossip(tx)
+	require.NoError(err)
+	unmarshaled, err := marshaller.UnmarshalGossip(b)
+	require.NoError(err)
+	require.Equal(tx, unmarshaled)
+
+	_, err = marshaller.UnmarshalGossip(append(b, b...))
+	require.ErrorIs(err, errUnexpectedTxCount)
+}
+
+func TestPullHandlerThrottles(t *testing.T) {
+	require := require.New(t)
+
+	mempool := &testPullMempool{}
+	for i := 0; i < 3; i++ {
+		mempool.txs = append(mempool.txs, &testTx{id: ids.GenerateTestID()})
+	}
+	cfg := DefaultPullConfig()
+	cfg.ThrottlingPeriod = time.Hour
+	cfg.ThrottlingLimit = 1
+	pull := newTestPull(t, mempool, cfg)
+	handler := pull.Handler()
+
+	// An empty filter requests every tx in the mempool
+	emptyFilter, err := bloom.New(1, 1)
+	require.NoError(err)
+	request, err := gossip.MarshalAppRequest(emptyFilter.Marshal(), ids.Empty[:])
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	response, appErr := handler.AppRequest(context.Background(), nodeID, time.Time{}, request)
+	require.Nil(appErr)
+	gossipBytes, err := gossip.ParseAppResponse(response)
+	require.NoError(err)
+	require.Len(gossipBytes, len(mempool.txs))
+
+	_, appErr = handler.AppRequest(context.Background(), nodeID, time.Time{}, request)
+	require.NotNil(appErr)
+	require.Equal(1.0, testutil.ToFloat64(pull.throttled))
+}
@@ -92,6 +92,16 @@ func (l *List[T]) PushBack(v T) *Element[T] {
 	return l.insertValueAfter(v, l.root.prev)
 }
 
+// InsertBefore inserts [v] before [mark], which must be an element of [l]
+func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	return l.insertValueAfter(v, mark.prev)
+}
+
+// InsertAfter inserts [v] after [mark], which must be an element of [l]
+func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	return l.insertValueAfter(v, mark)
+}
+
 func (l *List[T]) Remove(e *Element[T]) T {
 	if e.list == l {
 		l.remove(e)
@@ -5,6 +5,7 @@ package mempool
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"time"
 
@@ -25,6 +26,9 @@ type Item interface {
 
 	Sponsor() codec.Address
 	Size() int
+	// Nonce orders the items of a [Sponsor]. Items with the same nonce are
+	// ordered by arrival.
+	Nonce() uint64
 }
 
 type Mempool[T Item] struct {
@@ -43,6 +47,14 @@ type Mempool[T Item] struct {
 	// owned tracks the number of items in the mempool owned by a single
 	// [Sponsor]
 	owned map[codec.Address]int
+	// sponsorItems tracks the items of each [Sponsor] in the order of their
+	// nonce, which is also their order in [queue]
+	sponsorItems map[codec.Address][]*list.Element[T]
+
+	// private tracks the expiry of items that must not be gossiped. Items
+	// leave and re-enter [queue] as they are streamed, gossiped, or included
+	// in rejected blocks, so an item stays private until it expires.
+	private map[ids.ID]int64
 
 	// streamedItems have been removed from the mempool during streaming
 	// and should not be re-added by calls to [Add].
@@ -68,12 +80,15 @@ func New[T Item](
 		queue: &list.List[T]{},
 		eh:    eheap.New[*list.Element[T]](min(maxSize, maxPrealloc)),
 
-		owned: map[codec.Address]int{},
+		owned:        map[codec.Address]int{},
+		sponsorItems: map[codec.Address][]*list.Element[T]{},
+		private:      map[ids.ID]int64{},
 	}
 }
 
 func (m *Mempool[T]) removeFromOwned(item T) {
 	sender := item.Sponsor()
+	m.removeFromSponsorItems(sender, item.ID())
 	items, ok := m.owned[sender]
 	if !ok {
 		// May no longer be populated
@@ -86,6 +101,55 @@ func (m *Mempool[T]) removeFromOwned(item T) {
 	m.owned[sender] = items - 1
 }
 
+func (m *Mempool[T]) removeFromSponsorItems(sender codec.Address, itemID ids.ID) {
+	elems := m.sponsorItems[sender]
+	i := slices.IndexFunc(elems, func(e *list.Element[T]) bool { return e.ID() == itemID })
+	if i == -1 {
+		return
+	}
+	if len(elems) == 1 {
+		delete(m.sponsorItems, sender)
+		return
+	}
+	m.sponsorItems[sender] = slices.Delete(elems, i, i+1)
+}
+
+// insert adds [item] to the back or the front of [m.queue], while keeping the
+// items of its sponsor ordered by nonce
+func (m *Mempool[T]) insert(item T, front bool) *list.Element[T] {
+	sender := item.Sponsor()
+	elems := m.sponsorItems[sender]
+	var (
+		i    int
+		elem *list.Element[T]
+	)
+	if !front {
+		// Before the first item of the sponsor with a higher nonce
+		i = slices.IndexFunc(elems, func(e *list.Element[T]) bool { return e.Value().Nonce() > item.Nonce() })
+		switch i {
+		case -1:
+			i = len(elems)
+			elem = m.queue.PushBack(item)
+		default:
+			elem = m.queue.InsertBefore(item, elems[i])
+		}
+	} else {
+		// After the last item of the sponsor with a lower nonce
+		i = slices.IndexFunc(elems, func(e *list.Element[T]) bool { return e.Value().Nonce() >= item.Nonce() })
+		if i == -1 {
+			i = len(elems)
+		}
+		switch i {
+		case 0:
+			elem = m.queue.PushFront(item)
+		default:
+			elem = m.queue.InsertAfter(item, elems[i-1])
+		}
+	}
+	m.sponsorItems[sender] = slices.Insert(elems, i, elem)
+	return elem
+}
+
 // Has returns if the eh of [m] contains [itemID]
 func (m *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 	_, span := m.tracer.Start(ctx, "Mempool.Has")
@@ -97,6 +161,39 @@ func (m *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 	return m.eh.Has(itemID)
 }
 
+// IsPrivate returns if [itemID] was added with [AddPrivate] and has not yet
+// expired
+func (m *Mempool[T]) IsPrivate(ctx context.Context, itemID ids.ID) bool {
+	_, span := m.tracer.Start(ctx, "Mempool.IsPrivate")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.private[itemID]
+	return ok
+}
+
+// Iterate calls [f] on each public item in the mempool, in the order they
+// would be streamed, until [f] returns false. Items are not removed from the
+// mempool.
+func (m *Mempool[T]) Iterate(ctx context.Context, f func(T) bool) {
+	_, span := m.tracer.Start(ctx, "Mempool.Iterate")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for elem := m.queue.First(); elem != nil; elem = elem.Next() {
+		if _, ok := m.private[elem.ID()]; ok {
+			continue
+		}
+		if !f(elem.Value()) {
+			return
+		}
+	}
+}
+
 // Add pushes all new items from [items] to m. Does not add a item if
 // the item sponsor is not exempt and their items in the mempool exceed m.maxSponsorSize.
 // If the size of m exceeds m.maxSize, Add pops the lowest value item
@@ -111,6 +208,25 @@ func (m *Mempool[T]) Add(ctx context.Context, items []T) {
 	m.add(items, false)
 }
 
+// AddPrivate pushes all new items from [items] to m and marks them as
+// private. Private items are built like any other item but are skipped by
+// [Top] and [Iterate], so they are never gossiped.
+func (m *Mempool[T]) AddPrivate(ctx context.Context, items []T) {
+	_, span := m.tracer.Start(ctx, "Mempool.AddPrivate")
+	defer span.End()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.add(items, false)
+	for _, item := range items {
+		itemID := item.ID()
+		if m.eh.Has(itemID) {
+			m.private[itemID] = item.Expiry()
+		}
+	}
+}
+
 func (m *Mempool[T]) add(items []T, front bool) {
 	for _, item := range items {
 		sender := item.Sponsor()
@@ -136,12 +252,7 @@ func (m *Mempool[T]) add(items []T, front bool) {
 		}
 
 		// Add to mempool
-		var elem *list.Element[T]
-		if !front {
-			elem = m.queue.PushBack(item)
-		} else {
-			elem = m.queue.PushFront(item)
-		}
+		elem := m.insert(item, front)
 		m.eh.Add(elem)
 		m.owned[sender]++
 		m.pendingSize += item.Size()
@@ -243,10 +354,15 @@ func (m *Mempool[T]) SetMinTimestamp(ctx context.Context, t int64) []T {
 		m.pendingSize -= v.Size()
 		removed[i] = v
 	}
+	for itemID, expiry := range m.private {
+		if expiry < t {
+			delete(m.private, itemID)
+		}
+	}
 	return removed
 }
 
-// Top iterates over the highest-valued items in the mempool.
+// Top iterates over the highest-valued public items in the mempool.
 func (m *Mempool[T]) Top(
 	ctx context.Context,
 	targetDuration time.Duration,
@@ -265,6 +381,10 @@ func (m *Mempool[T]) Top(
 	)
 	for m.eh.Len() > 0 {
 		next, _ := m.popNext()
+		if _, ok := m.private[next.ID()]; ok {
+			restorableItems = append(restorableItems, next)
+			continue
+		}
 		cont, restore, fErr := f(ctx, next)
 		if restore {
 			// Waiting to restore unused transactions ensures that an account will be
@@ -6,6 +6,7 @@ package mempool
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,7 @@ type TestItem struct {
 	id        ids.ID
 	sponsor   codec.Address
 	timestamp int64
+	nonce     uint64
 }
 
 func (mti *TestItem) ID() ids.ID {
@@ -38,6 +40,10 @@ func (*TestItem) Size() int {
 	return 2 // distinguish from len
 }
 
+func (mti *TestItem) Nonce() uint64 {
+	return mti.nonce
+}
+
 func GenerateTestItem(sponsor codec.Address, t int64) *TestItem {
 	id := ids.GenerateTestID()
 	return &TestItem{
@@ -66,6 +72,71 @@ func TestMempool(t *testing.T) {
 	require.Equal(6, txm.Size(ctx))
 }
 
+func TestMempoolIterate(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*TestItem](tracer, 3, 16)
+
+	items := []*TestItem{
+		GenerateTestItem(testSponsor, 100),
+		GenerateTestItem(testSponsor, 200),
+		GenerateTestItem(testSponsor, 300),
+	}
+	txm.Add(ctx, items)
+
+	var iterated []*TestItem
+	txm.Iterate(ctx, func(item *TestItem) bool {
+		iterated = append(iterated, item)
+		return len(iterated) < 2
+	})
+	require.Equal(items[:2], iterated)
+	require.Equal(3, txm.Len(ctx))
+}
+
+func TestMempoolPrivateItems(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*TestItem](tracer, 3, 16)
+
+	public := GenerateTestItem(testSponsor, 100)
+	private := GenerateTestItem(testSponsor, 200)
+	txm.Add(ctx, []*TestItem{public})
+	txm.AddPrivate(ctx, []*TestItem{private})
+	require.Equal(2, txm.Len(ctx))
+	require.False(txm.IsPrivate(ctx, public.ID()))
+	require.True(txm.IsPrivate(ctx, private.ID()))
+
+	// Private items are not gossiped
+	var iterated []*TestItem
+	txm.Iterate(ctx, func(item *TestItem) bool {
+		iterated = append(iterated, item)
+		return true
+	})
+	require.Equal([]*TestItem{public}, iterated)
+	var top []*TestItem
+	require.NoError(txm.Top(ctx, time.Second, func(_ context.Context, item *TestItem) (bool, bool, error) {
+		top = append(top, item)
+		return true, true, nil
+	}))
+	require.Equal([]*TestItem{public}, top)
+	require.Equal(2, txm.Len(ctx))
+
+	// Private items are still built and stay private when restored
+	txm.StartStreaming(ctx)
+	require.ElementsMatch([]*TestItem{public, private}, txm.Stream(ctx, 2))
+	txm.FinishStreaming(ctx, []*TestItem{private})
+	require.True(txm.IsPrivate(ctx, private.ID()))
+
+	// Private items expire like any other item
+	txm.SetMinTimestamp(ctx, 300)
+	require.Zero(txm.Len(ctx))
+	require.False(txm.IsPrivate(ctx, private.ID()))
+}
+
 func TestMempoolAddDuplicates(t *testing.T) {
 	require := require.New(t)
 	ctx := context.TODO()
@@ -175,3 +246,42 @@ func TestMempoolSetMinTimestamp(t *testing.T) {
 	// Mempool has same length
 	require.Equal(5, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
+
+func TestMempoolOrdersSponsorItemsByNonce(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := New[*TestItem](tracer, 20, 20)
+	otherSponsor := codec.CreateAddress(2, ids.GenerateTestID())
+	newItem := func(sponsor codec.Address, nonce uint64) *TestItem {
+		item := GenerateTestItem(sponsor, 100)
+		item.nonce = nonce
+		return item
+	}
+	a3 := newItem(testSponsor, 3)
+	b1 := newItem(otherSponsor, 1)
+	a1 := newItem(testSponsor, 1)
+	a2 := newItem(testSponsor, 2)
+	txm.Add(ctx, []*TestItem{a3, b1, a1, a2})
+
+	txm.StartStreaming(ctx)
+	streamed := txm.Stream(ctx, 4)
+	require.Equal([]*TestItem{a1, a2, a3, b1}, streamed)
+
+	// Restored items are added to the front, after the items of their sponsor
+	// with a lower nonce
+	a4 := newItem(testSponsor, 4)
+	txm.Add(ctx, []*TestItem{a4})
+	txm.FinishStreaming(ctx, []*TestItem{a2, b1})
+	var popped []*TestItem
+	for {
+		item, ok := txm.PopNext(ctx)
+		if !ok {
+			break
+		}
+		popped = append(popped, item)
+	}
+	require.Equal([]*TestItem{b1, a2, a4}, popped)
+	require.Empty(txm.sponsorItems)
+}
@@ -19,6 +19,10 @@ import (
 
 var ErrDuplicateContainer = errors.New("duplicate container")
 
+// GetTimestampModulusFunc returns the granularity of container expiries for
+// the given timestamp.
+type GetTimestampModulusFunc func(int64) int64
+
 type TimeValidityWindow[Container emap.Item] struct {
 	log    logging.Logger
 	tracer trace.Tracer
@@ -26,15 +30,20 @@ type TimeValidityWindow[Container emap.Item] struct {
 	lock                    sync.Mutex
 	chainIndex              ChainIndex[Container]
 	seen                    *emap.EMap[Container]
+	getTimestampModulus     GetTimestampModulusFunc
 	lastAcceptedBlockHeight uint64
 }
 
-func NewTimeValidityWindow[Container emap.Item](log logging.Logger, tracer trace.Tracer, chainIndex ChainIndex[Container]) *TimeValidityWindow[Container] {
+// NewTimeValidityWindow returns a validity window that tracks the containers
+// of each accepted block in buckets of the timestamp modulus at the timestamp
+// of the block
+func NewTimeValidityWindow[Container emap.Item](log logging.Logger, tracer trace.Tracer, chainIndex ChainIndex[Container], getTimestampModulus GetTimestampModulusFunc) *TimeValidityWindow[Container] {
 	return &TimeValidityWindow[Container]{
-		log:        log,
-		tracer:     tracer,
-		chainIndex: chainIndex,
-		seen:       emap.NewEMap[Container](),
+		log:                 log,
+		tracer:              tracer,
+		chainIndex:          chainIndex,
+		seen:                emap.NewEMap[Container](),
+		getTimestampModulus: getTimestampModulus,
 	}
 }
 
@@ -45,7 +54,7 @@ func (v *TimeValidityWindow[Container]) Accept(blk ExecutionBlock[Container]) {
 
 	evicted := v.seen.SetMin(blk.Timestamp())
 	v.log.Debug("txs evicted from seen", zap.Int("len", len(evicted)))
-	v.seen.Add(blk.Txs())
+	v.seen.AddBucketed(blk.Txs(), v.getTimestampModulus(blk.Timestamp()))
 	v.lastAcceptedBlockHeight = blk.Height()
 }
 
@@ -43,6 +43,7 @@ func GetABI(ctx context.Context, require *require.Assertions, uris []string, exp
 		require.GreaterOrEqual(len(actualABI.Actions), 1)
 		require.NotEmpty(actualABI.Actions[0].Name)
 		require.Equal(expectedABI, actualABI)
+		require.NoError(client.CheckABI(ctx, expectedABI))
 	}
 }
 
@@ -17,6 +17,7 @@ type Config struct {
 	txsPerSecondStep int
 	numClients       int
 	numAccounts      int
+	profile          *Profile
 }
 
 func NewDefaultConfig(
@@ -33,6 +34,7 @@ func NewDefaultConfig(
 		txsPerSecondStep: 200,
 		numClients:       10,
 		numAccounts:      25,
+		profile:          DefaultProfile(),
 	}
 }
 
@@ -57,5 +59,12 @@ func NewConfig(
 		txsPerSecondStep,
 		numClients,
 		numAccounts,
+		DefaultProfile(),
 	}
 }
+
+// WithProfile sets the workload [profile] sent by the spammer
+func (c *Config) WithProfile(profile *Profile) *Config {
+	c.profile = profile
+	return c
+}
@@ -5,4 +5,9 @@ package throughput
 
 import "errors"
 
-var ErrTxFailed = errors.New("tx failed on-chain")
+var (
+	ErrTxFailed             = errors.New("tx failed on-chain")
+	ErrInvalidProfile       = errors.New("invalid profile")
+	ErrWorkloadNotSupported = errors.New("spam helper does not implement WorkloadHelper")
+	ErrTooManyHotSpots      = errors.New("more hot spots than accounts")
+)
@@ -41,3 +41,14 @@ type SpamHelper interface {
 	// GetActions returns a list of actions the spammer sends to the network.
 	GetActions() []chain.Action
 }
+
+// WorkloadHelper is implemented by [SpamHelper]s that can send the action mix
+// of a [Profile]
+type WorkloadHelper interface {
+	SpamHelper
+
+	// GetAction returns an action of type [name] that reads or modifies the
+	// state of [target]. Hot spots of a [Profile] are created by passing the
+	// same [target] to many actions.
+	GetAction(name string, target codec.Address) (chain.Action, error)
+}
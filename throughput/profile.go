@@ -0,0 +1,202 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throughput
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// Pattern determines how the target TPS of the [Spammer] changes over time
+type Pattern string
+
+const (
+	// RampPattern increases the target TPS by txsPerSecondStep while the
+	// network keeps up and decreases it when a backlog builds up
+	RampPattern Pattern = "ramp"
+	// StepPattern increases the target TPS by txsPerSecondStep every
+	// [Profile.StepInterval], regardless of the backlog
+	StepPattern Pattern = "step"
+	// BurstPattern issues txsPerSecond for [Profile.BurstDuration] at the start
+	// of every [Profile.BurstInterval] and minTxsPerSecond otherwise
+	BurstPattern Pattern = "burst"
+)
+
+// SponsorDistribution determines which accounts send transactions
+type SponsorDistribution string
+
+const (
+	// ZipfSponsors picks senders with the Zipf distribution of the [Config]
+	ZipfSponsors SponsorDistribution = "zipf"
+	// UniformSponsors picks every account with the same probability
+	UniformSponsors SponsorDistribution = "uniform"
+	// SingleSponsor sends every transaction from the same account
+	SingleSponsor SponsorDistribution = "single"
+)
+
+// WeightedAction is an action type of the mix of a [Profile]. Each action is
+// chosen with a probability proportional to its [Weight].
+type WeightedAction struct {
+	Name   string `json:"name"`
+	Weight uint64 `json:"weight"`
+}
+
+// Profile declares the traffic sent by the [Spammer]
+type Profile struct {
+	// Actions is the mix of action types to send, generated by
+	// [WorkloadHelper.GetAction]. If empty, the spammer sends
+	// [SpamHelper.GetActions].
+	Actions []WeightedAction `json:"actions"`
+	// ActionsPerTx is the number of actions in each transaction
+	ActionsPerTx int `json:"actionsPerTx"`
+
+	// HotSpotRatio of the actions target one of the first [HotSpots] accounts
+	// to contend on their state. The other actions target a random account.
+	HotSpots     int     `json:"hotSpots"`
+	HotSpotRatio float64 `json:"hotSpotRatio"`
+
+	Sponsors SponsorDistribution `json:"sponsors"`
+
+	Pattern       Pattern       `json:"pattern"`
+	StepInterval  time.Duration `json:"stepInterval"`
+	BurstInterval time.Duration `json:"burstInterval"`
+	BurstDuration time.Duration `json:"burstDuration"`
+
+	// Duration stops the spammer after issuing for this long. If zero, the
+	// spammer runs until it is canceled or, if it was asked to terminate,
+	// until the pattern completes.
+	Duration time.Duration `json:"duration"`
+}
+
+// DefaultProfile sends [SpamHelper.GetActions] from Zipf-chosen accounts
+// with a ramping target TPS
+func DefaultProfile() *Profile {
+	return &Profile{
+		ActionsPerTx: 1,
+		Sponsors:     ZipfSponsors,
+		Pattern:      RampPattern,
+	}
+}
+
+// LoadProfile reads a JSON [Profile] from [path]. Omitted fields are set to
+// their value in [DefaultProfile].
+func LoadProfile(path string) (*Profile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	profile := DefaultProfile()
+	if err := json.Unmarshal(b, profile); err != nil {
+		return nil, err
+	}
+	return profile, profile.Verify()
+}
+
+func (p *Profile) Verify() error {
+	if p.ActionsPerTx < 1 {
+		return fmt.Errorf("%w: actionsPerTx must be at least 1", ErrInvalidProfile)
+	}
+	names := set.NewSet[string](len(p.Actions))
+	var totalWeight uint64
+	for _, action := range p.Actions {
+		if action.Weight == 0 {
+			return fmt.Errorf("%w: action %q has no weight", ErrInvalidProfile, action.Name)
+		}
+		if action.Weight > math.MaxInt64-totalWeight {
+			return fmt.Errorf("%w: total weight is too large", ErrInvalidProfile)
+		}
+		totalWeight += action.Weight
+		if names.Contains(action.Name) {
+			return fmt.Errorf("%w: duplicate action %q", ErrInvalidProfile, action.Name)
+		}
+		names.Add(action.Name)
+	}
+	if p.HotSpotRatio < 0 || p.HotSpotRatio > 1 {
+		return fmt.Errorf("%w: hotSpotRatio must be between 0 and 1", ErrInvalidProfile)
+	}
+	if p.HotSpotRatio > 0 && (p.HotSpots < 1 || len(p.Actions) == 0) {
+		return fmt.Errorf("%w: hot spots require hotSpots and actions", ErrInvalidProfile)
+	}
+	switch p.Sponsors {
+	case ZipfSponsors, UniformSponsors, SingleSponsor:
+	default:
+		return fmt.Errorf("%w: unknown sponsor distribution %q", ErrInvalidProfile, p.Sponsors)
+	}
+	switch p.Pattern {
+	case RampPattern:
+	case StepPattern:
+		if p.StepInterval <= 0 {
+			return fmt.Errorf("%w: step pattern requires stepInterval", ErrInvalidProfile)
+		}
+	case BurstPattern:
+		if p.BurstInterval <= 0 || p.BurstDuration <= 0 || p.BurstDuration > p.BurstInterval {
+			return fmt.Errorf("%w: burst pattern requires 0 < burstDuration <= burstInterval", ErrInvalidProfile)
+		}
+	default:
+		return fmt.Errorf("%w: unknown pattern %q", ErrInvalidProfile, p.Pattern)
+	}
+	if p.Duration < 0 {
+		return fmt.Errorf("%w: negative duration", ErrInvalidProfile)
+	}
+	return nil
+}
+
+// scheduledTarget returns the target TPS of the step and burst patterns
+// [elapsed] after the spammer started and whether the pattern has completed
+func (p *Profile) scheduledTarget(elapsed time.Duration, minTxsPerSecond, txsPerSecond, txsPerSecondStep int) (int, bool) {
+	switch p.Pattern {
+	case StepPattern:
+		steps := int(elapsed / p.StepInterval)
+		target := min(minTxsPerSecond+steps*txsPerSecondStep, txsPerSecond)
+		// Completes once the maximum was held for a full step
+		reachedAt := (txsPerSecond - minTxsPerSecond + txsPerSecondStep - 1) / max(txsPerSecondStep, 1)
+		return target, steps > reachedAt
+	case BurstPattern:
+		if elapsed%p.BurstInterval < p.BurstDuration {
+			return txsPerSecond, false
+		}
+		return minTxsPerSecond, elapsed >= p.BurstInterval
+	default:
+		return txsPerSecond, false
+	}
+}
+
+// actionPicker chooses the actions of each transaction
+type actionPicker struct {
+	names []string
+	// cumulative weights of [names]
+	weights []uint64
+}
+
+func newActionPicker(actions []WeightedAction) *actionPicker {
+	p := &actionPicker{
+		names:   make([]string, len(actions)),
+		weights: make([]uint64, len(actions)),
+	}
+	var total uint64
+	for i, action := range actions {
+		total += action.Weight
+		p.names[i] = action.Name
+		p.weights[i] = total
+	}
+	return p
+}
+
+// pick returns a name with a probability proportional to its weight. Do not
+// call it concurrently with the same [r].
+func (p *actionPicker) pick(r *rand.Rand) string {
+	v := uint64(r.Int63n(int64(p.weights[len(p.weights)-1])))
+	for i, w := range p.weights {
+		if v < w {
+			return p.names[i]
+		}
+	}
+	return p.names[len(p.names)-1]
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throughput
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Profile)
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			modify: func(*Profile) {},
+		},
+		{
+			name:        "no actions per tx",
+			modify:      func(p *Profile) { p.ActionsPerTx = 0 },
+			expectedErr: ErrInvalidProfile,
+		},
+		{
+			name: "zero weight",
+			modify: func(p *Profile) {
+				p.Actions = []WeightedAction{{Name: "transfer"}}
+			},
+			expectedErr: ErrInvalidProfile,
+		},
+		{
+			name: "duplicate action",
+			modify: func(p *Profile) {
+				p.Actions = []WeightedAction{{Name: "transfer", Weight: 1}, {Name: "transfer", Weight: 2}}
+			},
+			expectedErr: ErrInvalidProfile,
+		},
+		{
+			name: "hot spots without actions",
+			modify: func(p *Profile) {
+				p.HotSpots = 1
+				p.HotSpotRatio = 0.5
+			},
+			expectedErr: ErrInvalidProfile,
+		},
+		{
+			name:        "unknown sponsors",
+			modify:      func(p *Profile) { p.Sponsors = "random" },
+			expectedErr: ErrInvalidProfile,
+		},
+		{
+			name:        "step without interval",
+			modify:      func(p *Profile) { p.Pattern = StepPattern },
+			expectedErr: ErrInvalidProfile,
+		},
+		{
+			name: "burst longer than interval",
+			modify: func(p *Profile) {
+				p.Pattern = BurstPattern
+				p.BurstInterval = time.Second
+				p.BurstDuration = 2 * time.Second
+			},
+			expectedErr: ErrInvalidProfile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := DefaultProfile()
+			tt.modify(profile)
+			require.ErrorIs(t, profile.Verify(), tt.expectedErr)
+		})
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "profile.json")
+	require.NoError(os.WriteFile(path, []byte(`{
+		"actions": [{"name": "transfer", "weight": 3}, {"name": "mint", "weight": 1}],
+		"pattern": "step",
+		"stepInterval": 1000000000
+	}`), 0o600))
+
+	profile, err := LoadProfile(path)
+	require.NoError(err)
+	require.Equal(&Profile{
+		Actions:      []WeightedAction{{Name: "transfer", Weight: 3}, {Name: "mint", Weight: 1}},
+		ActionsPerTx: 1,
+		Sponsors:     ZipfSponsors,
+		Pattern:      StepPattern,
+		StepInterval: time.Second,
+	}, profile)
+}
+
+func TestScheduledTarget(t *testing.T) {
+	require := require.New(t)
+
+	step := &Profile{Pattern: StepPattern, StepInterval: 10 * time.Second}
+	for _, tt := range []struct {
+		elapsed  time.Duration
+		target   int
+		complete bool
+	}{
+		{0, 100, false},
+		{15 * time.Second, 150, false},
+		{25 * time.Second, 200, false},
+		{35 * time.Second, 200, true},
+	} {
+		target, complete := step.scheduledTarget(tt.elapsed, 100, 200, 50)
+		require.Equal(tt.target, target, tt.elapsed)
+		require.Equal(tt.complete, complete, tt.elapsed)
+	}
+
+	burst := &Profile{Pattern: BurstPattern, BurstInterval: 10 * time.Second, BurstDuration: 2 * time.Second}
+	for _, tt := range []struct {
+		elapsed  time.Duration
+		target   int
+		complete bool
+	}{
+		{time.Second, 200, false},
+		{5 * time.Second, 100, false},
+		{11 * time.Second, 200, false},
+		{15 * time.Second, 100, true},
+	} {
+		target, complete := burst.scheduledTarget(tt.elapsed, 100, 200, 50)
+		require.Equal(tt.target, target, tt.elapsed)
+		require.Equal(tt.complete, complete, tt.elapsed)
+	}
+}
+
+func TestActionPicker(t *testing.T) {
+	require := require.New(t)
+
+	picker := newActionPicker([]WeightedAction{{Name: "a", Weight: 3}, {Name: "b", Weight: 1}})
+	r := rand.New(rand.NewSource(0)) //nolint:gosec
+	counts := map[string]int{}
+	for i := 0; i < 10_000; i++ {
+		counts[picker.pick(r)]++
+	}
+	require.Len(counts, 2)
+	require.InDelta(3.0, float64(counts["a"])/float64(counts["b"]), 0.3)
+}
+
+func TestReport(t *testing.T) {
+	require := require.New(t)
+
+	tr := newTracker()
+	for i := 1; i <= 100; i++ {
+		tr.latencies = append(tr.latencies, time.Duration(101-i)*time.Millisecond)
+	}
+	tr.issuedTxs = 110
+	tr.confirmedTxs = 95
+	tr.rejections["expired"] = 10
+
+	r := tr.report(*DefaultProfile(), 10*time.Second)
+	require.Equal(110, r.Issued)
+	require.Equal(100, r.Included)
+	require.Equal(95, r.Succeeded)
+	require.InDelta(10.0, r.AchievedTPS, 0.001)
+	require.Equal(LatencyReport{
+		P50: 50 * time.Millisecond,
+		P90: 90 * time.Millisecond,
+		P99: 99 * time.Millisecond,
+		Max: 100 * time.Millisecond,
+	}, r.Latency)
+	require.Equal(map[string]int{"expired": 10}, r.Rejections)
+}
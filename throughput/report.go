@@ -0,0 +1,87 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throughput
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"time"
+)
+
+// Report summarizes a run of the [Spammer]
+type Report struct {
+	Profile  Profile       `json:"profile"`
+	Duration time.Duration `json:"duration"`
+
+	// Issued is the number of txs sent to the network
+	Issued int `json:"issued"`
+	// Included is the number of txs included in a block, successful or not
+	Included int `json:"included"`
+	// Succeeded is the number of included txs that executed successfully
+	Succeeded int `json:"succeeded"`
+	// AchievedTPS is the number of included txs per second
+	AchievedTPS float64 `json:"achievedTPS"`
+
+	// Latency is the time from submitting a tx to its inclusion in an
+	// accepted block
+	Latency LatencyReport `json:"latency"`
+
+	// Rejections counts the txs that failed on-chain or were not included by
+	// error message
+	Rejections map[string]int `json:"rejections"`
+}
+
+type LatencyReport struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// WriteFile writes the report as JSON to [path]
+func (r *Report) WriteFile(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+func (t *tracker) report(profile Profile, duration time.Duration) *Report {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	latencies := slices.Clone(t.latencies)
+	slices.Sort(latencies)
+	r := &Report{
+		Profile:    profile,
+		Duration:   duration,
+		Issued:     t.issuedTxs,
+		Included:   len(latencies),
+		Succeeded:  t.confirmedTxs,
+		Rejections: maps.Clone(t.rejections),
+		Latency: LatencyReport{
+			P50: percentile(latencies, 50),
+			P90: percentile(latencies, 90),
+			P99: percentile(latencies, 99),
+			Max: percentile(latencies, 100),
+		},
+	}
+	if duration > 0 {
+		r.AchievedTPS = float64(r.Included) / duration.Seconds()
+	}
+	return r
+}
+
+// percentile returns the nearest-rank [p]th percentile of [sorted]
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/hypersdk/api/ws"
 	"github.com/ava-labs/hypersdk/auth"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/pubsub"
@@ -58,14 +59,47 @@ type Spammer struct {
 	// Number of accounts
 	numAccounts int
 
+	profile *Profile
+	// workload and picker are set if the profile has an action mix
+	workload WorkloadHelper
+	picker   *actionPicker
+
 	// keep track of variables shared across issuers
 	tracker *tracker
+	report  *Report
+}
+
+// pickedAction is an action of a transaction chosen by the broadcast loop
+type pickedAction struct {
+	name   string
+	target codec.Address
 }
 
 func NewSpammer(sc *Config, sh SpamHelper) (*Spammer, error) {
 	// Log Zipf participants
 	zipfSeed := rand.New(rand.NewSource(0)) //nolint:gosec
-	tracker := &tracker{}
+	tracker := newTracker()
+
+	profile := sc.profile
+	if err := profile.Verify(); err != nil {
+		return nil, err
+	}
+	if profile.HotSpots > sc.numAccounts {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyHotSpots, profile.HotSpots, sc.numAccounts)
+	}
+	var (
+		workload WorkloadHelper
+		picker   *actionPicker
+	)
+	if len(profile.Actions) > 0 {
+		var ok bool
+		workload, ok = sh.(WorkloadHelper)
+		if !ok {
+			return nil, ErrWorkloadNotSupported
+		}
+		picker = newActionPicker(profile.Actions)
+	}
+
 	balance, err := sh.LookupBalance(sc.authFactory.Address())
 	if err != nil {
 		return nil, err
@@ -85,10 +119,20 @@ func NewSpammer(sc *Config, sh SpamHelper) (*Spammer, error) {
 		numClients:       sc.numClients,
 		numAccounts:      sc.numAccounts,
 
+		profile:  profile,
+		workload: workload,
+		picker:   picker,
+
 		tracker: tracker,
 	}, nil
 }
 
+// Report returns the report of the last call to [Spam] or nil if it did not
+// complete
+func (s *Spammer) Report() *Report {
+	return s.report
+}
+
 // Spam tests the throughput of the network by sending transactions using
 // multiple accounts and clients. It first distributes funds to the accounts
 // and then sends transactions between the accounts. It returns the funds to
@@ -109,7 +153,7 @@ func (s *Spammer) Spam(ctx context.Context, sh SpamHelper, terminate bool, symbo
 		return err
 	}
 	actions := sh.GetTransfer(s.authFactory.Address(), 0, s.tracker.uniqueBytes())
-	maxUnits, err := chain.EstimateUnits(parser.Rules(time.Now().UnixMilli()), actions, s.authFactory)
+	maxUnits, err := s.estimateWorkloadUnits(parser.Rules(time.Now().UnixMilli()), sh)
 	if err != nil {
 		return err
 	}
@@ -146,6 +190,7 @@ func (s *Spammer) Spam(ctx context.Context, sh SpamHelper, terminate bool, symbo
 	s.tracker.logState(cctx, issuers[0].cli)
 
 	// broadcast transactions
+	start := time.Now()
 	err = s.broadcast(cctx, sh, accounts, factories, issuers, feePerTx, terminate)
 	cancel()
 	if err != nil {
@@ -155,6 +200,15 @@ func (s *Spammer) Spam(ctx context.Context, sh SpamHelper, terminate bool, symbo
 	// Wait for all issuers to finish
 	utils.Outf("{{yellow}}waiting for issuers to return{{/}}\n")
 	s.tracker.issuerWg.Wait()
+	s.report = s.tracker.report(*s.profile, time.Since(start))
+	utils.Outf(
+		"{{yellow}}achieved tps:{{/}} %.2f {{yellow}}included:{{/}} %d/%d {{yellow}}p50 latency:{{/}} %s {{yellow}}p99 latency:{{/}} %s\n",
+		s.report.AchievedTPS,
+		s.report.Included,
+		s.report.Issued,
+		s.report.Latency.P50,
+		s.report.Latency.P99,
+	)
 
 	maxUnits, err = chain.EstimateUnits(parser.Rules(time.Now().UnixMilli()), actions, s.authFactory)
 	if err != nil {
@@ -188,6 +242,8 @@ func (s Spammer) broadcast(
 		consecutiveAboveBacklog int
 		broadcastErr            error
 		stop                    bool
+		broadcastStart          = time.Now()
+		ramp                    = s.profile.Pattern == RampPattern
 	)
 	utils.Outf("{{cyan}}initial target tps:{{/}} %d\n", currentTarget)
 	for !stop {
@@ -195,11 +251,30 @@ func (s Spammer) broadcast(
 		case <-it.C:
 			start := time.Now()
 
+			elapsed := start.Sub(broadcastStart)
+			if s.profile.Duration > 0 && elapsed >= s.profile.Duration {
+				utils.Outf("{{green}}reached profile duration:{{/}} %s\n", s.profile.Duration)
+				stop = true
+				break
+			}
+			if !ramp {
+				target, done := s.profile.scheduledTarget(elapsed, s.minTxsPerSecond, s.txsPerSecond, s.txsPerSecondStep)
+				if terminate && done {
+					utils.Outf("{{green}}completed %s pattern{{/}}\n", s.profile.Pattern)
+					stop = true
+					break
+				}
+				if target != currentTarget {
+					currentTarget = target
+					utils.Outf("{{cyan}}%s pattern target tps:{{/}} %d\n", s.profile.Pattern, currentTarget)
+				}
+			}
+
 			// Check to see if we should wait for pending txs
 			if int64(currentTarget)+s.tracker.inflight.Load() > int64(currentTarget*pendingTargetMultiplier) {
 				consecutiveUnderBacklog = 0
 				consecutiveAboveBacklog++
-				if consecutiveAboveBacklog >= failedRunsToDecreaseTarget {
+				if ramp && consecutiveAboveBacklog >= failedRunsToDecreaseTarget {
 					if currentTarget > s.txsPerSecondStep {
 						currentTarget -= s.txsPerSecondStep
 						utils.Outf("{{cyan}}skipping issuance because large backlog detected, decreasing target tps:{{/}} %d\n", currentTarget)
@@ -216,9 +291,10 @@ func (s Spammer) broadcast(
 			g := &errgroup.Group{}
 			g.SetLimit(maxConcurrency)
 			for i := 0; i < currentTarget; i++ {
-				senderIndex := z.Uint64()
+				senderIndex := s.pickSponsor(z)
 				sender := accounts[senderIndex].Address
 				issuer := getRandomIssuer(issuers)
+				picked := s.pickActions(accounts)
 				g.Go(func() error {
 					factory := factories[senderIndex]
 					balance, err := sh.LookupBalance(sender)
@@ -229,7 +305,10 @@ func (s Spammer) broadcast(
 						return fmt.Errorf("insufficient funds (have=%d need=%d)", balance, feePerTx)
 					}
 					// Send transaction
-					actions := sh.GetActions()
+					actions, err := s.buildActions(sh, picked)
+					if err != nil {
+						return err
+					}
 					return issuer.Send(ctx, actions, factory, feePerTx)
 				})
 			}
@@ -251,6 +330,9 @@ func (s Spammer) broadcast(
 			// Check to see if we should increase target
 			consecutiveAboveBacklog = 0
 			consecutiveUnderBacklog++
+			if !ramp {
+				break
+			}
 			// once desired TPS is reached, stop the spammer
 			if terminate && currentTarget == s.txsPerSecond && consecutiveUnderBacklog >= successfulRunsToIncreaseTarget {
 				utils.Outf("{{green}}reached target tps:{{/}} %d\n", currentTarget)
@@ -272,6 +354,96 @@ func (s Spammer) broadcast(
 	return broadcastErr
 }
 
+// estimateWorkloadUnits returns the maximum units of the txs sent by the
+// broadcast loop
+func (s *Spammer) estimateWorkloadUnits(rules chain.Rules, sh SpamHelper) (fees.Dimensions, error) {
+	var txs [][]chain.Action
+	if s.workload == nil {
+		// [SpamHelper.GetActions] may depend on the created accounts, so we
+		// estimate with transfers
+		txs = append(txs, s.repeatAction(sh.GetTransfer(s.authFactory.Address(), 0, s.tracker.uniqueBytes())...))
+	}
+	for _, action := range s.profile.Actions {
+		a, err := s.workload.GetAction(action.Name, s.authFactory.Address())
+		if err != nil {
+			return fees.Dimensions{}, err
+		}
+		txs = append(txs, s.repeatAction(a))
+	}
+
+	var maxUnits fees.Dimensions
+	for _, actions := range txs {
+		units, err := chain.EstimateUnits(rules, actions, s.authFactory)
+		if err != nil {
+			return fees.Dimensions{}, err
+		}
+		for i := range maxUnits {
+			maxUnits[i] = max(maxUnits[i], units[i])
+		}
+	}
+	return maxUnits, nil
+}
+
+func (s *Spammer) repeatAction(actions ...chain.Action) []chain.Action {
+	repeated := make([]chain.Action, 0, len(actions)*s.profile.ActionsPerTx)
+	for i := 0; i < s.profile.ActionsPerTx; i++ {
+		repeated = append(repeated, actions...)
+	}
+	return repeated
+}
+
+// pickSponsor returns the index of the account that sends the next tx. Do not
+// call it concurrently.
+func (s *Spammer) pickSponsor(z *rand.Zipf) uint64 {
+	switch s.profile.Sponsors {
+	case UniformSponsors:
+		return uint64(s.zipfSeed.Intn(s.numAccounts))
+	case SingleSponsor:
+		return 0
+	default:
+		return z.Uint64()
+	}
+}
+
+// pickActions returns the actions of the next tx or nil if the profile has no
+// action mix. Do not call it concurrently.
+func (s *Spammer) pickActions(accounts []*auth.PrivateKey) []pickedAction {
+	if s.picker == nil {
+		return nil
+	}
+	picked := make([]pickedAction, s.profile.ActionsPerTx)
+	for i := range picked {
+		target := accounts[s.zipfSeed.Intn(len(accounts))]
+		if s.zipfSeed.Float64() < s.profile.HotSpotRatio {
+			target = accounts[s.zipfSeed.Intn(s.profile.HotSpots)]
+		}
+		picked[i] = pickedAction{
+			name:   s.picker.pick(s.zipfSeed),
+			target: target.Address,
+		}
+	}
+	return picked
+}
+
+func (s *Spammer) buildActions(sh SpamHelper, picked []pickedAction) ([]chain.Action, error) {
+	if picked == nil {
+		actions := make([]chain.Action, 0, s.profile.ActionsPerTx)
+		for i := 0; i < s.profile.ActionsPerTx; i++ {
+			actions = append(actions, sh.GetActions()...)
+		}
+		return actions, nil
+	}
+	actions := make([]chain.Action, len(picked))
+	for i, p := range picked {
+		action, err := s.workload.GetAction(p.name, p.target)
+		if err != nil {
+			return nil, err
+		}
+		actions[i] = action
+	}
+	return actions, nil
+}
+
 func (s *Spammer) logZipf(zipfSeed *rand.Rand) {
 	zz := rand.NewZipf(zipfSeed, s.sZipf, s.vZipf, uint64(s.numAccounts)-1)
 	trials := s.txsPerSecond * 60 * 2 // sender/receiver
@@ -11,6 +11,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
+
 	"github.com/ava-labs/hypersdk/api/jsonrpc"
 	"github.com/ava-labs/hypersdk/api/ws"
 	"github.com/ava-labs/hypersdk/chain"
@@ -25,21 +27,52 @@ type tracker struct {
 	confirmedTxs int
 	totalTxs     int
 
+	// submitted is when each inflight tx was sent
+	submitted map[ids.ID]time.Time
+	issuedTxs int
+	// latencies of the txs included in a block
+	latencies []time.Duration
+	// rejections counts the txs that failed or were not included by error
+	rejections map[string]int
+
 	sent atomic.Int64
 }
 
+func newTracker() *tracker {
+	return &tracker{
+		submitted:  make(map[ids.ID]time.Time),
+		rejections: make(map[string]int),
+	}
+}
+
+func (t *tracker) submit(txID ids.ID) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	t.submitted[txID] = time.Now()
+	t.issuedTxs++
+}
+
 func (t *tracker) logResult(
+	txID ids.ID,
 	result *chain.Result,
 	wsErr error,
 ) {
 	t.l.Lock()
+	submitted, ok := t.submitted[txID]
+	delete(t.submitted, txID)
 	if result != nil {
+		if ok {
+			t.latencies = append(t.latencies, time.Since(submitted))
+		}
 		if result.Success {
 			t.confirmedTxs++
 		} else {
+			t.rejections[string(result.Error)]++
 			utils.Outf("{{orange}}on-chain tx failure:{{/}} %s %t\n", string(result.Error), result.Success)
 		}
 	} else {
+		t.rejections[wsErr.Error()]++
 		// We can't error match here because we receive it over the wire.
 		if !strings.Contains(wsErr.Error(), ws.ErrExpired.Error()) {
 			utils.Outf("{{orange}}pre-execute tx failure:{{/}} %v\n", wsErr)
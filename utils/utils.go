@@ -93,11 +93,18 @@ func Repeat[T any](v T, n int) []T {
 // [add] (in ms) is added to the unix time before it is rounded (typically
 // used when generating an expiry time with a validity window).
 func UnixRMilli(now, add int64) int64 {
+	return UnixRMilliModulus(now, add, consts.MillisecondsPerSecond)
+}
+
+// UnixRMilliModulus is like [UnixRMilli] but rounds down to the nearest
+// multiple of [modulus] (in ms), which is typically the timestamp modulus of
+// the chain.
+func UnixRMilliModulus(now, add, modulus int64) int64 {
 	if now < 0 {
 		now = time.Now().UnixMilli()
 	}
 	t := now + add
-	return t - t%consts.MillisecondsPerSecond
+	return t - t%modulus
 }
 
 // SaveBytes writes [b] to a file [filename]. If filename does
@@ -111,3 +111,11 @@ func TestFormatAndParseBalance(t *testing.T) {
 	_, err := ParseBalance("invalid")
 	require.ErrorIs(err, strconv.ErrSyntax)
 }
+
+func TestUnixRMilliModulus(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(int64(61_000), UnixRMilli(1_234, 60_000))
+	require.Equal(int64(61_200), UnixRMilliModulus(1_234, 60_000, 100))
+	require.Equal(int64(61_234), UnixRMilliModulus(1_234, 60_000, 1))
+}
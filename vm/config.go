@@ -11,6 +11,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/units"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/internal/builder"
+	"github.com/ava-labs/hypersdk/internal/gossiper"
 	"github.com/ava-labs/hypersdk/internal/trace"
 )
 
@@ -21,6 +23,7 @@ type Config struct {
 	VerifyAuth                       bool                       `json:"verifyAuth"`
 	RootGenerationCores              int                        `json:"rootGenerationCores"`
 	MempoolSponsorSize               int                        `json:"mempoolSponsorSize"`
+	MempoolPersistence               bool                       `json:"mempoolPersistence"`               // journal mempool txs to disk and restore them on restart
 	StateHistoryLength               int                        `json:"stateHistoryLength"`               // how many roots back of data to keep to serve state queries
 	IntermediateNodeCacheSize        int                        `json:"intermediateNodeCacheSize"`        // how many bytes to keep in intermediate cache
 	StateIntermediateWriteBufferSize int                        `json:"stateIntermediateWriteBufferSize"` // how many bytes to keep unwritten in intermediate cache
@@ -36,6 +39,10 @@ type Config struct {
 	ContinuousProfilerConfig         profiler.Config            `json:"continuousProfilerConfig"`
 	ProcessingBuildSkip              int                        `json:"processingBuildSkip"`
 	TargetGossipDuration             time.Duration              `json:"targetGossipDuration"`
+	GossipConfig                     gossiper.ProposerConfig    `json:"gossipConfig"`
+	PullGossipConfig                 gossiper.PullConfig        `json:"pullGossipConfig"`
+	PrivateTxConfig                  gossiper.PrivateConfig     `json:"privateTxConfig"`
+	AdaptiveBuilderConfig            builder.AdaptiveConfig     `json:"adaptiveBuilderConfig"`
 	BlockCompactionFrequency         int                        `json:"blockCompactionFrequency"`
 	ChainConfig                      chain.Config               `json:"executionConfig"`
 	ServiceConfig                    map[string]json.RawMessage `json:"services"` // Config of service namespace -> raw service config
@@ -49,6 +56,7 @@ func NewConfig() Config {
 		VerifyAuth:                       true,
 		RootGenerationCores:              1,
 		MempoolSponsorSize:               32,
+		MempoolPersistence:               false,
 		StateHistoryLength:               256,
 		IntermediateNodeCacheSize:        4 * units.GiB,
 		StateIntermediateWriteBufferSize: 32 * units.MiB,
@@ -64,6 +72,9 @@ func NewConfig() Config {
 		ContinuousProfilerConfig:         profiler.Config{Enabled: false},
 		ProcessingBuildSkip:              16,
 		TargetGossipDuration:             20 * time.Millisecond,
+		GossipConfig:                     *gossiper.DefaultProposerConfig(),
+		PullGossipConfig:                 *gossiper.DefaultPullConfig(),
+		AdaptiveBuilderConfig:            *builder.DefaultAdaptiveConfig(),
 		BlockCompactionFrequency:         32, // 64 MB of deletion if 2 MB blocks
 		ChainConfig:                      chain.NewDefaultConfig(),
 	}
@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// mempoolJournal stores the txs submitted to this node on-disk so that they
+// can be restored to the mempool after a restart (only used if
+// [Config.MempoolPersistence] is set).
+//
+// Txs received over gossip are not journaled because they are journaled by
+// the node they were submitted to.
+type mempoolJournal struct {
+	log         logging.Logger
+	db          database.Database
+	actionCodec *codec.TypeParser[chain.Action]
+	authCodec   *codec.TypeParser[chain.Auth]
+}
+
+func newMempoolJournal(
+	log logging.Logger,
+	db database.Database,
+	actionCodec *codec.TypeParser[chain.Action],
+	authCodec *codec.TypeParser[chain.Auth],
+) *mempoolJournal {
+	return &mempoolJournal{
+		log:         log,
+		db:          db,
+		actionCodec: actionCodec,
+		authCodec:   authCodec,
+	}
+}
+
+// Add stores [txs] in the journal
+func (j *mempoolJournal) Add(txs []*chain.Transaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	batch := j.db.NewBatch()
+	for _, tx := range txs {
+		if err := batch.Put(PrefixMempoolKey(tx.Expiry(), tx.ID()), tx.Bytes()); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// Remove deletes [txs] and all txs that expire before [minTimestamp] from the
+// journal.
+func (j *mempoolJournal) Remove(txs []*chain.Transaction, minTimestamp int64) error {
+	batch := j.db.NewBatch()
+	for _, tx := range txs {
+		if err := batch.Delete(PrefixMempoolKey(tx.Expiry(), tx.ID())); err != nil {
+			return err
+		}
+	}
+	it := j.db.NewIteratorWithPrefix([]byte{mempoolPrefix})
+	defer it.Release()
+	for it.Next() {
+		if int64(binary.BigEndian.Uint64(it.Key()[1:])) >= minTimestamp {
+			break
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Restore passes the journaled txs that do not expire before [minTimestamp]
+// to [submit], which re-validates them and adds them to the mempool. Txs that
+// are expired, cannot be parsed, or are rejected by [submit] are removed from
+// the journal. Txs rejected with [ErrNotAdded] are already in the mempool, so
+// they are kept.
+func (j *mempoolJournal) Restore(
+	ctx context.Context,
+	minTimestamp int64,
+	submit func(context.Context, []*chain.Transaction) []error,
+) error {
+	var (
+		txs     []*chain.Transaction
+		dropped [][]byte
+	)
+	it := j.db.NewIteratorWithPrefix([]byte{mempoolPrefix})
+	for it.Next() {
+		// The iterator may reuse the key buffer
+		key := slices.Clone(it.Key())
+		if int64(binary.BigEndian.Uint64(key[1:])) < minTimestamp {
+			dropped = append(dropped, key)
+			continue
+		}
+		p := codec.NewReader(it.Value(), consts.NetworkSizeLimit)
+		tx, err := chain.UnmarshalTx(p, j.actionCodec, j.authCodec)
+		if err == nil && !p.Empty() {
+			err = chain.ErrInvalidObject
+		}
+		if err != nil {
+			// The tx may have been encoded by an incompatible version of the
+			// VM
+			j.log.Warn("dropping unparsable journaled tx", zap.Error(err))
+			dropped = append(dropped, key)
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	var restored int
+	if len(txs) > 0 {
+		errs := submit(ctx, txs)
+		if len(errs) != len(txs) {
+			// [submit] returns a single error if it could not validate any
+			// txs, so we keep the journal to retry on the next restart
+			return fmt.Errorf("%w: unable to submit journaled txs", errors.Join(errs...))
+		}
+		for i, err := range errs {
+			switch {
+			case err == nil:
+				restored++
+			case errors.Is(err, ErrNotAdded):
+			default:
+				dropped = append(dropped, PrefixMempoolKey(txs[i].Expiry(), txs[i].ID()))
+			}
+		}
+	}
+	batch := j.db.NewBatch()
+	for _, key := range dropped {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("%w: unable to remove dropped txs from mempool journal", err)
+	}
+	j.log.Info("restored mempool from journal",
+		zap.Int("restored", restored),
+		zap.Int("dropped", len(dropped)),
+	)
+	return nil
+}
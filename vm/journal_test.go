@@ -0,0 +1,267 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var (
+	_ chain.Action      = (*journalAction)(nil)
+	_ chain.Auth        = (*journalAuth)(nil)
+	_ chain.AuthFactory = (*journalAuth)(nil)
+
+	errPreExecute = errors.New("pre-execute failed")
+)
+
+type journalAction struct {
+	ID uint64 `serialize:"true" json:"id"`
+}
+
+func (*journalAction) GetTypeID() uint8 {
+	return 0
+}
+
+func (*journalAction) ComputeUnits(chain.Rules) uint64 {
+	return 1
+}
+
+func (*journalAction) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{}
+}
+
+func (*journalAction) Execute(context.Context, chain.Rules, state.Mutable, int64, codec.Address, ids.ID) (codec.Typed, error) {
+	return nil, nil
+}
+
+func (*journalAction) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func unmarshalJournalAction(p *codec.Packer) (chain.Action, error) {
+	var action journalAction
+	err := codec.LinearCodec.UnmarshalFrom(p.Packer, &action)
+	return &action, err
+}
+
+// journalAuth is an unsigned auth because the journal does not verify txs
+type journalAuth struct {
+	Signer codec.Address `serialize:"true" json:"signer"`
+}
+
+func (*journalAuth) GetTypeID() uint8 {
+	return 0
+}
+
+func (*journalAuth) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (a *journalAuth) Marshal(p *codec.Packer) {
+	p.PackAddress(a.Signer)
+}
+
+func (*journalAuth) Size() int {
+	return codec.AddressLen
+}
+
+func (*journalAuth) ComputeUnits(chain.Rules) uint64 {
+	return 1
+}
+
+func (*journalAuth) Verify(context.Context, []byte) error {
+	return nil
+}
+
+func (a *journalAuth) Actor() codec.Address {
+	return a.Signer
+}
+
+func (a *journalAuth) Sponsor() codec.Address {
+	return a.Signer
+}
+
+func (a *journalAuth) Sign([]byte) (chain.Auth, error) {
+	return a, nil
+}
+
+func (*journalAuth) MaxUnits() (uint64, uint64) {
+	return codec.AddressLen, 1
+}
+
+func (a *journalAuth) Address() codec.Address {
+	return a.Signer
+}
+
+func unmarshalJournalAuth(p *codec.Packer) (chain.Auth, error) {
+	var auth journalAuth
+	p.UnpackAddress(&auth.Signer)
+	return &auth, p.Err()
+}
+
+func newTestMempoolJournal(t *testing.T) (*mempoolJournal, func() *mempoolJournal) {
+	r := require.New(t)
+
+	actionCodec := codec.NewTypeParser[chain.Action]()
+	r.NoError(actionCodec.Register(&journalAction{}, unmarshalJournalAction))
+	authCodec := codec.NewTypeParser[chain.Auth]()
+	r.NoError(authCodec.Register(&journalAuth{}, unmarshalJournalAuth))
+
+	db := memdb.New()
+	restart := func() *mempoolJournal {
+		return newMempoolJournal(logging.NoLog{}, db, actionCodec, authCodec)
+	}
+	return restart(), restart
+}
+
+// newJournalTxs returns a tx expiring at each of [expiries]
+func newJournalTxs(t *testing.T, expiries ...int64) []*chain.Transaction {
+	factory := &journalAuth{Signer: codec.CreateAddress(0, ids.GenerateTestID())}
+
+	txs := make([]*chain.Transaction, len(expiries))
+	for i, expiry := range expiries {
+		txData := chain.NewTxData(
+			&chain.Base{
+				Timestamp: expiry,
+				ChainID:   ids.GenerateTestID(),
+				MaxFee:    1,
+			},
+			[]chain.Action{&journalAction{ID: uint64(i)}},
+		)
+		tx, err := txData.Sign(factory)
+		require.NoError(t, err)
+		txs[i] = tx
+	}
+	return txs
+}
+
+func TestMempoolJournalRestore(t *testing.T) {
+	txs := newJournalTxs(t, 1_000, 2_000, 3_000, 4_000)
+	expired, valid, invalid, inMempool := txs[0], txs[1], txs[2], txs[3]
+
+	tests := []struct {
+		name        string
+		submitErrs  map[ids.ID]error
+		wantErr     bool
+		wantSubmit  []*chain.Transaction
+		wantJournal []*chain.Transaction
+	}{
+		{
+			name: "restore journaled txs",
+			submitErrs: map[ids.ID]error{
+				invalid.ID():   errPreExecute,
+				inMempool.ID(): ErrNotAdded,
+			},
+			// Expired txs are dropped without being submitted
+			wantSubmit: []*chain.Transaction{valid, invalid, inMempool},
+			// Txs that fail validation again are dropped
+			wantJournal: []*chain.Transaction{valid, inMempool},
+		},
+		{
+			name:       "keep journal if txs cannot be validated",
+			wantErr:    true,
+			wantSubmit: []*chain.Transaction{valid, invalid, inMempool},
+			// The journal is kept to retry on the next restart
+			wantJournal: []*chain.Transaction{valid, invalid, inMempool},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			ctx := context.Background()
+
+			journal, restart := newTestMempoolJournal(t)
+			r.NoError(journal.Add(txs))
+
+			var submitted []*chain.Transaction
+			submit := func(_ context.Context, txs []*chain.Transaction) []error {
+				submitted = txs
+				if tt.submitErrs == nil {
+					return []error{ErrNotReady}
+				}
+				errs := make([]error, len(txs))
+				for i, tx := range txs {
+					errs[i] = tt.submitErrs[tx.ID()]
+				}
+				return errs
+			}
+			err := restart().Restore(ctx, expired.Expiry()+1, submit)
+			if tt.wantErr {
+				r.ErrorIs(err, ErrNotReady)
+			} else {
+				r.NoError(err)
+			}
+			r.Len(submitted, len(tt.wantSubmit))
+			for i, tx := range tt.wantSubmit {
+				r.Equal(tx.ID(), submitted[i].ID())
+			}
+
+			// Restoring again submits only the txs left in the journal
+			submitted = nil
+			r.NoError(restart().Restore(ctx, expired.Expiry()+1, func(_ context.Context, txs []*chain.Transaction) []error {
+				submitted = txs
+				return make([]error, len(txs))
+			}))
+			r.Len(submitted, len(tt.wantJournal))
+			for i, tx := range tt.wantJournal {
+				r.Equal(tx.ID(), submitted[i].ID())
+				r.Equal(tx.Bytes(), submitted[i].Bytes())
+			}
+		})
+	}
+}
+
+func TestMempoolJournalRemove(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	journal, restart := newTestMempoolJournal(t)
+	txs := newJournalTxs(t, 1_000, 2_000, 3_000, 4_000)
+	r.NoError(journal.Add(txs))
+
+	// Remove an accepted tx and all txs that expired before the accepted block
+	r.NoError(journal.Remove([]*chain.Transaction{txs[2]}, txs[1].Expiry()))
+
+	var submitted []*chain.Transaction
+	r.NoError(restart().Restore(ctx, 0, func(_ context.Context, txs []*chain.Transaction) []error {
+		submitted = txs
+		return make([]error, len(txs))
+	}))
+	r.Len(submitted, 2)
+	r.Equal(txs[1].ID(), submitted[0].ID())
+	r.Equal(txs[3].ID(), submitted[1].ID())
+}
+
+func TestMempoolJournalDropUnparsable(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	journal, restart := newTestMempoolJournal(t)
+	txs := newJournalTxs(t, 1_000, 2_000)
+	r.NoError(journal.Add(txs))
+	r.NoError(journal.db.Put(PrefixMempoolKey(txs[0].Expiry(), txs[0].ID()), []byte{0x1}))
+
+	var submitted []*chain.Transaction
+	r.NoError(restart().Restore(ctx, 0, func(_ context.Context, txs []*chain.Transaction) []error {
+		submitted = txs
+		return make([]error, len(txs))
+	}))
+	r.Len(submitted, 1)
+	r.Equal(txs[1].ID(), submitted[0].ID())
+
+	has, err := journal.db.Has(PrefixMempoolKey(txs[0].Expiry(), txs[0].ID()))
+	r.NoError(err)
+	r.False(has)
+}
@@ -17,6 +17,7 @@ type Options struct {
 	gossiper                   bool
 	blockSubscriptionFactories []event.SubscriptionFactory[*chain.ExecutedBlock]
 	vmAPIHandlerFactories      []api.HandlerFactory[api.VM]
+	buildPolicy                chain.BuildPolicy
 }
 
 type optionFunc func(vm api.VM, configBytes []byte) (Opt, error)
@@ -90,6 +91,14 @@ func WithVMAPIs(apiHandlerFactories ...api.HandlerFactory[api.VM]) Opt {
 	})
 }
 
+// WithBuildPolicy replaces [chain.DefaultBuildPolicy] with [policy] when
+// building blocks
+func WithBuildPolicy(policy chain.BuildPolicy) Opt {
+	return newFuncOption(func(o *Options) {
+		o.buildPolicy = policy
+	})
+}
+
 type Opt interface {
 	apply(*Options)
 }
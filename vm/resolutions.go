@@ -289,6 +289,11 @@ func (vm *VM) Accepted(ctx context.Context, b *StatefulBlock) {
 	// transactions instead of the mempool because we won't need to iterate
 	// through as many transactions.
 	removed := vm.mempool.SetMinTimestamp(ctx, blkTime)
+	if vm.journal != nil {
+		if err := vm.journal.Remove(b.StatelessBlock.Txs, blkTime); err != nil {
+			vm.snowCtx.Log.Warn("unable to remove txs from mempool journal", zap.Error(err))
+		}
+	}
 
 	// Enqueue block for processing
 	vm.acceptedQueue <- b
@@ -309,6 +314,19 @@ func (vm *VM) Proposers(ctx context.Context, diff int, depth int) (set.Set[ids.N
 	return vm.proposerMonitor.Proposers(ctx, diff, depth)
 }
 
+// Weights returns the stake weight of each current validator
+func (vm *VM) Weights(ctx context.Context) (map[ids.NodeID]uint64, error) {
+	validators, _ := vm.proposerMonitor.Validators(ctx)
+	if validators == nil {
+		return nil, ErrValidatorsUnavailable
+	}
+	weights := make(map[ids.NodeID]uint64, len(validators))
+	for nodeID, validator := range validators {
+		weights[nodeID] = validator.Weight
+	}
+	return weights, nil
+}
+
 func (vm *VM) CurrentValidators(
 	ctx context.Context,
 ) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}) {
@@ -394,6 +412,9 @@ func (vm *VM) cacheAuth(auth chain.Auth) {
 
 func (vm *VM) RecordBlockVerify(t time.Duration) {
 	vm.metrics.blockVerify.Observe(float64(t))
+	if adaptiveBuilder, ok := vm.builder.(*builder.Adaptive); ok {
+		adaptiveBuilder.ObserveVerify(t)
+	}
 }
 
 func (vm *VM) RecordBlockAccept(t time.Duration) {
@@ -31,6 +31,7 @@ const (
 	blockPrefix         = 0x0 // TODO: move to flat files (https://github.com/ava-labs/hypersdk/issues/553)
 	blockIDHeightPrefix = 0x1 // ID -> Height
 	blockHeightIDPrefix = 0x2 // Height -> ID (don't always need full block from disk)
+	mempoolPrefix       = 0x3 // Expiry|ID -> Tx (only used if [Config.MempoolPersistence] is set)
 )
 
 var (
@@ -60,6 +61,16 @@ func PrefixBlockHeightIDKey(height uint64) []byte {
 	return k
 }
 
+// PrefixMempoolKey orders journaled txs by expiry so that expired txs can be
+// deleted without scanning the entire journal.
+func PrefixMempoolKey(expiry int64, id ids.ID) []byte {
+	k := make([]byte, 1+consts.Uint64Len+ids.IDLen)
+	k[0] = mempoolPrefix
+	binary.BigEndian.PutUint64(k[1:], uint64(expiry))
+	copy(k[1+consts.Uint64Len:], id[:])
+	return k
+}
+
 func (vm *VM) HasGenesis() (bool, error) {
 	return vm.HasDiskBlock(0)
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
@@ -28,6 +29,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/api"
+	"github.com/ava-labs/hypersdk/api/middleware"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/event"
@@ -61,7 +63,9 @@ const (
 	MaxAcceptorSize        = 256
 	MinAcceptedBlockWindow = 1024
 
-	txGossipHandlerID = 0x2
+	txGossipHandlerID     = 0x2
+	txPullGossipHandlerID = 0x3
+	privateTxHandlerID    = 0x4
 )
 
 type VM struct {
@@ -87,7 +91,10 @@ type VM struct {
 	seenValidityWindow      chan struct{}
 
 	builder                    builder.Builder
+	buildPolicy                chain.BuildPolicy
 	gossiper                   gossiper.Gossiper
+	pullGossiper               *gossiper.Pull[*chain.Transaction]
+	privateTxs                 *gossiper.Private[*chain.Transaction]
 	blockSubscriptionFactories []event.SubscriptionFactory[*chain.ExecutedBlock]
 	blockSubscriptions         []event.Subscription[*chain.ExecutedBlock]
 
@@ -96,6 +103,7 @@ type VM struct {
 	stateDB               merkledb.MerkleDB
 	vmDB                  database.Database
 	handlers              map[string]http.Handler
+	apiMiddlewareMetrics  *middleware.Metrics
 	balanceHandler        chain.BalanceHandler
 	metadataManager       chain.MetadataManager
 	actionCodec           *codec.TypeParser[chain.Action]
@@ -106,6 +114,7 @@ type VM struct {
 
 	tracer  avatrace.Tracer
 	mempool *mempool.Mempool[*chain.Transaction]
+	journal *mempoolJournal // nil unless [Config.MempoolPersistence] is set
 
 	// We cannot use a map here because we may parse blocks up in the ancestry
 	parsedBlocks *avacache.LRU[ids.ID, *StatefulBlock]
@@ -256,6 +265,9 @@ func (vm *VM) Initialize(
 
 	// Set defaults
 	vm.mempool = mempool.New[*chain.Transaction](vm.tracer, vm.config.MempoolSize, vm.config.MempoolSponsorSize)
+	if vm.config.MempoolPersistence {
+		vm.journal = newMempoolJournal(vm.snowCtx.Log, vm.vmDB, vm.actionCodec, vm.authCodec)
+	}
 
 	// Setup profiler
 	if cfg := vm.config.ContinuousProfilerConfig; cfg.Enabled {
@@ -321,14 +333,25 @@ func (vm *VM) Initialize(
 		if err != nil {
 			return err
 		}
+		numAPIs := len(options.vmAPIHandlerFactories)
 		opt.apply(options)
+		if err := vm.applyAPIMiddleware(Option.Namespace, config, options.vmAPIHandlerFactories[numAPIs:]); err != nil {
+			return fmt.Errorf("failed to apply %q api middleware: %w", Option.Namespace, err)
+		}
 	}
 	err = vm.applyOptions(options)
 	if err != nil {
 		return fmt.Errorf("failed to apply options : %w", err)
 	}
 
-	vm.chainTimeValidityWindow = validitywindow.NewTimeValidityWindow(vm.snowCtx.Log, vm.tracer, vm)
+	vm.chainTimeValidityWindow = validitywindow.NewTimeValidityWindow(
+		vm.snowCtx.Log,
+		vm.tracer,
+		vm,
+		func(timestamp int64) int64 {
+			return vm.ruleFactory.GetRules(timestamp).GetTimestampModulus()
+		},
+	)
 	registerer := prometheus.NewRegistry()
 	if err := vm.snowCtx.Metrics.Register("chain", registerer); err != nil {
 		return err
@@ -345,6 +368,7 @@ func (vm *VM) Initialize(
 		vm.AuthVerifiers(),
 		vm,
 		vm.chainTimeValidityWindow,
+		vm.buildPolicy,
 		vm.config.ChainConfig,
 	)
 	if err != nil {
@@ -480,9 +504,35 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	vm.privateTxs = gossiper.NewPrivate[*chain.Transaction](
+		vm,
+		vm.snowCtx.Log,
+		&chain.TxSerializer{
+			ActionRegistry: vm.actionCodec,
+			AuthRegistry:   vm.authCodec,
+		},
+		privateSubmitter{vm: vm},
+		vm.network.NewClient(privateTxHandlerID),
+		&vm.config.PrivateTxConfig,
+	)
+	if err := vm.network.AddHandler(privateTxHandlerID, vm.privateTxs); err != nil {
+		return err
+	}
+	if vm.pullGossiper != nil {
+		if err := vm.network.AddHandler(
+			txPullGossipHandlerID,
+			vm.pullGossiper.Handler(),
+		); err != nil {
+			return err
+		}
+	}
+
 	// Startup block builder and gossiper
 	go vm.builder.Run()
 	go vm.gossiper.Run(vm.network.NewClient(txGossipHandlerID))
+	if vm.pullGossiper != nil {
+		go vm.pullGossiper.Run(vm.network.NewClient(txPullGossipHandlerID), vm.stop)
+	}
 
 	// Wait until VM is ready and then send a state sync message to engine
 	go vm.markReady()
@@ -521,9 +571,27 @@ func (vm *VM) Initialize(
 func (vm *VM) applyOptions(o *Options) error {
 	vm.blockSubscriptionFactories = o.blockSubscriptionFactories
 	vm.vmAPIHandlerFactories = o.vmAPIHandlerFactories
-	if o.builder {
+	vm.buildPolicy = o.buildPolicy
+	switch {
+	case o.builder:
 		vm.builder = builder.NewManual(vm.toEngine, vm.snowCtx.Log)
-	} else {
+	case vm.config.AdaptiveBuilderConfig.Enabled:
+		builderRegistry := prometheus.NewRegistry()
+		if err := vm.snowCtx.Metrics.Register("builder", builderRegistry); err != nil {
+			return fmt.Errorf("failed to register builder metrics: %w", err)
+		}
+		adaptiveBuilder, err := builder.NewAdaptive(
+			vm.toEngine,
+			vm.snowCtx.Log,
+			builderRegistry,
+			&vm.config.AdaptiveBuilderConfig,
+			vm.getBuildState,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create adaptive builder: %w", err)
+		}
+		vm.builder = adaptiveBuilder
+	default:
 		vm.builder = builder.NewTime(vm.toEngine, vm.snowCtx.Log, vm.mempool, func(ctx context.Context, t int64) (int64, int64, error) {
 			blk, err := vm.GetStatefulBlock(ctx, vm.preferred)
 			if err != nil {
@@ -547,13 +615,20 @@ func (vm *VM) applyOptions(o *Options) error {
 				ActionRegistry: vm.actionCodec,
 				AuthRegistry:   vm.authCodec,
 			},
-			vm,
+			gossipSubmitter{vm: vm},
 			vm.config.TargetGossipDuration,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create manual gossiper: %w", err)
 		}
 	} else {
+		if vm.config.GossipConfig.PeerSelection == gossiper.PushPullPeerSelection && !vm.config.PullGossipConfig.Enabled {
+			return fmt.Errorf("%w: %s peer selection requires pull gossip", ErrPullGossipDisabled, gossiper.PushPullPeerSelection)
+		}
+		peerSelector, err := gossiper.NewPeerSelector(&vm.config.GossipConfig, vm)
+		if err != nil {
+			return err
+		}
 		txGossiper, err := gossiper.NewProposer[*chain.Transaction](
 			vm.tracer,
 			vm.snowCtx.Log,
@@ -563,20 +638,125 @@ func (vm *VM) applyOptions(o *Options) error {
 				ActionRegistry: vm.actionCodec,
 				AuthRegistry:   vm.authCodec,
 			},
+			gossipSubmitter{vm: vm},
 			vm,
-			vm,
+			peerSelector,
 			vm.config.TargetGossipDuration,
-			gossiper.DefaultProposerConfig(),
+			&vm.config.GossipConfig,
 			vm.stop,
 		)
 		if err != nil {
 			return err
 		}
 		vm.gossiper = txGossiper
+
+		if vm.config.PullGossipConfig.Enabled {
+			vm.pullGossiper, err = gossiper.NewPull[*chain.Transaction](
+				vm.snowCtx.Log,
+				gossipRegistry,
+				vm.mempool,
+				&chain.TxSerializer{
+					ActionRegistry: vm.actionCodec,
+					AuthRegistry:   vm.authCodec,
+				},
+				gossipSubmitter{vm: vm},
+				&vm.config.PullGossipConfig,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create pull gossiper: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyAPIMiddleware puts the handlers created by [factories], which were
+// registered by the option of [namespace], behind the middleware configured
+// in [serviceConfig]
+func (vm *VM) applyAPIMiddleware(namespace string, serviceConfig []byte, factories []api.HandlerFactory[api.VM]) error {
+	if len(factories) == 0 {
+		return nil
+	}
+	config, err := middleware.ParseConfig(serviceConfig)
+	if err != nil {
+		return err
+	}
+	if !config.Enabled {
+		return nil
+	}
+	if vm.apiMiddlewareMetrics == nil {
+		middlewareRegistry := prometheus.NewRegistry()
+		if err := vm.snowCtx.Metrics.Register("middleware", middlewareRegistry); err != nil {
+			return fmt.Errorf("failed to register middleware metrics: %w", err)
+		}
+		vm.apiMiddlewareMetrics, err = middleware.NewMetrics(middlewareRegistry)
+		if err != nil {
+			return err
+		}
+	}
+	m, err := middleware.New(namespace, config, vm.apiMiddlewareMetrics)
+	if err != nil {
+		return err
+	}
+	for i, factory := range factories {
+		factories[i] = &middlewareHandlerFactory{
+			factory:    factory,
+			middleware: m,
+		}
 	}
 	return nil
 }
 
+type middlewareHandlerFactory struct {
+	factory    api.HandlerFactory[api.VM]
+	middleware *middleware.Middleware
+}
+
+func (m *middlewareHandlerFactory) New(vm api.VM) (api.Handler, error) {
+	handler, err := m.factory.New(vm)
+	if err != nil {
+		return api.Handler{}, err
+	}
+	handler.Handler = m.middleware.Wrap(handler.Handler)
+	return handler, nil
+}
+
+// getBuildState returns the preferred block and the txs pending in the
+// mempool for the adaptive builder. Pending units and fees are only counted
+// until they exceed the max units of a block.
+func (vm *VM) getBuildState(ctx context.Context, now int64) (*builder.BuildState, error) {
+	blk, err := vm.GetStatefulBlock(ctx, vm.preferred)
+	if err != nil {
+		return nil, err
+	}
+	r := vm.ruleFactory.GetRules(now)
+	state := &builder.BuildState{
+		PreferredTimestamp: blk.Tmstmp,
+		MinBlockGap:        r.GetMinBlockGap(),
+		MaxBlockUnits:      r.GetMaxBlockUnits(),
+		PendingTxs:         vm.mempool.Len(ctx),
+	}
+	vm.mempool.Iterate(ctx, func(tx *chain.Transaction) bool {
+		units, err := tx.Units(vm.balanceHandler, r)
+		if err != nil {
+			return true
+		}
+		full := !state.PendingUnits.CanAdd(units, state.MaxBlockUnits)
+		nextUnits, err := fees.Add(state.PendingUnits, units)
+		if err != nil {
+			return false
+		}
+		nextFees, err := math.Add(state.PendingFees, tx.MaxFee())
+		if err != nil {
+			return false
+		}
+		state.PendingUnits = nextUnits
+		state.PendingFees = nextFees
+		return !full
+	})
+	return state, nil
+}
+
 func (vm *VM) checkActivity(ctx context.Context) {
 	vm.gossiper.Queue(ctx)
 	vm.builder.Queue(ctx)
@@ -597,6 +777,14 @@ func (vm *VM) markReady() {
 
 	// Wait for a full [ValidityWindow] before
 	// we are willing to vote on blocks.
+	//
+	// Chains that use nonce replay protection don't need to observe
+	// a [ValidityWindow] to detect duplicate transactions.
+	if vm.ruleFactory.GetRules(time.Now().UnixMilli()).GetNonceReplayProtection() {
+		vm.seenValidityWindowOnce.Do(func() {
+			close(vm.seenValidityWindow)
+		})
+	}
 	select {
 	case <-vm.stop:
 		return
@@ -613,6 +801,15 @@ func (vm *VM) markReady() {
 		"node is now ready",
 		zap.Bool("synced", vm.StateSyncClient.Started()),
 	)
+	if vm.journal != nil {
+		// Journaled txs are already on-disk, so they are not journaled again
+		submit := func(ctx context.Context, txs []*chain.Transaction) []error {
+			return vm.submit(ctx, txs, false, false, false)
+		}
+		if err := vm.journal.Restore(context.TODO(), vm.lastAccepted.Tmstmp, submit); err != nil {
+			vm.snowCtx.Log.Error("unable to restore mempool", zap.Error(err))
+		}
+	}
 	vm.checkActivity(context.TODO())
 }
 
@@ -732,6 +929,9 @@ func (vm *VM) Shutdown(context.Context) error {
 	// Shutdown other async VM mechanisms
 	vm.builder.Done()
 	vm.gossiper.Done()
+	if vm.pullGossiper != nil {
+		vm.pullGossiper.Done()
+	}
 	vm.authVerifiers.Stop()
 	if vm.profiler != nil {
 		vm.profiler.Shutdown()
@@ -952,6 +1152,73 @@ func (vm *VM) BuildBlock(ctx context.Context) (snowman.Block, error) {
 func (vm *VM) Submit(
 	ctx context.Context,
 	txs []*chain.Transaction,
+) (errs []error) {
+	return vm.submit(ctx, txs, false, false, true)
+}
+
+// SubmitBundle adds the members of a bundle to the mempool. Unlike [VM.Submit],
+// no member is added unless every member is valid, so a bundle is never
+// partially added. Members that were valid but not added fail with
+// [ErrNotAdded].
+func (vm *VM) SubmitBundle(
+	ctx context.Context,
+	txs []*chain.Transaction,
+) (errs []error) {
+	return vm.submit(ctx, txs, false, true, true)
+}
+
+// SubmitPrivate adds [txs] to the private lane of the mempool and forwards them
+// to the peers in [Config.PrivateTxConfig]. Private txs are never gossiped, so
+// they can only be included by this node or by those peers.
+func (vm *VM) SubmitPrivate(
+	ctx context.Context,
+	txs []*chain.Transaction,
+) (errs []error) {
+	errs = vm.submit(ctx, txs, true, false, false)
+	if len(errs) != len(txs) {
+		return errs
+	}
+	validTxs := make([]*chain.Transaction, 0, len(txs))
+	for i, err := range errs {
+		if err == nil {
+			validTxs = append(validTxs, txs[i])
+		}
+	}
+	if err := vm.privateTxs.Forward(ctx, validTxs); err != nil {
+		vm.snowCtx.Log.Warn("unable to forward private txs", zap.Error(err))
+	}
+	return errs
+}
+
+// privateSubmitter adds private txs received from peers to the private lane
+// of the mempool without forwarding them again
+type privateSubmitter struct {
+	vm *VM
+}
+
+func (p privateSubmitter) Submit(ctx context.Context, txs []*chain.Transaction) []error {
+	return p.vm.submit(ctx, txs, true, false, false)
+}
+
+// gossipSubmitter adds txs received over gossip to the mempool without
+// journaling them
+type gossipSubmitter struct {
+	vm *VM
+}
+
+func (g gossipSubmitter) Submit(ctx context.Context, txs []*chain.Transaction) []error {
+	return g.vm.submit(ctx, txs, false, false, false)
+}
+
+// submit verifies [txs] and adds the valid ones to the mempool. If [atomic] is
+// true, no tx is added unless every tx is valid. If [journal] is true, public
+// txs are journaled so they are restored after a restart.
+func (vm *VM) submit(
+	ctx context.Context,
+	txs []*chain.Transaction,
+	private bool,
+	atomic bool,
+	journal bool,
 ) (errs []error) {
 	ctx, span := vm.tracer.Start(ctx, "VM.Submit")
 	defer span.End()
@@ -993,7 +1260,27 @@ func (vm *VM) Submit(
 		errs = append(errs, nil)
 		validTxs = append(validTxs, tx)
 	}
-	vm.mempool.Add(ctx, validTxs)
+	if atomic && len(validTxs) != len(txs) {
+		for i, err := range errs {
+			if err == nil {
+				errs[i] = ErrNotAdded
+			}
+		}
+		return errs
+	}
+	switch {
+	case private:
+		// Private txs are not journaled because they would be restored as
+		// public txs
+		vm.mempool.AddPrivate(ctx, validTxs)
+	default:
+		vm.mempool.Add(ctx, validTxs)
+		if journal && vm.journal != nil {
+			if err := vm.journal.Add(validTxs); err != nil {
+				vm.snowCtx.Log.Warn("unable to journal mempool txs", zap.Error(err))
+			}
+		}
+	}
 	vm.checkActivity(ctx)
 	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
 	return errs
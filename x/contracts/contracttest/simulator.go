@@ -0,0 +1,275 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package contracttest runs WASM contracts against in-memory state so they can
+// be tested with go test. Helpers return errors instead of failing the test so
+// they can be used with any assertion library.
+//
+// Contracts tested from Rust keep using the simulator crate in
+// x/contracts/simulator.
+package contracttest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/x/contracts/runtime"
+)
+
+// DefaultFuel is the fuel available to calls made with Call
+const DefaultFuel = 10_000_000
+
+var (
+	ErrUnexpectedResult      = errors.New("unexpected result")
+	ErrStateNotChanged       = errors.New("state not changed")
+	ErrUnexpectedStateChange = errors.New("unexpected state change")
+)
+
+// Simulator deploys and calls contracts against in-memory state
+type Simulator struct {
+	runtime *runtime.WasmRuntime
+	state   *StateManager
+
+	actor        codec.Address
+	height       uint64
+	timestamp    uint64
+	fuelSchedule *runtime.FuelSchedule
+	callLimits   *runtime.CallLimits
+}
+
+func NewSimulator() *Simulator {
+	return NewSimulatorWithConfig(runtime.NewConfig())
+}
+
+func NewSimulatorWithConfig(cfg *runtime.Config) *Simulator {
+	return &Simulator{
+		runtime: runtime.NewRuntime(cfg, logging.NoLog{}),
+		state:   NewStateManager(),
+	}
+}
+
+// State returns the state contracts are executed against
+func (s *Simulator) State() *StateManager {
+	return s.state
+}
+
+// Actor returns the account calls are made from
+func (s *Simulator) Actor() codec.Address {
+	return s.actor
+}
+
+func (s *Simulator) SetActor(actor codec.Address) {
+	s.actor = actor
+}
+
+func (s *Simulator) Height() uint64 {
+	return s.height
+}
+
+func (s *Simulator) SetHeight(height uint64) {
+	s.height = height
+}
+
+// AdvanceHeight increases the height of the chain by [blocks]
+func (s *Simulator) AdvanceHeight(blocks uint64) {
+	s.height += blocks
+}
+
+func (s *Simulator) Timestamp() uint64 {
+	return s.timestamp
+}
+
+func (s *Simulator) SetTimestamp(timestamp uint64) {
+	s.timestamp = timestamp
+}
+
+// AdvanceTimestamp increases the timestamp of the chain by [duration]
+func (s *Simulator) AdvanceTimestamp(duration uint64) {
+	s.timestamp += duration
+}
+
+// SetFuelSchedule sets the fuel charged for execution. DefaultFuelSchedule is
+// used if [schedule] is nil.
+func (s *Simulator) SetFuelSchedule(schedule *runtime.FuelSchedule) {
+	s.fuelSchedule = schedule
+}
+
+// SetCallLimits sets the limits of nested calls. DefaultCallLimits is used if
+// [limits] is nil.
+func (s *Simulator) SetCallLimits(limits *runtime.CallLimits) {
+	s.callLimits = limits
+}
+
+func (s *Simulator) GetBalance(ctx context.Context, account codec.Address) (uint64, error) {
+	return s.state.GetBalance(ctx, account)
+}
+
+func (s *Simulator) SetBalance(ctx context.Context, account codec.Address, balance uint64) error {
+	if err := s.state.SetBalance(ctx, account, balance); err != nil {
+		return err
+	}
+	s.state.Commit()
+	return nil
+}
+
+// Publish stores [contractBytes] and returns its ContractID
+func (s *Simulator) Publish(ctx context.Context, contractBytes []byte) (runtime.ContractID, error) {
+	id := sha256.Sum256(contractBytes)
+	contractID := runtime.ContractID(id[:])
+	if err := s.state.SetContractBytes(ctx, contractID, contractBytes); err != nil {
+		return nil, err
+	}
+	s.state.Commit()
+	return contractID, nil
+}
+
+// Deploy publishes [contractBytes] and creates a new account with it
+func (s *Simulator) Deploy(ctx context.Context, contractBytes []byte, accountCreationData []byte) (codec.Address, error) {
+	contractID, err := s.Publish(ctx, contractBytes)
+	if err != nil {
+		return codec.EmptyAddress, err
+	}
+	account, err := s.state.NewAccountWithContract(ctx, contractID, accountCreationData)
+	if err != nil {
+		return codec.EmptyAddress, err
+	}
+	s.state.Commit()
+	return account, nil
+}
+
+// DeployFile deploys the compiled .wasm contract at [path]
+func (s *Simulator) DeployFile(ctx context.Context, path string, accountCreationData []byte) (codec.Address, error) {
+	contractBytes, err := os.ReadFile(path)
+	if err != nil {
+		return codec.EmptyAddress, err
+	}
+	return s.Deploy(ctx, contractBytes, accountCreationData)
+}
+
+// CallResult is the outcome of a contract call
+type CallResult struct {
+	// Value is the serialized value returned by the contract
+	Value []byte
+	// ConsumedFuel is the fuel consumed by the call
+	ConsumedFuel uint64
+	// StateChanges are the changes made to the state of contracts, empty if
+	// the call failed
+	StateChanges []StateChange
+	Err          error
+}
+
+// StateChange returns the change made to [key] of [contract]
+func (r *CallResult) StateChange(contract codec.Address, key []byte) (StateChange, bool) {
+	return findChange(r.StateChanges, contract, key)
+}
+
+// Call calls [function] of [contract] with [args] serialized as its
+// parameters
+func (s *Simulator) Call(ctx context.Context, contract codec.Address, function string, args ...any) *CallResult {
+	return s.CallWithValue(ctx, contract, function, 0, DefaultFuel, args...)
+}
+
+// CallWithValue calls [function] of [contract] transferring [value] to the
+// contract and limiting execution to [fuel]. If the call fails, its changes
+// are reverted.
+func (s *Simulator) CallWithValue(
+	ctx context.Context,
+	contract codec.Address,
+	function string,
+	value uint64,
+	fuel uint64,
+	args ...any,
+) *CallResult {
+	params, err := serializeArgs(args)
+	if err != nil {
+		return &CallResult{Err: err}
+	}
+
+	callInfo := &runtime.CallInfo{
+		State:        s.state,
+		Actor:        s.actor,
+		FunctionName: function,
+		Contract:     contract,
+		Params:       params,
+		Fuel:         fuel,
+		Height:       s.height,
+		Timestamp:    s.timestamp,
+		ActionID:     ids.Empty,
+		Value:        value,
+		FuelSchedule: s.fuelSchedule,
+		CallLimits:   s.callLimits,
+	}
+	resultBytes, err := s.runtime.CallContract(ctx, callInfo)
+	result := &CallResult{
+		Value:        resultBytes,
+		ConsumedFuel: fuel - callInfo.RemainingFuel(),
+		Err:          err,
+	}
+	if err != nil {
+		if revertErr := s.state.Revert(ctx); revertErr != nil {
+			result.Err = revertErr
+		}
+		return result
+	}
+	result.StateChanges = s.state.Commit()
+	return result
+}
+
+func serializeArgs(args []any) ([]byte, error) {
+	params := []byte{}
+	for _, arg := range args {
+		argBytes, err := runtime.Serialize(arg)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, argBytes...)
+	}
+	return params, nil
+}
+
+// Into deserializes the value returned by a successful call
+func Into[T any](result *CallResult) (T, error) {
+	if result.Err != nil {
+		return *new(T), result.Err
+	}
+	value, err := runtime.Deserialize[T](result.Value)
+	if err != nil {
+		return *new(T), err
+	}
+	return *value, nil
+}
+
+// CheckResult returns an error unless the call succeeded and returned
+// [expected]
+func CheckResult[T any](result *CallResult, expected T) error {
+	value, err := Into[T](result)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected, value) {
+		return fmt.Errorf("%w: expected %v, got %v", ErrUnexpectedResult, expected, value)
+	}
+	return nil
+}
+
+// CheckStateChange returns an error unless the call changed [key] of
+// [contract] to [value]. A nil [value] requires that the key was removed.
+func CheckStateChange(result *CallResult, contract codec.Address, key []byte, value []byte) error {
+	change, ok := result.StateChange(contract, key)
+	if !ok {
+		return fmt.Errorf("%w: state of %s at %x", ErrStateNotChanged, contract, key)
+	}
+	if (value == nil) != (change.Value == nil) || !bytes.Equal(value, change.Value) {
+		return fmt.Errorf("%w: state of %s at %x is %x, expected %x", ErrUnexpectedStateChange, contract, key, change.Value, value)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contracttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/bytecodealliance/wasmtime-go/v25"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// testContract returns 42 from "answer", stores "v" at "k" from "store" and
+// stores "v" at "k" before trapping from "fail"
+const testContract = `
+(module
+  (import "contract" "set_call_result" (func $set_call_result (param i32 i32)))
+  (import "state" "put" (func $put (param i32 i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 16) "\2a\00\00\00\00\00\00\00")
+  (data (i32.const 32) "\01\00\00\00\01\00\00\00k\01\00\00\00v")
+  (func (export "alloc") (param i32) (result i32)
+    i32.const 1024)
+  (func (export "answer") (param i32)
+    (call $set_call_result (i32.const 16) (i32.const 8)))
+  (func (export "store") (param i32)
+    (call $put (i32.const 32) (i32.const 14)))
+  (func (export "fail") (param i32)
+    (call $put (i32.const 32) (i32.const 14))
+    unreachable))
+`
+
+func deployTestContract(t *testing.T, simulator *Simulator) codec.Address {
+	contractBytes, err := wasmtime.Wat2Wasm(testContract)
+	require.NoError(t, err)
+
+	contract, err := simulator.Deploy(context.Background(), contractBytes, []byte{})
+	require.NoError(t, err)
+	return contract
+}
+
+func TestSimulatorCall(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	simulator := NewSimulator()
+	contract := deployTestContract(t, simulator)
+
+	result := simulator.Call(ctx, contract, "answer")
+	require.NoError(CheckResult(result, int64(42)))
+	require.ErrorIs(CheckResult(result, int64(41)), ErrUnexpectedResult)
+	require.Positive(result.ConsumedFuel)
+	require.Empty(result.StateChanges)
+
+	result = simulator.Call(ctx, contract, "missing")
+	require.Error(result.Err)
+}
+
+func TestSimulatorStateChanges(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	simulator := NewSimulator()
+	contract := deployTestContract(t, simulator)
+
+	result := simulator.Call(ctx, contract, "fail")
+	require.Error(result.Err)
+	require.Empty(result.StateChanges)
+
+	value, err := simulator.State().GetContractState(contract).GetValue(ctx, []byte("k"))
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Nil(value)
+
+	result = simulator.Call(ctx, contract, "store")
+	require.NoError(result.Err)
+	require.NoError(CheckStateChange(result, contract, []byte("k"), []byte("v")))
+	require.ErrorIs(CheckStateChange(result, contract, []byte("k"), nil), ErrUnexpectedStateChange)
+	require.ErrorIs(CheckStateChange(result, contract, []byte("other"), []byte("v")), ErrStateNotChanged)
+
+	change, ok := result.StateChange(contract, []byte("k"))
+	require.True(ok)
+	require.Nil(change.Previous)
+}
+
+func TestSimulatorBalances(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	simulator := NewSimulator()
+	contract := deployTestContract(t, simulator)
+	actor := codec.CreateAddress(0, ids.GenerateTestID())
+	simulator.SetActor(actor)
+	require.NoError(simulator.SetBalance(ctx, actor, 100))
+
+	result := simulator.CallWithValue(ctx, contract, "answer", 150, DefaultFuel)
+	require.Error(result.Err)
+
+	result = simulator.CallWithValue(ctx, contract, "answer", 40, DefaultFuel)
+	require.NoError(result.Err)
+
+	balance, err := simulator.GetBalance(ctx, actor)
+	require.NoError(err)
+	require.Equal(uint64(60), balance)
+	balance, err = simulator.GetBalance(ctx, contract)
+	require.NoError(err)
+	require.Equal(uint64(40), balance)
+}
+
+func TestSimulatorAdvance(t *testing.T) {
+	require := require.New(t)
+
+	simulator := NewSimulator()
+	simulator.SetHeight(10)
+	simulator.AdvanceHeight(5)
+	require.Equal(uint64(15), simulator.Height())
+
+	simulator.SetTimestamp(1000)
+	simulator.AdvanceTimestamp(500)
+	require.Equal(uint64(1500), simulator.Timestamp())
+}
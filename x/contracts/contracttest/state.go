@@ -0,0 +1,290 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package contracttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/runtime"
+	"github.com/ava-labs/hypersdk/x/contracts/test"
+)
+
+var (
+	_ runtime.StateManager   = (*StateManager)(nil)
+	_ runtime.PrefixIterable = (*journaledState)(nil)
+	_ runtime.PrefixIterable = (*recordingState)(nil)
+
+	ErrInsufficientBalance = errors.New("insufficient balance")
+
+	balancePrefix  = []byte{0x0}
+	contractPrefix = []byte{0x1}
+)
+
+// StateChange is a change made to the state of a contract
+type StateChange struct {
+	Contract codec.Address
+	Key      []byte
+	// Previous is the value before the change, nil if the key did not exist
+	Previous []byte
+	// Value is the value after the change, nil if the key was removed
+	Value []byte
+}
+
+// StateManager is an in-memory runtime.StateManager. Changes made since the
+// last call to Commit can be reverted and the changes made to the state of
+// contracts are recorded.
+type StateManager struct {
+	db        *journaledState
+	contracts *runtime.ContractStateManager
+
+	changes      []StateChange
+	changedIndex map[string]int
+}
+
+func NewStateManager() *StateManager {
+	db := &journaledState{db: test.NewTestDB()}
+	return &StateManager{
+		db:           db,
+		contracts:    runtime.NewContractStateManager(db, contractPrefix),
+		changedIndex: map[string]int{},
+	}
+}
+
+func (s *StateManager) GetBalance(ctx context.Context, address codec.Address) (uint64, error) {
+	value, err := s.db.GetValue(ctx, balanceKey(address))
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+func (s *StateManager) SetBalance(ctx context.Context, address codec.Address, amount uint64) error {
+	return s.db.Insert(ctx, balanceKey(address), binary.BigEndian.AppendUint64(nil, amount))
+}
+
+func (s *StateManager) TransferBalance(ctx context.Context, from codec.Address, to codec.Address, amount uint64) error {
+	fromBalance, err := s.GetBalance(ctx, from)
+	if err != nil {
+		return err
+	}
+	if fromBalance < amount {
+		return ErrInsufficientBalance
+	}
+	if err := s.SetBalance(ctx, from, fromBalance-amount); err != nil {
+		return err
+	}
+	toBalance, err := s.GetBalance(ctx, to)
+	if err != nil {
+		return err
+	}
+	return s.SetBalance(ctx, to, toBalance+amount)
+}
+
+func (s *StateManager) GetContractState(address codec.Address) state.Mutable {
+	return &recordingState{
+		Mutable:  s.contracts.GetContractState(address),
+		contract: address,
+		manager:  s,
+	}
+}
+
+func (s *StateManager) GetAccountContract(ctx context.Context, account codec.Address) (runtime.ContractID, error) {
+	return s.contracts.GetAccountContract(ctx, account)
+}
+
+func (s *StateManager) GetContractBytes(ctx context.Context, contractID runtime.ContractID) ([]byte, error) {
+	return s.contracts.GetContractBytes(ctx, contractID)
+}
+
+func (s *StateManager) NewAccountWithContract(ctx context.Context, contractID runtime.ContractID, accountCreationData []byte) (codec.Address, error) {
+	return s.contracts.NewAccountWithContract(ctx, contractID, accountCreationData)
+}
+
+func (s *StateManager) SetAccountContract(ctx context.Context, account codec.Address, contractID runtime.ContractID) error {
+	return s.contracts.SetAccountContract(ctx, account, contractID)
+}
+
+func (s *StateManager) SetContractBytes(ctx context.Context, contractID runtime.ContractID, contractBytes []byte) error {
+	return s.contracts.SetContractBytes(ctx, contractID, contractBytes)
+}
+
+func (s *StateManager) SetAccountImmutable(ctx context.Context, account codec.Address) error {
+	return s.contracts.SetAccountImmutable(ctx, account)
+}
+
+func (s *StateManager) IsAccountImmutable(ctx context.Context, account codec.Address) (bool, error) {
+	return s.contracts.IsAccountImmutable(ctx, account)
+}
+
+// Commit keeps the changes made since the last commit and returns the changes
+// made to the state of contracts
+func (s *StateManager) Commit() []StateChange {
+	changes := s.changes
+	s.db.journal = nil
+	s.changes = nil
+	s.changedIndex = map[string]int{}
+	return changes
+}
+
+// Revert discards the changes made since the last commit
+func (s *StateManager) Revert(ctx context.Context) error {
+	if err := s.db.revert(ctx); err != nil {
+		return err
+	}
+	s.changes = nil
+	s.changedIndex = map[string]int{}
+	return nil
+}
+
+// recordChange records [key] of [contract] changing from [previous] to
+// [value]. Multiple changes to the same key are merged.
+func (s *StateManager) recordChange(contract codec.Address, key []byte, previous []byte, value []byte) {
+	changedKey := string(contract[:]) + string(key)
+	if i, ok := s.changedIndex[changedKey]; ok {
+		s.changes[i].Value = slices.Clone(value)
+		return
+	}
+	s.changedIndex[changedKey] = len(s.changes)
+	s.changes = append(s.changes, StateChange{
+		Contract: contract,
+		Key:      slices.Clone(key),
+		Previous: slices.Clone(previous),
+		Value:    slices.Clone(value),
+	})
+}
+
+func balanceKey(address codec.Address) []byte {
+	return append(slices.Clone(balancePrefix), address[:]...)
+}
+
+// recordingState records the changes made to the state of a contract
+type recordingState struct {
+	state.Mutable
+	contract codec.Address
+	manager  *StateManager
+}
+
+func (r *recordingState) Insert(ctx context.Context, key []byte, value []byte) error {
+	previous, err := r.previous(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := r.Mutable.Insert(ctx, key, value); err != nil {
+		return err
+	}
+	r.manager.recordChange(r.contract, key, previous, value)
+	return nil
+}
+
+func (r *recordingState) Remove(ctx context.Context, key []byte) error {
+	previous, err := r.previous(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := r.Mutable.Remove(ctx, key); err != nil {
+		return err
+	}
+	if previous != nil {
+		r.manager.recordChange(r.contract, key, previous, nil)
+	}
+	return nil
+}
+
+func (r *recordingState) NewIteratorWithPrefix(ctx context.Context, prefix []byte) (database.Iterator, error) {
+	return r.Mutable.(runtime.PrefixIterable).NewIteratorWithPrefix(ctx, prefix)
+}
+
+func (r *recordingState) previous(ctx context.Context, key []byte) ([]byte, error) {
+	value, err := r.Mutable.GetValue(ctx, key)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	return value, err
+}
+
+type journalEntry struct {
+	key    []byte
+	value  []byte
+	exists bool
+}
+
+// journaledState records the previous values of changed keys so they can be
+// reverted
+type journaledState struct {
+	db      *test.DB
+	journal []journalEntry
+}
+
+func (j *journaledState) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	return j.db.GetValue(ctx, key)
+}
+
+func (j *journaledState) Insert(ctx context.Context, key []byte, value []byte) error {
+	if err := j.record(ctx, key); err != nil {
+		return err
+	}
+	return j.db.Insert(ctx, key, value)
+}
+
+func (j *journaledState) Remove(ctx context.Context, key []byte) error {
+	if err := j.record(ctx, key); err != nil {
+		return err
+	}
+	return j.db.Remove(ctx, key)
+}
+
+func (j *journaledState) NewIteratorWithPrefix(ctx context.Context, prefix []byte) (database.Iterator, error) {
+	return j.db.NewIteratorWithPrefix(ctx, prefix)
+}
+
+func (j *journaledState) record(ctx context.Context, key []byte) error {
+	value, err := j.db.GetValue(ctx, key)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	j.journal = append(j.journal, journalEntry{
+		key:    slices.Clone(key),
+		value:  value,
+		exists: err == nil,
+	})
+	return nil
+}
+
+func (j *journaledState) revert(ctx context.Context) error {
+	for i := len(j.journal) - 1; i >= 0; i-- {
+		entry := j.journal[i]
+		var err error
+		if entry.exists {
+			err = j.db.Insert(ctx, entry.key, entry.value)
+		} else {
+			err = j.db.Remove(ctx, entry.key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	j.journal = nil
+	return nil
+}
+
+// findChange returns the change made to [key] of [contract]
+func findChange(changes []StateChange, contract codec.Address, key []byte) (StateChange, bool) {
+	for _, change := range changes {
+		if change.Contract == contract && bytes.Equal(change.Key, key) {
+			return change, true
+		}
+	}
+	return StateChange{}, false
+}
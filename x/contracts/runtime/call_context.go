@@ -50,6 +50,18 @@ func (c CallContext) CallContract(ctx context.Context, info *CallInfo) ([]byte,
 	return c.r.CallContract(ctx, newInfo)
 }
 
+// CallContractWithProfile calls a contract with profiling enabled and returns
+// how the call consumed its fuel
+func (c CallContext) CallContractWithProfile(ctx context.Context, info *CallInfo) ([]byte, *CallProfile, error) {
+	newInfo, err := c.createCallInfo(info)
+	if err != nil {
+		return nil, nil, err
+	}
+	newInfo.profile = newCallProfile(newInfo.Contract, newInfo.FunctionName)
+	result, err := c.r.CallContract(ctx, newInfo)
+	return result, newInfo.profile, err
+}
+
 func (c CallContext) WithStateManager(manager StateManager) CallContext {
 	c.defaultCallInfo.State = manager
 	return c
@@ -99,3 +111,13 @@ func (c CallContext) WithValue(value uint64) CallContext {
 	c.defaultCallInfo.Value = value
 	return c
 }
+
+func (c CallContext) WithFuelSchedule(schedule *FuelSchedule) CallContext {
+	c.defaultCallInfo.FuelSchedule = schedule
+	return c
+}
+
+func (c CallContext) WithCallLimits(limits *CallLimits) CallContext {
+	c.defaultCallInfo.CallLimits = limits
+	return c
+}
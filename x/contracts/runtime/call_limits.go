@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bytecodealliance/wasmtime-go/v25"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// CallLimitsKey is the key of the CallLimits in the custom chain rules
+const CallLimitsKey = "contracts.callLimits"
+
+// NonReentrantExport is the function exported by contracts that cannot be
+// called while they are executing further up the call stack. It is exported
+// by the wasmlanche nonreentrant! macro.
+const NonReentrantExport = "__wasmlanche_nonreentrant"
+
+const defaultMaxCallDepth = 16
+
+var (
+	ErrInvalidCallLimits = errors.New("invalid call limits")
+	ErrReentrantCall     = errors.New("reentrant call")
+
+	defaultCallLimits = DefaultCallLimits()
+)
+
+// CallLimits limits the contract calls nested in a top-level call. They are
+// loaded from the chain rules so every node enforces the same limits.
+type CallLimits struct {
+	// MaxCallDepth is the maximum number of nested contract calls made from a
+	// single top-level call. Calls exceeding it fail with CallDepthExceeded.
+	MaxCallDepth uint32 `json:"maxCallDepth"`
+}
+
+func DefaultCallLimits() *CallLimits {
+	return &CallLimits{
+		MaxCallDepth: defaultMaxCallDepth,
+	}
+}
+
+// GetCallLimits returns the CallLimits defined in [rules] or the default
+// limits if [rules] does not define them. The limits may be provided as a
+// CallLimits or as its JSON encoding.
+func GetCallLimits(rules CustomRules) (*CallLimits, error) {
+	if rules == nil {
+		return DefaultCallLimits(), nil
+	}
+	value, ok := rules.FetchCustom(CallLimitsKey)
+	if !ok {
+		return DefaultCallLimits(), nil
+	}
+
+	var limits *CallLimits
+	switch v := value.(type) {
+	case *CallLimits:
+		limits = v
+	case CallLimits:
+		limits = &v
+	case json.RawMessage:
+		limits = DefaultCallLimits()
+		if err := json.Unmarshal(v, limits); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCallLimits, err)
+		}
+	case []byte:
+		limits = DefaultCallLimits()
+		if err := json.Unmarshal(v, limits); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCallLimits, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unexpected type %T", ErrInvalidCallLimits, value)
+	}
+	return limits, nil
+}
+
+// isNonReentrant returns true if [contractModule] cannot be called while it is
+// executing further up the call stack
+func isNonReentrant(contractModule *wasmtime.Module) bool {
+	for _, export := range contractModule.Exports() {
+		if export.Name() == NonReentrantExport {
+			return true
+		}
+	}
+	return false
+}
+
+// isExecuting returns true if [contract] is executing in [c] or any call
+// further up its call stack
+func (c *CallInfo) isExecuting(contract codec.Address) bool {
+	for caller := c; caller != nil; caller = caller.caller {
+		if caller.Contract == contract {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/genesis"
+)
+
+func TestGetCallLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		custom         map[string]json.RawMessage
+		expectedLimits *CallLimits
+		expectedErr    error
+	}{
+		{
+			name:           "default limits",
+			expectedLimits: DefaultCallLimits(),
+		},
+		{
+			name: "custom limits",
+			custom: map[string]json.RawMessage{
+				CallLimitsKey: []byte(`{"maxCallDepth":4}`),
+			},
+			expectedLimits: &CallLimits{MaxCallDepth: 4},
+		},
+		{
+			name: "invalid json",
+			custom: map[string]json.RawMessage{
+				CallLimitsKey: []byte(`{`),
+			},
+			expectedErr: ErrInvalidCallLimits,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			rules := genesis.NewDefaultRules()
+			rules.Custom = tt.custom
+
+			limits, err := GetCallLimits(rules)
+			r.ErrorIs(err, tt.expectedErr)
+			r.Equal(tt.expectedLimits, limits)
+		})
+	}
+}
@@ -25,6 +25,10 @@ var (
 	DefaultProfilingStrategy    = wasmtime.ProfilingStrategyNone
 	DefaultMultiValue           = false
 
+	DefaultEnableFuelProfiling = false
+	DefaultModuleCacheSize     = 256 * units.MiB
+	DefaultPrecompileOnPublish = false
+
 	defaultContractCacheSize            = 10 * units.MiB
 	defaultWasmThreads                  = false
 	defaultFuelMetering                 = true
@@ -41,8 +45,11 @@ var (
 // NewConfig creates a new engine config with default settings
 func NewConfig() *Config {
 	return &Config{
-		wasmConfig:        DefaultWasmtimeConfig(),
-		ContractCacheSize: defaultContractCacheSize,
+		wasmConfig:          DefaultWasmtimeConfig(),
+		ContractCacheSize:   defaultContractCacheSize,
+		EnableFuelProfiling: DefaultEnableFuelProfiling,
+		ModuleCacheSize:     DefaultModuleCacheSize,
+		PrecompileOnPublish: DefaultPrecompileOnPublish,
 	}
 }
 
@@ -54,6 +61,21 @@ type Config struct {
 	CompileStrategy CompileStrategy `json:"compileStrategy,omitempty" yaml:"compile_strategy,omitempty"`
 
 	ContractCacheSize int
+
+	// EnableFuelProfiling records a CallProfile for every top-level call. It
+	// is read from CallInfo.Profile after the call.
+	EnableFuelProfiling bool
+
+	// ModuleCacheDir is the directory compiled modules are persisted to so
+	// they are not recompiled after a restart. Disabled if empty.
+	ModuleCacheDir string
+
+	// ModuleCacheSize is the maximum size in bytes of ModuleCacheDir.
+	ModuleCacheSize int
+
+	// PrecompileOnPublish compiles contracts in the background when they are
+	// published instead of when they are first called.
+	PrecompileOnPublish bool
 }
 
 // Get returns the underlying wasmtime config.
@@ -197,6 +219,9 @@ func NewConfigBuilder() *ConfigBuilder {
 		MaxWasmStack:             DefaultMaxWasmStack,
 		ProfilingStrategy:        DefaultProfilingStrategy,
 		EnableDefaultCache:       false,
+		EnableFuelProfiling:      DefaultEnableFuelProfiling,
+		ModuleCacheSize:          DefaultModuleCacheSize,
+		PrecompileOnPublish:      DefaultPrecompileOnPublish,
 	}
 }
 
@@ -240,6 +265,22 @@ type ConfigBuilder struct {
 	// ProfilingStrategy decides what sort of profiling to enable, if any.
 	// Default is `wasmtime.ProfilingStrategyNone`.
 	ProfilingStrategy wasmtime.ProfilingStrategy
+	// EnableFuelProfiling records, for every top-level call, the fuel consumed
+	// by each host function and nested call along with its wall time.
+	// This is false by default.
+	EnableFuelProfiling bool `json:"enableFuelProfiling,omitempty" yaml:"enable_fuel_profiling,omitempty"`
+	// ModuleCacheDir is the directory compiled modules are persisted to. Modules
+	// are keyed by contract ID, wasmtime version and engine configuration.
+	// The cache is disabled by default.
+	ModuleCacheDir string `json:"moduleCacheDir,omitempty" yaml:"module_cache_dir,omitempty"`
+	// ModuleCacheSize is the maximum size in bytes of ModuleCacheDir. The least
+	// recently used modules are removed once it is exceeded.
+	// This is 256 MiB by default.
+	ModuleCacheSize int `json:"moduleCacheSize,omitempty" yaml:"module_cache_size,omitempty"`
+	// PrecompileOnPublish compiles contracts in the background when they are
+	// published.
+	// This is false by default.
+	PrecompileOnPublish bool `json:"precompileOnPublish,omitempty" yaml:"precompile_on_publish,omitempty"`
 }
 
 // WithMaxWasmStack defines the maximum amount of stack space available for
@@ -299,6 +340,33 @@ func (c *ConfigBuilder) WithProfilingStrategy(strategy wasmtime.ProfilingStrateg
 	return c
 }
 
+// WithFuelProfiling records a CallProfile for every top-level call.
+//
+// Default is false.
+func (c *ConfigBuilder) WithFuelProfiling(enabled bool) *ConfigBuilder {
+	c.EnableFuelProfiling = enabled
+	return c
+}
+
+// WithModuleCache persists compiled modules to [dir], removing the least
+// recently used modules once it grows beyond [size] bytes.
+//
+// Default is disabled.
+func (c *ConfigBuilder) WithModuleCache(dir string, size int) *ConfigBuilder {
+	c.ModuleCacheDir = dir
+	c.ModuleCacheSize = size
+	return c
+}
+
+// WithPrecompileOnPublish compiles contracts in the background when they are
+// published.
+//
+// Default is false.
+func (c *ConfigBuilder) WithPrecompileOnPublish(enabled bool) *ConfigBuilder {
+	c.PrecompileOnPublish = enabled
+	return c
+}
+
 // WithDefaultCache enables the default caching strategy.
 //
 // Default is false.
@@ -315,6 +383,10 @@ func (c *ConfigBuilder) Build() (*Config, error) {
 	cfg.SetWasmSIMD(c.EnableWasmSIMD)
 	cfg.SetMaxWasmStack(c.MaxWasmStack)
 	cfg.SetProfiler(c.ProfilingStrategy)
+	cfg.EnableFuelProfiling = c.EnableFuelProfiling
+	cfg.ModuleCacheDir = c.ModuleCacheDir
+	cfg.ModuleCacheSize = c.ModuleCacheSize
+	cfg.PrecompileOnPublish = c.PrecompileOnPublish
 	if c.EnableDefaultCache {
 		if err := cfg.CacheConfigLoadDefault(); err != nil {
 			return nil, err
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/bytecodealliance/wasmtime-go/v25"
 
@@ -59,16 +60,62 @@ type CallInfo struct {
 
 	Value uint64
 
+	// the fuel charged for execution, DefaultFuelSchedule is used if nil
+	FuelSchedule *FuelSchedule
+
+	// the limits of nested calls, DefaultCallLimits is used if nil
+	CallLimits *CallLimits
+
 	inst *ContractInstance
+
+	// the call that made this call, nil for top-level calls
+	caller *CallInfo
+
+	// the number of calls above this call in the call stack
+	depth int
+
+	// upgrades made by this call and any nested calls
+	upgrades *[]ContractUpgrade
+
+	// the state iterators opened by this call
+	iterators []database.Iterator
+
+	// the profile of this call, nil if profiling is disabled
+	profile *CallProfile
+}
+
+// Profile returns how the call consumed its fuel, nil if the call was not
+// profiled
+func (c *CallInfo) Profile() *CallProfile {
+	return c.profile
+}
+
+func (c *CallInfo) fuelSchedule() *FuelSchedule {
+	if c.FuelSchedule == nil {
+		return defaultFuelSchedule
+	}
+	return c.FuelSchedule
+}
+
+func (c *CallInfo) callLimits() *CallLimits {
+	if c.CallLimits == nil {
+		return defaultCallLimits
+	}
+	return c.CallLimits
 }
 
 func (c *CallInfo) RemainingFuel() uint64 {
+	// the contract was never instantiated
+	if c.inst == nil {
+		return c.Fuel
+	}
+
 	remaining, err := c.inst.store.GetFuel()
 	if err != nil {
 		return c.Fuel
 	}
 
-	return remaining
+	return c.fuelSchedule().fromWasmFuel(remaining)
 }
 
 func (c *CallInfo) AddFuel(fuel uint64) {
@@ -78,28 +125,41 @@ func (c *CallInfo) AddFuel(fuel uint64) {
 		return
 	}
 
-	_ = c.inst.store.SetFuel(remaining + fuel)
+	_ = c.inst.store.SetFuel(remaining + c.fuelSchedule().toWasmFuel(fuel))
 }
 
 func (c *CallInfo) ConsumeFuel(fuel uint64) error {
-	remaining, err := c.inst.store.GetFuel()
-	if err != nil {
-		return err
-	}
-
+	remaining := c.RemainingFuel()
 	if remaining < fuel {
 		return errors.New("out of fuel")
 	}
 
-	err = c.inst.store.SetFuel(remaining - fuel)
+	return c.inst.store.SetFuel(c.fuelSchedule().toWasmFuel(remaining - fuel))
+}
+
+// consumeMemoryGrowth charges the fuel for the pages of linear memory grown
+// since the last time it was called
+func (c *CallInfo) consumeMemoryGrowth(storeLike wasmtime.Storelike) error {
+	memory := c.inst.inst.GetExport(storeLike, MemoryName).Memory()
+	pages := memory.Size(storeLike)
+	if pages <= c.inst.memoryPages {
+		return nil
+	}
 
-	return err
+	grownPages := pages - c.inst.memoryPages
+	c.inst.memoryPages = pages
+	fuel := grownPages * c.fuelSchedule().MemoryPageCost
+	c.profile.recordMemoryGrowth(fuel)
+	return c.ConsumeFuel(fuel)
 }
 
 type ContractInstance struct {
 	inst   *wasmtime.Instance
 	store  *wasmtime.Store
 	result []byte
+
+	// the number of pages of linear memory that have been charged for
+	memoryPages uint64
 }
 
 func (p *ContractInstance) call(ctx context.Context, callInfo *CallInfo) ([]byte, error) {
@@ -108,7 +168,7 @@ func (p *ContractInstance) call(ctx context.Context, callInfo *CallInfo) ([]byte
 		return nil, err
 	}
 
-	if err := p.store.SetFuel(remaining + callInfo.Fuel); err != nil {
+	if err := p.store.SetFuel(remaining + callInfo.fuelSchedule().toWasmFuel(callInfo.Fuel)); err != nil {
 		return nil, err
 	}
 
@@ -138,13 +198,18 @@ func (p *ContractInstance) call(ctx context.Context, callInfo *CallInfo) ([]byte
 		return nil, err
 	}
 
+	// the initial memory and the memory used by the params are not charged
+	p.memoryPages = p.inst.GetExport(p.store, MemoryName).Memory().Size(p.store)
+
 	function := p.inst.GetFunc(p.store, callInfo.FunctionName)
 	if function == nil {
 		return nil, errors.New("this function does not exist")
 	}
-	_, err = function.Call(p.store, paramsOffset)
+	if _, err := function.Call(p.store, paramsOffset); err != nil {
+		return p.result, err
+	}
 
-	return p.result, err
+	return p.result, callInfo.consumeMemoryGrowth(p.store)
 }
 
 func (p *ContractInstance) writeToMemory(data []byte) (int32, error) {
@@ -0,0 +1,138 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// FuelScheduleKey is the key of the FuelSchedule in the custom chain rules
+const FuelScheduleKey = "contracts.fuelSchedule"
+
+const (
+	defaultInstructionWeight  = 1
+	defaultFuelPerComputeUnit = 1000
+)
+
+var (
+	ErrInvalidFuelSchedule = errors.New("invalid fuel schedule")
+
+	defaultFuelSchedule = DefaultFuelSchedule()
+)
+
+// CustomRules provides the custom values of the chain rules
+type CustomRules interface {
+	FetchCustom(string) (any, bool)
+}
+
+// FuelSchedule defines the fuel charged for contract execution. It is loaded
+// from the chain rules so it can be changed through a rule upgrade.
+type FuelSchedule struct {
+	// HostFunctionCosts overrides the fuel cost of host functions, keyed by
+	// module name and then function name.
+	HostFunctionCosts map[string]map[string]uint64 `json:"hostFunctionCosts,omitempty"`
+
+	// MemoryPageCost is the fuel charged for each page of linear memory a
+	// contract grows during a call.
+	MemoryPageCost uint64 `json:"memoryPageCost"`
+
+	// InstructionWeight is the fuel charged for each unit of fuel consumed by
+	// wasm instructions.
+	InstructionWeight uint64 `json:"instructionWeight"`
+
+	// FuelPerComputeUnit is the amount of fuel that costs a single compute unit.
+	FuelPerComputeUnit uint64 `json:"fuelPerComputeUnit"`
+}
+
+func DefaultFuelSchedule() *FuelSchedule {
+	return &FuelSchedule{
+		InstructionWeight:  defaultInstructionWeight,
+		FuelPerComputeUnit: defaultFuelPerComputeUnit,
+	}
+}
+
+func (s *FuelSchedule) Verify() error {
+	if s.InstructionWeight == 0 {
+		return fmt.Errorf("%w: instruction weight must be non-zero", ErrInvalidFuelSchedule)
+	}
+	if s.FuelPerComputeUnit == 0 {
+		return fmt.Errorf("%w: fuel per compute unit must be non-zero", ErrInvalidFuelSchedule)
+	}
+	return nil
+}
+
+// ComputeUnits converts [fuel] into compute units, rounding up. Fuel was
+// previously rounded down, which let calls consuming less than
+// [FuelPerComputeUnit] fuel execute without paying for compute.
+func (s *FuelSchedule) ComputeUnits(fuel uint64) uint64 {
+	units := fuel / s.FuelPerComputeUnit
+	if fuel%s.FuelPerComputeUnit != 0 {
+		units++
+	}
+	return units
+}
+
+func (s *FuelSchedule) hostFunctionCost(moduleName string, functionName string, defaultCost uint64) uint64 {
+	if cost, ok := s.HostFunctionCosts[moduleName][functionName]; ok {
+		return cost
+	}
+	return defaultCost
+}
+
+// toWasmFuel converts fuel into the fuel consumed by wasm instructions
+func (s *FuelSchedule) toWasmFuel(fuel uint64) uint64 {
+	return fuel / s.InstructionWeight
+}
+
+// fromWasmFuel converts fuel consumed by wasm instructions into fuel
+func (s *FuelSchedule) fromWasmFuel(wasmFuel uint64) uint64 {
+	fuel, err := safemath.Mul(wasmFuel, s.InstructionWeight)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return fuel
+}
+
+// GetFuelSchedule returns the FuelSchedule defined in [rules] or the default
+// schedule if [rules] does not define one. The schedule may be provided as a
+// FuelSchedule or as its JSON encoding.
+func GetFuelSchedule(rules CustomRules) (*FuelSchedule, error) {
+	if rules == nil {
+		return DefaultFuelSchedule(), nil
+	}
+	value, ok := rules.FetchCustom(FuelScheduleKey)
+	if !ok {
+		return DefaultFuelSchedule(), nil
+	}
+
+	var schedule *FuelSchedule
+	switch v := value.(type) {
+	case *FuelSchedule:
+		schedule = v
+	case FuelSchedule:
+		schedule = &v
+	case json.RawMessage:
+		schedule = DefaultFuelSchedule()
+		if err := json.Unmarshal(v, schedule); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFuelSchedule, err)
+		}
+	case []byte:
+		schedule = DefaultFuelSchedule()
+		if err := json.Unmarshal(v, schedule); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFuelSchedule, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unexpected type %T", ErrInvalidFuelSchedule, value)
+	}
+
+	if err := schedule.Verify(); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
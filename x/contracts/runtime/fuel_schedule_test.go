@@ -0,0 +1,105 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/genesis"
+)
+
+func TestGetFuelSchedule(t *testing.T) {
+	tests := []struct {
+		name             string
+		custom           map[string]json.RawMessage
+		expectedSchedule *FuelSchedule
+		expectedErr      error
+	}{
+		{
+			name:             "default schedule",
+			expectedSchedule: DefaultFuelSchedule(),
+		},
+		{
+			name: "custom schedule",
+			custom: map[string]json.RawMessage{
+				FuelScheduleKey: []byte(`{"hostFunctionCosts":{"state":{"get":5}},"memoryPageCost":100,"instructionWeight":2,"fuelPerComputeUnit":10}`),
+			},
+			expectedSchedule: &FuelSchedule{
+				HostFunctionCosts: map[string]map[string]uint64{
+					"state": {"get": 5},
+				},
+				MemoryPageCost:     100,
+				InstructionWeight:  2,
+				FuelPerComputeUnit: 10,
+			},
+		},
+		{
+			name: "partial schedule uses defaults",
+			custom: map[string]json.RawMessage{
+				FuelScheduleKey: []byte(`{"memoryPageCost":100}`),
+			},
+			expectedSchedule: &FuelSchedule{
+				MemoryPageCost:     100,
+				InstructionWeight:  defaultInstructionWeight,
+				FuelPerComputeUnit: defaultFuelPerComputeUnit,
+			},
+		},
+		{
+			name: "zero instruction weight",
+			custom: map[string]json.RawMessage{
+				FuelScheduleKey: []byte(`{"instructionWeight":0}`),
+			},
+			expectedErr: ErrInvalidFuelSchedule,
+		},
+		{
+			name: "invalid json",
+			custom: map[string]json.RawMessage{
+				FuelScheduleKey: []byte(`{`),
+			},
+			expectedErr: ErrInvalidFuelSchedule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			rules := genesis.NewDefaultRules()
+			rules.Custom = tt.custom
+
+			schedule, err := GetFuelSchedule(rules)
+			r.ErrorIs(err, tt.expectedErr)
+			r.Equal(tt.expectedSchedule, schedule)
+		})
+	}
+}
+
+func TestFuelScheduleComputeUnits(t *testing.T) {
+	r := require.New(t)
+
+	schedule := &FuelSchedule{
+		InstructionWeight:  1,
+		FuelPerComputeUnit: 1000,
+	}
+	r.Equal(uint64(0), schedule.ComputeUnits(0))
+	r.Equal(uint64(1), schedule.ComputeUnits(1))
+	r.Equal(uint64(1), schedule.ComputeUnits(1000))
+	r.Equal(uint64(2), schedule.ComputeUnits(1001))
+}
+
+func TestFuelScheduleHostFunctionCost(t *testing.T) {
+	r := require.New(t)
+
+	schedule := &FuelSchedule{
+		HostFunctionCosts: map[string]map[string]uint64{
+			"state": {"get": 5},
+		},
+	}
+	r.Equal(uint64(5), schedule.hostFunctionCost("state", "get", getCost))
+	r.Equal(uint64(putManyCost), schedule.hostFunctionCost("state", "put", putManyCost))
+	r.Equal(uint64(sendBalanceCost), schedule.hostFunctionCost("balance", "send", sendBalanceCost))
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+const (
+	getAssetBalanceCost = 10000
+	transferAssetCost   = 10000
+)
+
+var (
+	ErrAssetsNotSupported       = errors.New("state does not support native assets")
+	ErrInsufficientAssetBalance = errors.New("insufficient asset balance")
+)
+
+// AssetManager is implemented by a StateManager that supports native assets.
+// Contracts can only move native assets when it is implemented. TransferAsset
+// returns ErrInsufficientAssetBalance if [from] cannot cover [amount].
+type AssetManager interface {
+	GetAssetBalance(ctx context.Context, asset ids.ID, account codec.Address) (uint64, error)
+	TransferAsset(ctx context.Context, asset ids.ID, from codec.Address, to codec.Address, amount uint64) error
+}
+
+type assetBalanceInput struct {
+	Asset   ids.ID
+	Account codec.Address
+}
+
+type transferAssetInput struct {
+	Asset  ids.ID
+	To     codec.Address
+	Amount uint64
+}
+
+func NewAssetModule() *ImportModule {
+	return &ImportModule{
+		Name: "asset",
+		HostFunctions: map[string]HostFunction{
+			"balance": {FuelCost: getAssetBalanceCost, Function: Function[assetBalanceInput, uint64](func(callInfo *CallInfo, input assetBalanceInput) (uint64, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				assets, ok := callInfo.State.(AssetManager)
+				if !ok {
+					return 0, ErrAssetsNotSupported
+				}
+				return assets.GetAssetBalance(ctx, input.Asset, input.Account)
+			})},
+			"transfer": {FuelCost: transferAssetCost, Function: Function[transferAssetInput, Result[Unit, ContractCallErrorCode]](func(callInfo *CallInfo, input transferAssetInput) (Result[Unit, ContractCallErrorCode], error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				assets, ok := callInfo.State.(AssetManager)
+				if !ok {
+					return Err[Unit, ContractCallErrorCode](ExecutionFailure), ErrAssetsNotSupported
+				}
+				err := assets.TransferAsset(ctx, input.Asset, callInfo.Contract, input.To, input.Amount)
+				if errors.Is(err, ErrInsufficientAssetBalance) {
+					return Err[Unit, ContractCallErrorCode](InsufficientBalance), nil
+				}
+				if err != nil {
+					return Err[Unit, ContractCallErrorCode](ExecutionFailure), err
+				}
+				return Ok[Unit, ContractCallErrorCode](Unit{}), nil
+			})},
+		},
+	}
+}
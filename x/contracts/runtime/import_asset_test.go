@@ -0,0 +1,132 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/bytecodealliance/wasmtime-go/v25"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/x/contracts/test"
+)
+
+// assetContract is deployed at codec.EmptyAddress. "balance" returns its own
+// balance of testAsset and "transfer" sends 5 of testAsset to testAssetReceiver,
+// returning only the tag of the result.
+const assetContract = `
+(module
+  (import "contract" "set_call_result" (func $set_call_result (param i32 i32)))
+  (import "asset" "balance" (func $balance (param i32 i32) (result i32)))
+  (import "asset" "transfer" (func $transfer (param i32 i32) (result i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 64) "\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\02\05\00\00\00\00\00\00\00")
+  (data (i32.const 256) "\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01\01")
+  (global $next (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $len i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $next))
+    (global.set $next (i32.add (global.get $next) (local.get $len)))
+    (local.get $ptr))
+  (func (export "balance") (param i32)
+    (call $set_call_result (call $balance (i32.const 256) (i32.const 65)) (i32.const 8)))
+  (func (export "transfer") (param i32)
+    (call $set_call_result (call $transfer (i32.const 64) (i32.const 73)) (i32.const 1))))
+`
+
+var (
+	testAsset         = ids.ID(bytes.Repeat([]byte{1}, ids.IDLen))
+	testAssetReceiver = codec.Address(bytes.Repeat([]byte{2}, codec.AddressLen))
+)
+
+type testAssetStateManager struct {
+	TestStateManager
+	assets map[ids.ID]map[codec.Address]uint64
+}
+
+func (t testAssetStateManager) GetAssetBalance(_ context.Context, asset ids.ID, account codec.Address) (uint64, error) {
+	return t.assets[asset][account], nil
+}
+
+func (t testAssetStateManager) TransferAsset(_ context.Context, asset ids.ID, from codec.Address, to codec.Address, amount uint64) error {
+	balances := t.assets[asset]
+	if balances[from] < amount {
+		return ErrInsufficientAssetBalance
+	}
+	balances[from] -= amount
+	balances[to] += amount
+	return nil
+}
+
+func newAssetCallContext(t *testing.T, stateManager StateManager) CallContext {
+	require := require.New(t)
+	ctx := context.Background()
+
+	contractBytes, err := wasmtime.Wat2Wasm(assetContract)
+	require.NoError(err)
+
+	contractManager := NewContractStateManager(test.NewTestDB(), []byte{})
+	contractID := ids.GenerateTestID()
+	require.NoError(contractManager.SetContractBytes(ctx, contractID[:], contractBytes))
+	require.NoError(contractManager.SetAccountContract(ctx, codec.EmptyAddress, contractID[:]))
+
+	switch s := stateManager.(type) {
+	case TestStateManager:
+		s.ContractManager = contractManager
+		stateManager = s
+	case testAssetStateManager:
+		s.ContractManager = contractManager
+		stateManager = s
+	}
+	return NewRuntime(NewConfig(), logging.NoLog{}).WithDefaults(CallInfo{State: stateManager, Fuel: 1_000_000})
+}
+
+func TestImportAssetBalance(t *testing.T) {
+	require := require.New(t)
+
+	callContext := newAssetCallContext(t, testAssetStateManager{
+		assets: map[ids.ID]map[codec.Address]uint64{
+			testAsset: {codec.EmptyAddress: 7},
+		},
+	})
+	result, err := callContext.CallContract(context.Background(), &CallInfo{Contract: codec.EmptyAddress, FunctionName: "balance"})
+	require.NoError(err)
+	require.Equal(uint64(7), into[uint64](result))
+}
+
+func TestImportAssetTransfer(t *testing.T) {
+	require := require.New(t)
+
+	stateManager := testAssetStateManager{
+		assets: map[ids.ID]map[codec.Address]uint64{
+			testAsset: {codec.EmptyAddress: 7},
+		},
+	}
+	callContext := newAssetCallContext(t, stateManager)
+
+	result, err := callContext.CallContract(context.Background(), &CallInfo{Contract: codec.EmptyAddress, FunctionName: "transfer"})
+	require.NoError(err)
+	require.Equal([]byte{resultOkPrefix}, result)
+	require.Equal(uint64(2), stateManager.assets[testAsset][codec.EmptyAddress])
+	require.Equal(uint64(5), stateManager.assets[testAsset][testAssetReceiver])
+
+	// the contract can no longer cover the transfer
+	result, err = callContext.CallContract(context.Background(), &CallInfo{Contract: codec.EmptyAddress, FunctionName: "transfer"})
+	require.NoError(err)
+	require.Equal([]byte{resultErrPrefix}, result)
+	require.Equal(uint64(2), stateManager.assets[testAsset][codec.EmptyAddress])
+}
+
+func TestImportAssetNotSupported(t *testing.T) {
+	require := require.New(t)
+
+	callContext := newAssetCallContext(t, TestStateManager{Balances: map[codec.Address]uint64{}})
+	_, err := callContext.CallContract(context.Background(), &CallInfo{Contract: codec.EmptyAddress, FunctionName: "balance"})
+	require.ErrorContains(err, ErrAssetsNotSupported.Error())
+}
@@ -20,6 +20,7 @@ const (
 	setCallResultCost = 10000
 	remainingFuelCost = 10000
 	deployCost        = 10000
+	upgradeCost       = 10000
 )
 
 const (
@@ -27,6 +28,9 @@ const (
 	CallPanicked
 	OutOfFuel
 	InsufficientBalance
+	ImmutableContract
+	CallDepthExceeded
+	ReentrantCall
 )
 
 type callContractInput struct {
@@ -42,6 +46,10 @@ type deployContractInput struct {
 	AccountCreationData []byte
 }
 
+type upgradeContractInput struct {
+	ContractID ContractID
+}
+
 func ExtractContractCallErrorCode(err error) (ContractCallErrorCode, bool) {
 	var trap *wasmtime.Trap
 	if errors.As(err, &trap) {
@@ -62,6 +70,10 @@ func NewContractModule(r *WasmRuntime) *ImportModule {
 		Name: "contract",
 		HostFunctions: map[string]HostFunction{
 			"call_contract": {FuelCost: callContractCost, Function: Function[callContractInput, Result[RawBytes, ContractCallErrorCode]](func(callInfo *CallInfo, input callContractInput) (Result[RawBytes, ContractCallErrorCode], error) {
+				if callInfo.depth+1 > int(callInfo.callLimits().MaxCallDepth) {
+					return Err[RawBytes, ContractCallErrorCode](CallDepthExceeded), nil
+				}
+
 				newInfo := *callInfo
 
 				if err := callInfo.ConsumeFuel(input.Fuel); err != nil {
@@ -74,10 +86,19 @@ func NewContractModule(r *WasmRuntime) *ImportModule {
 				newInfo.Params = input.Params
 				newInfo.Fuel = input.Fuel
 				newInfo.Value = input.Value
+				newInfo.caller = callInfo
+				newInfo.depth = callInfo.depth + 1
+				newInfo.iterators = nil
+				newInfo.profile = callInfo.profile.nestedCall(input.Contract, input.FunctionName)
 
 				result, err := r.CallContract(
 					context.Background(),
 					&newInfo)
+				if errors.Is(err, ErrReentrantCall) {
+					// the called contract was never instantiated
+					callInfo.AddFuel(input.Fuel)
+					return Err[RawBytes, ContractCallErrorCode](ReentrantCall), nil
+				}
 				if err != nil {
 					if code, ok := ExtractContractCallErrorCode(err); ok {
 						return Err[RawBytes, ContractCallErrorCode](code), nil
@@ -111,6 +132,25 @@ func NewContractModule(r *WasmRuntime) *ImportModule {
 						return address, nil
 					}),
 			},
+			// upgrade replaces the contract of the calling account. Contracts are
+			// responsible for authorizing upgrades before calling it.
+			"upgrade": {
+				FuelCost: upgradeCost,
+				Function: Function[upgradeContractInput, Result[Unit, ContractCallErrorCode]](
+					func(callInfo *CallInfo, input upgradeContractInput) (Result[Unit, ContractCallErrorCode], error) {
+						ctx, cancel := context.WithCancel(context.Background())
+						defer cancel()
+						upgrade, err := UpgradeAccountContract(ctx, callInfo.State, callInfo.Contract, input.ContractID)
+						if errors.Is(err, ErrImmutableContract) {
+							return Err[Unit, ContractCallErrorCode](ImmutableContract), nil
+						}
+						if err != nil {
+							return Err[Unit, ContractCallErrorCode](ExecutionFailure), err
+						}
+						callInfo.recordUpgrade(upgrade)
+						return Ok[Unit, ContractCallErrorCode](Unit{}), nil
+					}),
+			},
 		},
 	}
 }
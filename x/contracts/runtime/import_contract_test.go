@@ -198,3 +198,58 @@ func TestImportOutOfFuel(t *testing.T) {
 	require.NoError(err)
 	require.Equal([]byte{byte(OutOfFuel)}, result)
 }
+
+func TestImportContractCallDepthExceeded(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	rt := newTestRuntime(ctx).WithCallLimits(&CallLimits{MaxCallDepth: 2})
+	contract, err := rt.newTestContract("call_contract")
+	require.NoError(err)
+
+	result, err := contract.Call("call_self", uint32(2))
+	require.NoError(err)
+	expected, err := Serialize(Ok[uint32, ContractCallErrorCode](2))
+	require.NoError(err)
+	require.Equal(expected, result)
+
+	result, err = contract.Call("call_self", uint32(3))
+	require.NoError(err)
+	expected, err = Serialize(Err[uint32, ContractCallErrorCode](CallDepthExceeded))
+	require.NoError(err)
+	require.Equal(expected, result)
+}
+
+func TestImportContractReentrantCall(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	rt := newTestRuntime(ctx)
+	contract, err := rt.newTestContract("call_contract")
+	require.NoError(err)
+	guarded, err := rt.newTestContract("nonreentrant")
+	require.NoError(err)
+
+	ok, err := Serialize(Ok[int64, ContractCallErrorCode](0))
+	require.NoError(err)
+	reentrant, err := Serialize(Err[int64, ContractCallErrorCode](ReentrantCall))
+	require.NoError(err)
+
+	// contracts without the guard may call themselves
+	result, err := contract.Call("simple_call_checked", contract.Address, uint64(100000))
+	require.NoError(err)
+	require.Equal(ok, result)
+
+	// guarded contracts may be called while they are not executing
+	result, err = contract.Call("simple_call_checked", guarded.Address, uint64(100000))
+	require.NoError(err)
+	require.Equal(ok, result)
+
+	result, err = guarded.Call("call_self", uint64(100000))
+	require.NoError(err)
+	require.Equal(reentrant, result)
+
+	result, err = guarded.Call("call_through", contract.Address, uint64(1000000))
+	require.NoError(err)
+	require.Equal(reentrant, result)
+}
@@ -16,6 +16,15 @@ const (
 	deleteCost = 10000
 
 	putManyCost = 10000
+
+	iterPrefixCost = 10000
+	// nextCost is charged for each item returned by an iterator
+	nextCost = 1000
+)
+
+var (
+	ErrIterationNotSupported = errors.New("contract state does not support iteration")
+	ErrUnknownIterator       = errors.New("unknown iterator")
 )
 
 type keyValueInput struct {
@@ -54,6 +63,38 @@ func NewStateAccessModule() *ImportModule {
 				}
 				return nil
 			})},
+			"iter_prefix": {FuelCost: iterPrefixCost, Function: Function[[]byte, uint32](func(callInfo *CallInfo, input []byte) (uint32, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				contractState, ok := callInfo.State.GetContractState(callInfo.Contract).(PrefixIterable)
+				if !ok {
+					return 0, ErrIterationNotSupported
+				}
+				iter, err := contractState.NewIteratorWithPrefix(ctx, input)
+				if err != nil {
+					return 0, err
+				}
+				callInfo.iterators = append(callInfo.iterators, iter)
+				return uint32(len(callInfo.iterators) - 1), nil
+			})},
+			"next": {FuelCost: nextCost, Function: Function[uint32, RawBytes](func(callInfo *CallInfo, input uint32) (RawBytes, error) {
+				if int(input) >= len(callInfo.iterators) {
+					return nil, ErrUnknownIterator
+				}
+				iter := callInfo.iterators[input]
+				if !iter.Next() {
+					return nil, iter.Error()
+				}
+				return Serialize(keyValueInput{Key: iter.Key(), Value: iter.Value()})
+			})},
 		},
 	}
 }
+
+// releaseIterators releases the state iterators opened by this call
+func (c *CallInfo) releaseIterators() {
+	for _, iter := range c.iterators {
+		iter.Release()
+	}
+	c.iterators = nil
+}
@@ -7,8 +7,12 @@ import (
 	"context"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/near/borsh-go"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/x/contracts/test"
 )
 
 func TestImportStatePutGet(t *testing.T) {
@@ -79,3 +83,49 @@ func TestImportStateGetMissingKey(t *testing.T) {
 	require.NoError(err)
 	require.Equal(None[RawBytes](), into[Option[RawBytes]](result))
 }
+
+func TestImportStateIterPrefix(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	rt := newTestRuntime(ctx)
+	contract, err := rt.newTestContract("state_access")
+	require.NoError(err)
+
+	result, err := contract.Call("put_items", uint8(4))
+	require.NoError(err)
+	require.Nil(result)
+
+	result, err = contract.Call("sum_items")
+	require.NoError(err)
+	require.Equal(int64(6), into[int64](result))
+}
+
+func TestContractStateIteratePrefix(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	manager := NewContractStateManager(test.NewTestDB(), []byte{})
+	account := codec.CreateAddress(0, ids.GenerateTestID())
+	other := codec.CreateAddress(0, ids.GenerateTestID())
+
+	accountState := manager.GetContractState(account)
+	require.NoError(accountState.Insert(ctx, []byte{1, 2}, []byte{2}))
+	require.NoError(accountState.Insert(ctx, []byte{1, 1}, []byte{1}))
+	require.NoError(accountState.Insert(ctx, []byte{2, 1}, []byte{3}))
+	require.NoError(manager.GetContractState(other).Insert(ctx, []byte{1, 3}, []byte{4}))
+
+	iter, err := accountState.(PrefixIterable).NewIteratorWithPrefix(ctx, []byte{1})
+	require.NoError(err)
+	defer iter.Release()
+
+	entries := []keyValueInput{}
+	for iter.Next() {
+		entries = append(entries, keyValueInput{Key: iter.Key(), Value: iter.Value()})
+	}
+	require.NoError(iter.Error())
+	require.Equal([]keyValueInput{
+		{Key: []byte{1, 1}, Value: []byte{1}},
+		{Key: []byte{1, 2}, Value: []byte{2}},
+	}, entries)
+}
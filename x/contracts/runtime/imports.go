@@ -55,7 +55,7 @@ func (i *Imports) createLinker(r *WasmRuntime) (*wasmtime.Linker, error) {
 	linker := wasmtime.NewLinker(r.engine)
 	for moduleName, module := range i.Modules {
 		for funcName, hostFunction := range module.HostFunctions {
-			if err := linker.FuncNew(moduleName, funcName, hostFunction.Function.wasmType(), hostFunction.convert(r)); err != nil {
+			if err := linker.FuncNew(moduleName, funcName, hostFunction.Function.wasmType(), hostFunction.convert(r, moduleName, funcName)); err != nil {
 				return nil, err
 			}
 		}
@@ -68,10 +68,15 @@ type HostFunction struct {
 	FuelCost uint64
 }
 
-func (f HostFunction) convert(r *WasmRuntime) func(*wasmtime.Caller, []wasmtime.Val) ([]wasmtime.Val, *wasmtime.Trap) {
+func (f HostFunction) convert(r *WasmRuntime, moduleName string, functionName string) func(*wasmtime.Caller, []wasmtime.Val) ([]wasmtime.Val, *wasmtime.Trap) {
 	return func(caller *wasmtime.Caller, vals []wasmtime.Val) ([]wasmtime.Val, *wasmtime.Trap) {
 		callInfo := r.getCallInfo(caller)
-		if err := callInfo.ConsumeFuel(f.FuelCost); err != nil {
+		fuelCost := callInfo.fuelSchedule().hostFunctionCost(moduleName, functionName, f.FuelCost)
+		if err := callInfo.ConsumeFuel(fuelCost); err != nil {
+			return nil, convertToTrap(err)
+		}
+		callInfo.profile.recordHostFunction(moduleName, functionName, fuelCost)
+		if err := callInfo.consumeMemoryGrowth(caller); err != nil {
 			return nil, convertToTrap(err)
 		}
 		return f.Function.call(callInfo, caller, vals)
@@ -17,8 +17,10 @@ import (
 
 var (
 	_                 ContractManager = &ContractStateManager{}
+	_                 PrefixIterable  = (*prefixedStateMutable)(nil)
 	ErrUnknownAccount                 = errors.New("unknown account")
 	contractKeyBytes                  = []byte("contract")
+	immutableKeyBytes                 = []byte("immutable")
 )
 
 const (
@@ -92,6 +94,23 @@ func (p *ContractStateManager) SetAccountContract(ctx context.Context, account c
 	return p.db.Insert(ctx, accountDataKey(account[:], contractKeyBytes), contractID)
 }
 
+// SetAccountImmutable marks the contract of [account] as immutable
+func (p *ContractStateManager) SetAccountImmutable(ctx context.Context, account codec.Address) error {
+	return p.db.Insert(ctx, accountDataKey(account[:], immutableKeyBytes), []byte{1})
+}
+
+// IsAccountImmutable returns true if the contract of [account] cannot be upgraded
+func (p *ContractStateManager) IsAccountImmutable(ctx context.Context, account codec.Address) (bool, error) {
+	_, err := p.db.GetValue(ctx, accountDataKey(account[:], immutableKeyBytes))
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // setContract stores [contract] at [contractID]
 func (p *ContractStateManager) SetContractBytes(
 	ctx context.Context,
@@ -163,6 +182,28 @@ func (s *prefixedStateMutable) Remove(ctx context.Context, key []byte) error {
 	return s.inner.Remove(ctx, s.prefixKey(key))
 }
 
+func (s *prefixedStateMutable) NewIteratorWithPrefix(ctx context.Context, prefix []byte) (database.Iterator, error) {
+	inner, ok := s.inner.(PrefixIterable)
+	if !ok {
+		return nil, ErrIterationNotSupported
+	}
+	iter, err := inner.NewIteratorWithPrefix(ctx, s.prefixKey(prefix))
+	if err != nil {
+		return nil, err
+	}
+	return &prefixedIterator{Iterator: iter, prefixLen: len(s.prefix)}, nil
+}
+
+// prefixedIterator removes the prefix of the state it iterates from its keys
+type prefixedIterator struct {
+	database.Iterator
+	prefixLen int
+}
+
+func (i *prefixedIterator) Key() []byte {
+	return i.Iterator.Key()[i.prefixLen:]
+}
+
 func newAccountPrefixedMutable(account codec.Address, mutable state.Mutable) state.Mutable {
 	return &prefixedStateMutable{inner: mutable, prefix: accountStateKey(account[:])}
 }
@@ -0,0 +1,234 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytecodealliance/wasmtime-go/v25"
+)
+
+const (
+	moduleFileExtension = ".cwasm"
+	wasmtimeModulePath  = "github.com/bytecodealliance/wasmtime-go/v25"
+)
+
+// emptyWasmModule is the binary encoding of (module)
+var emptyWasmModule = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// moduleCache stores compiled modules on disk so contracts are not recompiled
+// after a restart. Each file holds the checksum of the serialized module
+// followed by the module. Files are named after the contract ID and the engine
+// key, so modules compiled by a different version of wasmtime or with a
+// different configuration are never loaded. The least recently used modules
+// are removed once the cache grows beyond its maximum size.
+//
+// Contract IDs must identify immutable contract bytes.
+type moduleCache struct {
+	dir       string
+	maxSize   int
+	engineKey []byte
+
+	lock    sync.Mutex
+	size    int
+	entries map[string]*moduleCacheEntry
+}
+
+type moduleCacheEntry struct {
+	size     int
+	lastUsed time.Time
+}
+
+func newModuleCache(dir string, maxSize int, engine *wasmtime.Engine) (*moduleCache, error) {
+	engineKey, err := getEngineKey(engine)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &moduleCache{
+		dir:       dir,
+		maxSize:   maxSize,
+		engineKey: engineKey,
+		entries:   map[string]*moduleCacheEntry{},
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), moduleFileExtension) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return nil, err
+		}
+		c.entries[file.Name()] = &moduleCacheEntry{
+			size:     int(info.Size()),
+			lastUsed: info.ModTime(),
+		}
+		c.size += int(info.Size())
+	}
+	c.evict()
+	return c, nil
+}
+
+// getEngineKey identifies the version of wasmtime and the configuration of
+// [engine]. Serialized modules include the settings of the engine that
+// compiled them, so the serialization of an empty module changes with them.
+func getEngineKey(engine *wasmtime.Engine) ([]byte, error) {
+	module, err := wasmtime.NewModule(engine, emptyWasmModule)
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := module.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	version := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == wasmtimeModulePath {
+				version = dep.Version
+				break
+			}
+		}
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write([]byte(version))
+	_, _ = hash.Write(serialized)
+	return hash.Sum(nil), nil
+}
+
+func (c *moduleCache) fileName(id ContractID) string {
+	hash := sha256.New()
+	_, _ = hash.Write(c.engineKey)
+	_, _ = hash.Write(id)
+	return hex.EncodeToString(hash.Sum(nil)) + moduleFileExtension
+}
+
+// has returns true if the module compiled for [id] is cached
+func (c *moduleCache) has(id ContractID) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, ok := c.entries[c.fileName(id)]
+	return ok
+}
+
+// get loads the module compiled for [id]. Corrupted files are removed.
+func (c *moduleCache) get(engine *wasmtime.Engine, id ContractID) (*wasmtime.Module, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	name := c.fileName(id)
+	entry, ok := c.entries[name]
+	if !ok {
+		return nil, false
+	}
+
+	path := filepath.Join(c.dir, name)
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < sha256.Size {
+		c.remove(name)
+		return nil, false
+	}
+	checksum := sha256.Sum256(data[sha256.Size:])
+	if !bytes.Equal(checksum[:], data[:sha256.Size]) {
+		c.remove(name)
+		return nil, false
+	}
+	module, err := wasmtime.NewModuleDeserialize(engine, data[sha256.Size:])
+	if err != nil {
+		c.remove(name)
+		return nil, false
+	}
+
+	entry.lastUsed = time.Now()
+	_ = os.Chtimes(path, entry.lastUsed, entry.lastUsed)
+	return module, true
+}
+
+// put stores [module] as the module compiled for [id]. Modules larger than
+// the cache are not stored.
+func (c *moduleCache) put(id ContractID, module *wasmtime.Module) error {
+	serialized, err := module.Serialize()
+	if err != nil {
+		return err
+	}
+	size := sha256.Size + len(serialized)
+	if size > c.maxSize {
+		return nil
+	}
+	checksum := sha256.Sum256(serialized)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	name := c.fileName(id)
+	if _, ok := c.entries[name]; ok {
+		return nil
+	}
+
+	// write to a temporary file first so a crash never leaves a partial module
+	tmp, err := os.CreateTemp(c.dir, "module-*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(checksum[:])
+	if err == nil {
+		_, err = tmp.Write(serialized)
+	}
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(c.dir, name))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	c.entries[name] = &moduleCacheEntry{size: size, lastUsed: time.Now()}
+	c.size += size
+	c.evict()
+	return nil
+}
+
+// evict removes the least recently used modules until the cache fits within
+// its maximum size
+func (c *moduleCache) evict() {
+	for c.size > c.maxSize {
+		oldest := ""
+		for name, entry := range c.entries {
+			if oldest == "" || entry.lastUsed.Before(c.entries[oldest].lastUsed) {
+				oldest = name
+			}
+		}
+		c.remove(oldest)
+	}
+}
+
+func (c *moduleCache) remove(name string) {
+	entry, ok := c.entries[name]
+	if !ok {
+		return
+	}
+	_ = os.Remove(filepath.Join(c.dir, name))
+	c.size -= entry.size
+	delete(c.entries, name)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/bytecodealliance/wasmtime-go/v25"
+	"github.com/stretchr/testify/require"
+)
+
+func newModuleCacheConfig(dir string, size int) *Config {
+	cfg := NewConfig()
+	cfg.ModuleCacheDir = dir
+	cfg.ModuleCacheSize = size
+	return cfg
+}
+
+func TestModuleCachePersistsModules(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	contractBytes, err := wasmtime.Wat2Wasm(profiledContract)
+	require.NoError(err)
+	contractID := ContractID("contract")
+
+	rt := NewRuntime(newModuleCacheConfig(dir, DefaultModuleCacheSize), logging.NoLog{})
+	require.NotNil(rt.moduleCache)
+	rt.PrecompileContract(contractID, contractBytes)
+
+	// contracts are compiled in the background
+	require.Eventually(func() bool {
+		return rt.moduleCache.has(contractID)
+	}, 10*time.Second, 10*time.Millisecond)
+	files, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(files, 1)
+
+	// a restarted runtime does not compile modules found on disk
+	restarted := NewRuntime(newModuleCacheConfig(dir, DefaultModuleCacheSize), logging.NoLog{})
+	restarted.PrecompileContract(contractID, contractBytes)
+	require.Empty(restarted.precompiling)
+
+	// a restarted runtime loads the module without the contract bytes
+	module, err := restarted.getModule(context.Background(), &CallInfo{}, contractID)
+	require.NoError(err)
+	require.NotNil(module)
+
+	// corrupted modules are removed instead of loaded
+	path := filepath.Join(dir, files[0].Name())
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(os.WriteFile(path, data, 0o600))
+
+	restarted = NewRuntime(newModuleCacheConfig(dir, DefaultModuleCacheSize), logging.NoLog{})
+	_, ok := restarted.moduleCache.get(restarted.engine, contractID)
+	require.False(ok)
+	require.NoFileExists(path)
+}
+
+func TestModuleCacheEngineKey(t *testing.T) {
+	require := require.New(t)
+
+	key, err := getEngineKey(wasmtime.NewEngineWithConfig(DefaultWasmtimeConfig()))
+	require.NoError(err)
+	sameKey, err := getEngineKey(wasmtime.NewEngineWithConfig(DefaultWasmtimeConfig()))
+	require.NoError(err)
+	require.Equal(key, sameKey)
+
+	wasmConfig := DefaultWasmtimeConfig()
+	wasmConfig.SetConsumeFuel(false)
+	otherKey, err := getEngineKey(wasmtime.NewEngineWithConfig(wasmConfig))
+	require.NoError(err)
+	require.NotEqual(key, otherKey)
+}
+
+func TestModuleCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	require := require.New(t)
+
+	contractBytes, err := wasmtime.Wat2Wasm(profiledContract)
+	require.NoError(err)
+	engine := wasmtime.NewEngineWithConfig(DefaultWasmtimeConfig())
+	module, err := wasmtime.NewModule(engine, contractBytes)
+	require.NoError(err)
+	serialized, err := module.Serialize()
+	require.NoError(err)
+
+	// room for two modules
+	cache, err := newModuleCache(t.TempDir(), 2*(len(serialized)+64), engine)
+	require.NoError(err)
+
+	first, second, third := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+	require.NoError(cache.put(first[:], module))
+	require.NoError(cache.put(second[:], module))
+	_, ok := cache.get(engine, first[:])
+	require.True(ok)
+
+	require.NoError(cache.put(third[:], module))
+	_, ok = cache.get(engine, second[:])
+	require.False(ok)
+	_, ok = cache.get(engine, first[:])
+	require.True(ok)
+	_, ok = cache.get(engine, third[:])
+	require.True(ok)
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+const (
+	instructionsFrame = "[instructions]"
+	memoryFrame       = "[memory]"
+)
+
+// HostFunctionProfile is the fuel charged for calls to a host function
+type HostFunctionProfile struct {
+	Calls uint64
+	Fuel  uint64
+}
+
+// CallProfile records how a contract call consumed its fuel
+type CallProfile struct {
+	Contract     codec.Address
+	FunctionName string
+
+	// ConsumedFuel is the fuel consumed by the call, including nested calls
+	ConsumedFuel uint64
+
+	// HostFunctions is the fuel charged for the host functions called by the
+	// contract, keyed by module name and function name joined with a dot.
+	// Host functions called by nested calls are recorded in their profiles.
+	HostFunctions map[string]*HostFunctionProfile
+
+	// MemoryFuel is the fuel charged for growing linear memory
+	MemoryFuel uint64
+
+	// Calls are the nested calls made by the contract, in the order they were
+	// made
+	Calls []*CallProfile
+
+	// Duration is the wall time of the call, including nested calls
+	Duration time.Duration
+}
+
+func newCallProfile(contract codec.Address, functionName string) *CallProfile {
+	return &CallProfile{
+		Contract:      contract,
+		FunctionName:  functionName,
+		HostFunctions: map[string]*HostFunctionProfile{},
+	}
+}
+
+// nestedCall returns the profile of a call made by [p], nil if [p] is nil
+func (p *CallProfile) nestedCall(contract codec.Address, functionName string) *CallProfile {
+	if p == nil {
+		return nil
+	}
+	nested := newCallProfile(contract, functionName)
+	p.Calls = append(p.Calls, nested)
+	return nested
+}
+
+func (p *CallProfile) recordHostFunction(moduleName string, functionName string, fuel uint64) {
+	if p == nil {
+		return
+	}
+	name := moduleName + "." + functionName
+	hostFunction, ok := p.HostFunctions[name]
+	if !ok {
+		hostFunction = &HostFunctionProfile{}
+		p.HostFunctions[name] = hostFunction
+	}
+	hostFunction.Calls++
+	hostFunction.Fuel += fuel
+}
+
+func (p *CallProfile) recordMemoryGrowth(fuel uint64) {
+	if p == nil {
+		return
+	}
+	p.MemoryFuel += fuel
+}
+
+func (p *CallProfile) finish(callInfo *CallInfo, start time.Time) {
+	p.Duration = time.Since(start)
+	p.ConsumedFuel = callInfo.Fuel - callInfo.RemainingFuel()
+}
+
+// SelfFuel is the fuel consumed by the call, excluding nested calls
+func (p *CallProfile) SelfFuel() uint64 {
+	fuel := p.ConsumedFuel
+	for _, call := range p.Calls {
+		fuel = saturatingSub(fuel, call.ConsumedFuel)
+	}
+	return fuel
+}
+
+// InstructionFuel is the fuel consumed by the instructions of the contract.
+// It includes the fuel forwarded to nested calls that failed.
+func (p *CallProfile) InstructionFuel() uint64 {
+	fuel := saturatingSub(p.SelfFuel(), p.MemoryFuel)
+	for _, hostFunction := range p.HostFunctions {
+		fuel = saturatingSub(fuel, hostFunction.Fuel)
+	}
+	return fuel
+}
+
+// FuelByContract returns the fuel consumed by each contract in the call tree,
+// excluding the fuel consumed by the contracts they call
+func (p *CallProfile) FuelByContract() map[codec.Address]uint64 {
+	fuel := map[codec.Address]uint64{}
+	p.addContractFuel(fuel)
+	return fuel
+}
+
+func (p *CallProfile) addContractFuel(fuel map[codec.Address]uint64) {
+	fuel[p.Contract] += p.SelfFuel()
+	for _, call := range p.Calls {
+		call.addContractFuel(fuel)
+	}
+}
+
+// Pprof converts the call tree into a pprof profile. Each call is a frame
+// named after its contract and function. The fuel of host functions, memory
+// growth and instructions is recorded in leaf frames below it.
+func (p *CallProfile) Pprof() *profile.Profile {
+	b := &pprofBuilder{
+		profile: &profile.Profile{
+			SampleType:    []*profile.ValueType{{Type: "fuel", Unit: "count"}},
+			DurationNanos: p.Duration.Nanoseconds(),
+		},
+		locations: map[string]*profile.Location{},
+	}
+	b.addCall(p, nil)
+	return b.profile
+}
+
+// WritePprof writes the pprof profile of the call tree to [w]
+func (p *CallProfile) WritePprof(w io.Writer) error {
+	return p.Pprof().Write(w)
+}
+
+type pprofBuilder struct {
+	profile   *profile.Profile
+	locations map[string]*profile.Location
+}
+
+func (b *pprofBuilder) addCall(p *CallProfile, stack []*profile.Location) {
+	stack = b.push(stack, fmt.Sprintf("%s.%s", p.Contract, p.FunctionName))
+
+	b.addSample(b.push(stack, instructionsFrame), p.InstructionFuel())
+	b.addSample(b.push(stack, memoryFrame), p.MemoryFuel)
+
+	names := maps.Keys(p.HostFunctions)
+	slices.Sort(names)
+	for _, name := range names {
+		b.addSample(b.push(stack, name), p.HostFunctions[name].Fuel)
+	}
+
+	for _, call := range p.Calls {
+		b.addCall(call, stack)
+	}
+}
+
+// push returns [stack] with the frame [name] as its leaf. pprof stacks are
+// ordered from the leaf to the root.
+func (b *pprofBuilder) push(stack []*profile.Location, name string) []*profile.Location {
+	return append([]*profile.Location{b.location(name)}, stack...)
+}
+
+func (b *pprofBuilder) addSample(stack []*profile.Location, fuel uint64) {
+	if fuel == 0 {
+		return
+	}
+	b.profile.Sample = append(b.profile.Sample, &profile.Sample{
+		Location: stack,
+		Value:    []int64{int64(fuel)},
+	})
+}
+
+func (b *pprofBuilder) location(name string) *profile.Location {
+	if location, ok := b.locations[name]; ok {
+		return location
+	}
+	function := &profile.Function{
+		ID:         uint64(len(b.profile.Function) + 1),
+		Name:       name,
+		SystemName: name,
+	}
+	location := &profile.Location{
+		ID:   uint64(len(b.profile.Location) + 1),
+		Line: []profile.Line{{Function: function}},
+	}
+	b.profile.Function = append(b.profile.Function, function)
+	b.profile.Location = append(b.profile.Location, location)
+	b.locations[name] = location
+	return location
+}
+
+func saturatingSub(a uint64, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
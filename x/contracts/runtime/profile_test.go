@@ -0,0 +1,121 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/bytecodealliance/wasmtime-go/v25"
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/x/contracts/test"
+)
+
+// profiledContract is deployed at codec.EmptyAddress. "answer" sets its call
+// result and "nested" calls "answer" on itself with 100000 fuel.
+const profiledContract = `
+(module
+  (import "contract" "set_call_result" (func $set_call_result (param i32 i32)))
+  (import "contract" "call_contract" (func $call_contract (param i32 i32) (result i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 16) "\2a\00\00\00\00\00\00\00")
+  (data (i32.const 64) "\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00\00")
+  (data (i32.const 97) "\06\00\00\00answer\00\00\00\00\a0\86\01\00\00\00\00\00\00\00\00\00\00\00\00\00")
+  (global $next (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $len i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $next))
+    (global.set $next (i32.add (global.get $next) (local.get $len)))
+    (local.get $ptr))
+  (func (export "answer") (param i32)
+    (call $set_call_result (i32.const 16) (i32.const 8)))
+  (func (export "nested") (param i32)
+    (drop (call $call_contract (i32.const 64) (i32.const 63)))
+    (call $set_call_result (i32.const 16) (i32.const 8))))
+`
+
+func newProfiledRuntime(t *testing.T, cfg *Config) (*WasmRuntime, StateManager) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	contractBytes, err := wasmtime.Wat2Wasm(profiledContract)
+	require.NoError(err)
+
+	stateManager := TestStateManager{
+		ContractManager: NewContractStateManager(test.NewTestDB(), []byte{}),
+		Balances:        map[codec.Address]uint64{},
+	}
+	contractID := ids.GenerateTestID()
+	require.NoError(stateManager.SetContractBytes(ctx, contractID[:], contractBytes))
+	require.NoError(stateManager.SetAccountContract(ctx, codec.EmptyAddress, contractID[:]))
+
+	return NewRuntime(cfg, logging.NoLog{}), stateManager
+}
+
+func TestCallContractWithProfile(t *testing.T) {
+	require := require.New(t)
+
+	rt, stateManager := newProfiledRuntime(t, NewConfig())
+	callContext := rt.WithDefaults(CallInfo{State: stateManager, Fuel: 1_000_000})
+
+	result, callProfile, err := callContext.CallContractWithProfile(
+		context.Background(),
+		&CallInfo{Contract: codec.EmptyAddress, FunctionName: "nested"},
+	)
+	require.NoError(err)
+	require.Equal(int64(42), into[int64](result))
+
+	require.Equal("nested", callProfile.FunctionName)
+	require.Positive(callProfile.Duration)
+	require.Equal(&HostFunctionProfile{Calls: 1, Fuel: callContractCost}, callProfile.HostFunctions["contract.call_contract"])
+	require.Equal(&HostFunctionProfile{Calls: 1, Fuel: setCallResultCost}, callProfile.HostFunctions["contract.set_call_result"])
+
+	require.Len(callProfile.Calls, 1)
+	nested := callProfile.Calls[0]
+	require.Equal("answer", nested.FunctionName)
+	require.Empty(nested.Calls)
+	require.Equal(&HostFunctionProfile{Calls: 1, Fuel: setCallResultCost}, nested.HostFunctions["contract.set_call_result"])
+	require.Greater(nested.ConsumedFuel, uint64(setCallResultCost))
+	require.Equal(nested.ConsumedFuel-setCallResultCost, nested.InstructionFuel())
+
+	require.Equal(callProfile.ConsumedFuel-nested.ConsumedFuel, callProfile.SelfFuel())
+	require.Equal(map[codec.Address]uint64{codec.EmptyAddress: callProfile.ConsumedFuel}, callProfile.FuelByContract())
+
+	// the fuel recorded in the pprof profile is the fuel consumed by the call
+	buf := &bytes.Buffer{}
+	require.NoError(callProfile.WritePprof(buf))
+	parsed, err := profile.Parse(buf)
+	require.NoError(err)
+	require.NoError(parsed.CheckValid())
+	total := int64(0)
+	for _, sample := range parsed.Sample {
+		total += sample.Value[0]
+	}
+	require.Equal(int64(callProfile.ConsumedFuel), total)
+}
+
+func TestRuntimeFuelProfiling(t *testing.T) {
+	require := require.New(t)
+
+	rt, stateManager := newProfiledRuntime(t, NewConfig())
+	callInfo := &CallInfo{State: stateManager, Contract: codec.EmptyAddress, FunctionName: "nested", Fuel: 1_000_000}
+	_, err := rt.CallContract(context.Background(), callInfo)
+	require.NoError(err)
+	require.Nil(callInfo.Profile())
+
+	cfg := NewConfig()
+	cfg.EnableFuelProfiling = true
+	rt, stateManager = newProfiledRuntime(t, cfg)
+	callInfo = &CallInfo{State: stateManager, Contract: codec.EmptyAddress, FunctionName: "nested", Fuel: 1_000_000}
+	_, err = rt.CallContract(context.Background(), callInfo)
+	require.NoError(err)
+	require.NotNil(callInfo.Profile())
+	require.Len(callInfo.Profile().Calls, 1)
+}
@@ -6,21 +6,32 @@ package runtime
 import (
 	"context"
 	"reflect"
+	"slices"
+	"time"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/bytecodealliance/wasmtime-go/v25"
+	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/state"
 )
 
+// maxConcurrentPrecompiles is the maximum number of contracts compiled in the
+// background at once
+const maxConcurrentPrecompiles = 2
+
 type WasmRuntime struct {
 	log    logging.Logger
 	engine *wasmtime.Engine
 	cfg    *Config
 
 	contractCache cache.Cacher[string, *wasmtime.Module]
+	moduleCache   *moduleCache
+	// precompiling bounds the number of contracts compiled in the background
+	precompiling chan struct{}
 
 	callerInfo map[uintptr]*CallInfo
 	linker     *wasmtime.Linker
@@ -50,6 +61,17 @@ type ContractManager interface {
 	SetAccountContract(ctx context.Context, account codec.Address, contractID ContractID) error
 	// SetContractBytes stores the compiled WASM bytes of the contract with the given ID.
 	SetContractBytes(ctx context.Context, contractID ContractID, contractBytes []byte) error
+	// SetAccountImmutable prevents the contract associated with the given account from being upgraded.
+	SetAccountImmutable(ctx context.Context, account codec.Address) error
+	// IsAccountImmutable returns true if the contract associated with the given account cannot be upgraded.
+	IsAccountImmutable(ctx context.Context, account codec.Address) (bool, error)
+}
+
+// PrefixIterable is implemented by contract state that supports iterating
+// over the keys beginning with a prefix. Keys are returned in lexicographic
+// order and without the prefix of the contract state.
+type PrefixIterable interface {
+	NewIteratorWithPrefix(ctx context.Context, prefix []byte) (database.Iterator, error)
 }
 
 func NewRuntime(
@@ -59,10 +81,11 @@ func NewRuntime(
 	hostImports := NewImports()
 
 	runtime := &WasmRuntime{
-		log:        log,
-		cfg:        cfg,
-		engine:     wasmtime.NewEngineWithConfig(cfg.wasmConfig),
-		callerInfo: map[uintptr]*CallInfo{},
+		log:          log,
+		cfg:          cfg,
+		engine:       wasmtime.NewEngineWithConfig(cfg.wasmConfig),
+		callerInfo:   map[uintptr]*CallInfo{},
+		precompiling: make(chan struct{}, maxConcurrentPrecompiles),
 		contractCache: cache.NewSizedLRU(cfg.ContractCacheSize, func(id string, mod *wasmtime.Module) int {
 			bytes, err := mod.Serialize()
 			if err != nil {
@@ -72,8 +95,20 @@ func NewRuntime(
 		}),
 	}
 
+	if cfg.ModuleCacheDir != "" {
+		diskCache, err := newModuleCache(cfg.ModuleCacheDir, cfg.ModuleCacheSize, runtime.engine)
+		if err != nil {
+			log.Warn("failed to open module cache, compiled modules will not be persisted",
+				zap.String("dir", cfg.ModuleCacheDir),
+				zap.Error(err),
+			)
+		}
+		runtime.moduleCache = diskCache
+	}
+
 	hostImports.AddModule(NewLogModule())
 	hostImports.AddModule(NewBalanceModule())
+	hostImports.AddModule(NewAssetModule())
 	hostImports.AddModule(NewStateAccessModule())
 	hostImports.AddModule(NewContractModule(runtime))
 
@@ -87,6 +122,11 @@ func NewRuntime(
 	return runtime
 }
 
+// Config returns the configuration of the runtime
+func (r *WasmRuntime) Config() *Config {
+	return r.cfg
+}
+
 func (r *WasmRuntime) WithDefaults(callInfo CallInfo) CallContext {
 	return CallContext{r: r, defaultCallInfo: callInfo}
 }
@@ -95,15 +135,59 @@ func (r *WasmRuntime) getModule(ctx context.Context, callInfo *CallInfo, id []by
 	if mod, ok := r.contractCache.Get(string(id)); ok {
 		return mod, nil
 	}
+	if r.moduleCache != nil {
+		if mod, ok := r.moduleCache.get(r.engine, id); ok {
+			r.contractCache.Put(string(id), mod)
+			return mod, nil
+		}
+	}
 	contractBytes, err := callInfo.State.GetContractBytes(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	return r.compile(id, contractBytes)
+}
+
+// PrecompileContract compiles [contractBytes] in the background and caches
+// the module so the contract is not compiled when it is first called.
+// Compilation is skipped if the module is already cached in memory or on disk,
+// or if too many contracts are already being compiled, in which case the
+// contract is compiled when it is first called.
+func (r *WasmRuntime) PrecompileContract(id ContractID, contractBytes []byte) {
+	if _, ok := r.contractCache.Get(string(id)); ok {
+		return
+	}
+	if r.moduleCache != nil && r.moduleCache.has(id) {
+		return
+	}
+	select {
+	case r.precompiling <- struct{}{}:
+	default:
+		return
+	}
+
+	id = slices.Clone(id)
+	go func() {
+		defer func() { <-r.precompiling }()
+
+		if _, err := r.compile(id, contractBytes); err != nil {
+			// invalid contracts fail when they are called
+			r.log.Debug("failed to precompile contract", zap.Error(err))
+		}
+	}()
+}
+
+func (r *WasmRuntime) compile(id ContractID, contractBytes []byte) (*wasmtime.Module, error) {
 	mod, err := wasmtime.NewModule(r.engine, contractBytes)
 	if err != nil {
 		return nil, err
 	}
 	r.contractCache.Put(string(id), mod)
+	if r.moduleCache != nil {
+		if err := r.moduleCache.put(id, mod); err != nil {
+			r.log.Warn("failed to persist compiled module", zap.Error(err))
+		}
+	}
 	return mod, nil
 }
 
@@ -112,18 +196,31 @@ func (r *WasmRuntime) CallContract(ctx context.Context, callInfo *CallInfo) (res
 	if err != nil {
 		return nil, err
 	}
+	if callInfo.profile == nil && callInfo.caller == nil && r.cfg.EnableFuelProfiling {
+		callInfo.profile = newCallProfile(callInfo.Contract, callInfo.FunctionName)
+	}
+	if callInfo.profile != nil {
+		defer callInfo.profile.finish(callInfo, time.Now())
+	}
 	contractModule, err := r.getModule(ctx, callInfo, contractID)
 	if err != nil {
 		return nil, err
 	}
+	if callInfo.caller != nil && isNonReentrant(contractModule) && callInfo.caller.isExecuting(callInfo.Contract) {
+		return nil, ErrReentrantCall
+	}
 	inst, err := r.getInstance(contractModule)
 	if err != nil {
 		return nil, err
 	}
 	callInfo.inst = inst
+	if callInfo.upgrades == nil {
+		callInfo.upgrades = &[]ContractUpgrade{}
+	}
 
 	r.setCallInfo(inst.store, callInfo)
 	defer r.deleteCallInfo(inst.store)
+	defer callInfo.releaseIterators()
 
 	return inst.call(ctx, callInfo)
 }
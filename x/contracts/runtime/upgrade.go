@@ -0,0 +1,74 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+var ErrImmutableContract = errors.New("contract is immutable")
+
+// ContractUpgrade records the contract associated with an account being replaced
+type ContractUpgrade struct {
+	Account            codec.Address
+	PreviousContractID ContractID
+	ContractID         ContractID
+}
+
+// UpgradeAccountContract associates [account] with [contractID], replacing its
+// current contract. Returns ErrImmutableContract if [account] was deployed as
+// immutable.
+func UpgradeAccountContract(
+	ctx context.Context,
+	manager ContractManager,
+	account codec.Address,
+	contractID ContractID,
+) (ContractUpgrade, error) {
+	immutable, err := manager.IsAccountImmutable(ctx, account)
+	if err != nil {
+		return ContractUpgrade{}, err
+	}
+	if immutable {
+		return ContractUpgrade{}, ErrImmutableContract
+	}
+
+	previousContractID, err := manager.GetAccountContract(ctx, account)
+	if err != nil {
+		return ContractUpgrade{}, err
+	}
+
+	// ensure the new contract has been published
+	if _, err := manager.GetContractBytes(ctx, contractID); err != nil {
+		return ContractUpgrade{}, err
+	}
+
+	if err := manager.SetAccountContract(ctx, account, contractID); err != nil {
+		return ContractUpgrade{}, err
+	}
+
+	return ContractUpgrade{
+		Account:            account,
+		PreviousContractID: slices.Clone(previousContractID),
+		ContractID:         slices.Clone(contractID),
+	}, nil
+}
+
+// Upgrades returns the contract upgrades made by this call and any nested calls
+func (c *CallInfo) Upgrades() []ContractUpgrade {
+	if c.upgrades == nil {
+		return nil
+	}
+	return *c.upgrades
+}
+
+func (c *CallInfo) recordUpgrade(upgrade ContractUpgrade) {
+	if c.upgrades == nil {
+		c.upgrades = &[]ContractUpgrade{}
+	}
+	*c.upgrades = append(*c.upgrades, upgrade)
+}
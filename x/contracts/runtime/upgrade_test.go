@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/x/contracts/test"
+)
+
+func TestUpgradeAccountContract(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	v1 := ids.GenerateTestID()
+	v2 := ids.GenerateTestID()
+	manager := NewContractStateManager(test.NewTestDB(), []byte{})
+	r.NoError(manager.SetContractBytes(ctx, v1[:], []byte("v1")))
+	r.NoError(manager.SetContractBytes(ctx, v2[:], []byte("v2")))
+
+	account, err := manager.NewAccountWithContract(ctx, v1[:], []byte{})
+	r.NoError(err)
+
+	unknown := ids.GenerateTestID()
+	_, err = UpgradeAccountContract(ctx, manager, account, unknown[:])
+	r.ErrorIs(err, ErrUnknownAccount)
+
+	upgrade, err := UpgradeAccountContract(ctx, manager, account, v2[:])
+	r.NoError(err)
+	r.Equal(account, upgrade.Account)
+	r.Equal(ContractID(v1[:]), upgrade.PreviousContractID)
+	r.Equal(ContractID(v2[:]), upgrade.ContractID)
+
+	contractID, err := manager.GetAccountContract(ctx, account)
+	r.NoError(err)
+	r.Equal(ContractID(v2[:]), contractID)
+}
+
+func TestUpgradeAccountContractImmutable(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	v1 := ids.GenerateTestID()
+	v2 := ids.GenerateTestID()
+	manager := NewContractStateManager(test.NewTestDB(), []byte{})
+	r.NoError(manager.SetContractBytes(ctx, v1[:], []byte("v1")))
+	r.NoError(manager.SetContractBytes(ctx, v2[:], []byte("v2")))
+
+	account, err := manager.NewAccountWithContract(ctx, v1[:], []byte{})
+	r.NoError(err)
+
+	immutable, err := manager.IsAccountImmutable(ctx, account)
+	r.NoError(err)
+	r.False(immutable)
+
+	r.NoError(manager.SetAccountImmutable(ctx, account))
+	immutable, err = manager.IsAccountImmutable(ctx, account)
+	r.NoError(err)
+	r.True(immutable)
+
+	_, err = UpgradeAccountContract(ctx, manager, account, v2[:])
+	r.ErrorIs(err, ErrImmutableContract)
+}
@@ -69,6 +69,14 @@ func (t TestStateManager) SetAccountContract(_ context.Context, account codec.Ad
 	return t.ContractManager.SetAccountContract(context.Background(), account, contractID)
 }
 
+func (t TestStateManager) SetAccountImmutable(ctx context.Context, account codec.Address) error {
+	return t.ContractManager.SetAccountImmutable(ctx, account)
+}
+
+func (t TestStateManager) IsAccountImmutable(ctx context.Context, account codec.Address) (bool, error) {
+	return t.ContractManager.IsAccountImmutable(ctx, account)
+}
+
 func (t TestStateManager) GetBalance(_ context.Context, address codec.Address) (uint64, error) {
 	if balance, ok := t.Balances[address]; ok {
 		return balance, nil
@@ -177,6 +185,11 @@ func (t *testRuntime) WithValue(value uint64) *testRuntime {
 	return t
 }
 
+func (t *testRuntime) WithCallLimits(limits *CallLimits) *testRuntime {
+	t.callContext = t.callContext.WithCallLimits(limits)
+	return t
+}
+
 // AddContract compiles [contractName] and sets the bytes in the state manager
 func (t *testRuntime) AddContract(contractID ContractID, account codec.Address, contractName string) error {
 	err := t.StateManager.(TestStateManager).CompileAndSetContract(contractID, contractName)
@@ -111,3 +111,11 @@ func (p *ContractStateManager) SetAccountContract(ctx context.Context, account c
 func (p *ContractStateManager) SetContractBytes(ctx context.Context, contractID runtime.ContractID, contractBytes []byte) error {
 	return p.contractState.SetContractBytes(ctx, contractID, contractBytes)
 }
+
+func (p *ContractStateManager) SetAccountImmutable(ctx context.Context, account codec.Address) error {
+	return p.contractState.SetAccountImmutable(ctx, account)
+}
+
+func (p *ContractStateManager) IsAccountImmutable(ctx context.Context, account codec.Address) (bool, error) {
+	return p.contractState.IsAccountImmutable(ctx, account)
+}
@@ -1,7 +1,7 @@
 // Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
 // See the file LICENSE for licensing terms.
 
-use wasmlanche::{public, Address, Context, Gas};
+use wasmlanche::{public, Address, Context, ExternalCallError, Gas};
 
 #[public]
 pub fn simple_call(_: &mut Context) -> i64 {
@@ -14,6 +14,37 @@ pub fn simple_call_external(ctx: &mut Context, target: Address, max_units: Gas)
         .unwrap()
 }
 
+#[public]
+pub fn simple_call_checked(
+    ctx: &mut Context,
+    target: Address,
+    max_units: Gas,
+) -> Result<i64, ExternalCallError> {
+    ctx.call_contract(target, "simple_call", &[], max_units, 0)
+}
+
+/// Calls itself [depth] times and returns the number of nested calls made.
+#[public]
+pub fn call_self(ctx: &mut Context, depth: u32) -> Result<u32, ExternalCallError> {
+    // fuel left to this call to handle the result of the nested call
+    const RESERVED_FUEL: Gas = 1_000_000;
+
+    if depth == 0 {
+        return Ok(0);
+    }
+
+    let address = ctx.contract_address();
+    let max_units = ctx.remaining_fuel().saturating_sub(RESERVED_FUEL);
+    let nested = ctx.call_contract::<Result<u32, ExternalCallError>>(
+        address,
+        "call_self",
+        &(depth - 1).to_le_bytes(),
+        max_units,
+        0,
+    )??;
+    Ok(nested + 1)
+}
+
 #[public]
 pub fn actor_check(context: &mut Context) -> Address {
     context.actor()
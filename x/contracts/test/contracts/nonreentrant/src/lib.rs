@@ -0,0 +1,35 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+use wasmlanche::{borsh, nonreentrant, public, Address, Context, ExternalCallError, Gas};
+
+nonreentrant!();
+
+#[public]
+pub fn simple_call(_: &mut Context) -> i64 {
+    0
+}
+
+#[public]
+pub fn call_self(ctx: &mut Context, max_units: Gas) -> Result<i64, ExternalCallError> {
+    let address = ctx.contract_address();
+    ctx.call_contract(address, "simple_call", &[], max_units, 0)
+}
+
+/// Calls back into this contract through `simple_call_checked` of [through].
+#[public]
+pub fn call_through(
+    ctx: &mut Context,
+    through: Address,
+    max_units: Gas,
+) -> Result<i64, ExternalCallError> {
+    let args = borsh::to_vec(&(ctx.contract_address(), max_units / 2))
+        .expect("failed to serialize args");
+    ctx.call_contract::<Result<i64, ExternalCallError>>(
+        through,
+        "simple_call_checked",
+        &args,
+        max_units,
+        0,
+    )?
+}
@@ -5,6 +5,7 @@ use wasmlanche::{public, state_schema, Context};
 
 state_schema! {
     State => i64,
+    Item(u8) => i64,
 }
 
 /// Initializes the contract with a name, symbol, and total supply.
@@ -24,3 +25,20 @@ pub fn get(context: &mut Context) -> Option<i64> {
 pub fn delete(context: &mut Context) -> Option<i64> {
     context.delete(State).expect("failed to get state")
 }
+
+#[public]
+pub fn put_items(context: &mut Context, count: u8) {
+    for i in 0..count {
+        context
+            .store_by_key(Item(i), i64::from(i))
+            .expect("failed to store state");
+    }
+}
+
+#[public]
+pub fn sum_items(context: &mut Context) -> i64 {
+    context
+        .iter_values::<Item>()
+        .map(|value| value.expect("failed to deserialize state"))
+        .sum()
+}
@@ -6,6 +6,7 @@ package test
 import (
 	"context"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 
 	"github.com/ava-labs/hypersdk/state"
@@ -39,3 +40,7 @@ func (c *DB) Put(key []byte, value []byte) error {
 func (c *DB) Remove(_ context.Context, key []byte) error {
 	return c.db.Delete(key)
 }
+
+func (c *DB) NewIteratorWithPrefix(_ context.Context, prefix []byte) (database.Iterator, error) {
+	return c.db.NewIteratorWithPrefix(prefix), nil
+}
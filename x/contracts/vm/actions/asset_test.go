@@ -0,0 +1,188 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
+)
+
+func TestAssetActions(t *testing.T) {
+	owner := codectest.NewRandomAddress()
+	holder := codectest.NewRandomAddress()
+	assetID := ids.GenerateTestID()
+
+	// newAssetState returns state with [assetID] owned by [owner] and [holder]
+	// holding 10 of its supply of 10
+	newAssetState := func() state.Mutable {
+		ctx := context.Background()
+		store := chaintest.NewInMemoryStore()
+		require.NoError(t, storage.SetAsset(ctx, store, assetID, &storage.Asset{
+			Owner:  owner,
+			Name:   []byte("Asset"),
+			Symbol: []byte("AST"),
+			Supply: 10,
+		}))
+		_, err := storage.AddAssetBalance(ctx, store, assetID, holder, 10)
+		require.NoError(t, err)
+		return store
+	}
+
+	tests := []chaintest.ActionTest{
+		{
+			Name:  "CreateAsset",
+			Actor: owner,
+			Action: &CreateAsset{
+				Name:     []byte("Asset"),
+				Symbol:   []byte("AST"),
+				Decimals: 9,
+				Metadata: []byte("metadata"),
+			},
+			ActionID:        assetID,
+			State:           chaintest.NewInMemoryStore(),
+			ExpectedOutputs: &CreateAssetResult{AssetID: assetID},
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				asset, err := storage.GetAsset(ctx, store, assetID)
+				require.NoError(t, err)
+				require.Equal(t, &storage.Asset{
+					Owner:    owner,
+					Name:     []byte("Asset"),
+					Symbol:   []byte("AST"),
+					Decimals: 9,
+					Metadata: []byte("metadata"),
+				}, asset)
+			},
+		},
+		{
+			Name:            "CreateExistingAsset",
+			Actor:           owner,
+			Action:          &CreateAsset{Name: []byte("Asset"), Symbol: []byte("AST")},
+			ActionID:        assetID,
+			State:           newAssetState(),
+			ExpectedErr:     ErrAssetExists,
+			ExpectedOutputs: nil,
+		},
+		{
+			Name:        "CreateAssetTooManyDecimals",
+			Actor:       owner,
+			Action:      &CreateAsset{Name: []byte("Asset"), Symbol: []byte("AST"), Decimals: MaxAssetDecimals + 1},
+			ActionID:    assetID,
+			State:       chaintest.NewInMemoryStore(),
+			ExpectedErr: ErrAssetDecimalsHigh,
+		},
+		{
+			Name:   "MintAsset",
+			Actor:  owner,
+			Action: &MintAsset{To: holder, Asset: assetID, Value: 5},
+			State:  newAssetState(),
+			ExpectedOutputs: &MintAssetResult{
+				ReceiverBalance: 15,
+				Supply:          15,
+			},
+		},
+		{
+			Name:        "MintAssetWrongOwner",
+			Actor:       holder,
+			Action:      &MintAsset{To: holder, Asset: assetID, Value: 5},
+			State:       newAssetState(),
+			ExpectedErr: ErrWrongAssetOwner,
+		},
+		{
+			Name:        "MintMissingAsset",
+			Actor:       owner,
+			Action:      &MintAsset{To: holder, Asset: ids.GenerateTestID(), Value: 5},
+			State:       newAssetState(),
+			ExpectedErr: storage.ErrAssetNotFound,
+		},
+		{
+			Name:   "BurnAsset",
+			Actor:  holder,
+			Action: &BurnAsset{Asset: assetID, Value: 4},
+			State:  newAssetState(),
+			ExpectedOutputs: &BurnAssetResult{
+				SenderBalance: 6,
+				Supply:        6,
+			},
+		},
+		{
+			Name:        "BurnAssetInsufficientBalance",
+			Actor:       holder,
+			Action:      &BurnAsset{Asset: assetID, Value: 11},
+			State:       newAssetState(),
+			ExpectedErr: storage.ErrInvalidBalance,
+		},
+		{
+			Name:   "TransferAsset",
+			Actor:  holder,
+			Action: &TransferAsset{To: owner, Asset: assetID, Value: 10},
+			State:  newAssetState(),
+			Assertion: func(ctx context.Context, t *testing.T, store state.Mutable) {
+				balance, err := storage.GetAssetBalance(ctx, store, assetID, owner)
+				require.NoError(t, err)
+				require.Equal(t, uint64(10), balance)
+				_, err = store.GetValue(ctx, storage.AssetBalanceKey(assetID, holder))
+				require.Error(t, err)
+			},
+			ExpectedOutputs: &TransferAssetResult{
+				SenderBalance:   0,
+				ReceiverBalance: 10,
+			},
+		},
+		{
+			Name:        "TransferAssetZero",
+			Actor:       holder,
+			Action:      &TransferAsset{To: owner, Asset: assetID},
+			State:       newAssetState(),
+			ExpectedErr: ErrOutputValueZero,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.Run(context.Background(), t)
+	}
+}
+
+func TestAssetActionsMarshal(t *testing.T) {
+	require := require.New(t)
+
+	for _, action := range []interface {
+		Size() int
+		Marshal(*codec.Packer)
+	}{
+		&CreateAsset{Name: []byte("Asset"), Symbol: []byte("AST"), Decimals: 9, Metadata: []byte("metadata")},
+		&MintAsset{To: codectest.NewRandomAddress(), Asset: ids.GenerateTestID(), Value: 1},
+		&BurnAsset{Asset: ids.GenerateTestID(), Value: 1},
+		&TransferAsset{To: codectest.NewRandomAddress(), Asset: ids.GenerateTestID(), Value: 1, Memo: []byte("memo")},
+	} {
+		p := codec.NewWriter(action.Size(), action.Size())
+		action.Marshal(p)
+		require.NoError(p.Err())
+		require.Len(p.Bytes(), action.Size())
+
+		var unmarshaled any
+		var err error
+		r := codec.NewReader(p.Bytes(), len(p.Bytes()))
+		switch action.(type) {
+		case *CreateAsset:
+			unmarshaled, err = UnmarshalCreateAsset(r)
+		case *MintAsset:
+			unmarshaled, err = UnmarshalMintAsset(r)
+		case *BurnAsset:
+			unmarshaled, err = UnmarshalBurnAsset(r)
+		case *TransferAsset:
+			unmarshaled, err = UnmarshalTransferAsset(r)
+		}
+		require.NoError(err)
+		require.Equal(action, unmarshaled)
+	}
+}
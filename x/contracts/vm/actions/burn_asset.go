@@ -0,0 +1,110 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	mconsts "github.com/ava-labs/hypersdk/x/contracts/vm/consts"
+)
+
+const BurnAssetComputeUnits = 1
+
+var _ chain.Action = (*BurnAsset)(nil)
+
+// BurnAsset destroys [Value] of [Asset] held by the actor
+type BurnAsset struct {
+	Asset ids.ID `serialize:"true" json:"asset"`
+	Value uint64 `serialize:"true" json:"value"`
+}
+
+func (*BurnAsset) GetTypeID() uint8 {
+	return mconsts.BurnAssetID
+}
+
+func (b *BurnAsset) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(b.Asset)):               state.Read | state.Write,
+		string(storage.AssetBalanceKey(b.Asset, actor)): state.Read | state.Write,
+	}
+}
+
+func (b *BurnAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if b.Value == 0 {
+		return nil, ErrOutputValueZero
+	}
+	asset, err := storage.GetAsset(ctx, mu, b.Asset)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := storage.SubAssetBalance(ctx, mu, b.Asset, actor, b.Value)
+	if err != nil {
+		return nil, err
+	}
+	asset.Supply, err = smath.Sub(asset.Supply, b.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetAsset(ctx, mu, b.Asset, asset); err != nil {
+		return nil, err
+	}
+	return &BurnAssetResult{
+		SenderBalance: balance,
+		Supply:        asset.Supply,
+	}, nil
+}
+
+func (*BurnAsset) ComputeUnits(chain.Rules) uint64 {
+	return BurnAssetComputeUnits
+}
+
+func (*BurnAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+var _ chain.Marshaler = (*BurnAsset)(nil)
+
+func (*BurnAsset) Size() int {
+	return ids.IDLen + consts.Uint64Len
+}
+
+func (b *BurnAsset) Marshal(p *codec.Packer) {
+	p.PackID(b.Asset)
+	p.PackLong(b.Value)
+}
+
+func UnmarshalBurnAsset(p *codec.Packer) (chain.Action, error) {
+	var burn BurnAsset
+	p.UnpackID(true, &burn.Asset)
+	burn.Value = p.UnpackUint64(true)
+	return &burn, p.Err()
+}
+
+var _ codec.Typed = (*BurnAssetResult)(nil)
+
+type BurnAssetResult struct {
+	SenderBalance uint64 `serialize:"true" json:"sender_balance"`
+	Supply        uint64 `serialize:"true" json:"supply"`
+}
+
+func (*BurnAssetResult) GetTypeID() uint8 {
+	return mconsts.BurnAssetOutputID
+}
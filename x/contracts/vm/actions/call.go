@@ -5,6 +5,7 @@ package actions
 
 import (
 	"context"
+	"math"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -19,7 +20,10 @@ import (
 	mconsts "github.com/ava-labs/hypersdk/x/contracts/vm/consts"
 )
 
-var _ chain.Action = (*Call)(nil)
+var (
+	_ chain.Action               = (*Call)(nil)
+	_ chain.ComputeUnitsReporter = (*Result)(nil)
+)
 
 const (
 	MaxCallDataSize    = units.MiB
@@ -29,6 +33,68 @@ const (
 type StateKeyPermission struct {
 	Key        string
 	Permission state.Permissions
+	// Prefix declares a range of keys beginning with [Key] that the contract
+	// may iterate over. Only keys in the range that are also declared are
+	// returned by iterators.
+	Prefix bool
+}
+
+// specifiedStateKeys returns the state keys of [permissions]. Prefix ranges
+// are locked by their PrefixRangeKey.
+func specifiedStateKeys(permissions []StateKeyPermission) state.Keys {
+	result := state.Keys{}
+	for _, stateKeyPermission := range permissions {
+		if stateKeyPermission.Prefix {
+			result.Add(string(storage.PrefixRangeKey([]byte(stateKeyPermission.Key))), stateKeyPermission.Permission)
+			continue
+		}
+		result.Add(stateKeyPermission.Key, stateKeyPermission.Permission)
+	}
+	return result
+}
+
+// newContractStateManager returns the state of a contract call that may
+// access [permissions]
+func newContractStateManager(mu state.Mutable, permissions []StateKeyPermission) *storage.ContractStateManager {
+	stateManager := &storage.ContractStateManager{Mutable: mu, Keys: state.Keys{}}
+	for _, stateKeyPermission := range permissions {
+		if !stateKeyPermission.Prefix {
+			stateManager.Keys.Add(stateKeyPermission.Key, stateKeyPermission.Permission)
+		} else if stateKeyPermission.Permission.Has(state.Read) {
+			stateManager.PrefixRanges = append(stateManager.PrefixRanges, []byte(stateKeyPermission.Key))
+		}
+	}
+	return stateManager
+}
+
+func marshalStateKeys(p *codec.Packer, permissions []StateKeyPermission) {
+	p.PackInt(uint32(len(permissions)))
+	for _, stateKeyPermission := range permissions {
+		p.PackString(stateKeyPermission.Key)
+		p.PackByte(byte(stateKeyPermission.Permission))
+		p.PackBool(stateKeyPermission.Prefix)
+	}
+}
+
+func unmarshalStateKeys(p *codec.Packer, required bool) []StateKeyPermission {
+	count := int(p.UnpackInt(required))
+	permissions := make([]StateKeyPermission, count)
+	for i := 0; i < count; i++ {
+		key := p.UnpackString(true)
+		value := p.UnpackByte()
+		prefix := p.UnpackBool()
+		permissions[i] = StateKeyPermission{Key: key, Permission: state.Permissions(value), Prefix: prefix}
+	}
+	return permissions
+}
+
+// stateKeyPermissionsSize returns the encoded size of [stateKeys]
+func stateKeyPermissionsSize(stateKeys []StateKeyPermission) int {
+	size := consts.Uint32Len
+	for _, stateKey := range stateKeys {
+		size += codec.StringLen(stateKey.Key) + consts.ByteLen + consts.BoolLen
+	}
+	return size
 }
 
 type Call struct {
@@ -56,45 +122,64 @@ func (*Call) GetTypeID() uint8 {
 }
 
 func (t *Call) StateKeys(_ codec.Address, _ ids.ID) state.Keys {
-	result := state.Keys{}
-	for _, stateKeyPermission := range t.SpecifiedStateKeys {
-		result.Add(stateKeyPermission.Key, stateKeyPermission.Permission)
-	}
-	return result
+	return specifiedStateKeys(t.SpecifiedStateKeys)
 }
 
 func (t *Call) Execute(
 	ctx context.Context,
-	_ chain.Rules,
+	rules chain.Rules,
 	mu state.Mutable,
 	timestamp int64,
 	actor codec.Address,
 	_ ids.ID,
 ) (codec.Typed, error) {
+	fuelSchedule, err := runtime.GetFuelSchedule(rules)
+	if err != nil {
+		return nil, err
+	}
+	callLimits, err := runtime.GetCallLimits(rules)
+	if err != nil {
+		return nil, err
+	}
 	callInfo := &runtime.CallInfo{
 		Contract:     t.ContractAddress,
 		Actor:        actor,
-		State:        &storage.ContractStateManager{Mutable: mu},
+		State:        newContractStateManager(mu, t.SpecifiedStateKeys),
 		FunctionName: t.Function,
 		Params:       t.CallData,
 		Timestamp:    uint64(timestamp),
 		Fuel:         t.Fuel,
 		Value:        t.Value,
+		FuelSchedule: fuelSchedule,
+		CallLimits:   callLimits,
 	}
 	resultBytes, err := t.r.CallContract(ctx, callInfo)
 	if err != nil {
 		return nil, err
 	}
 	consumedFuel := t.Fuel - callInfo.RemainingFuel()
-	return &Result{Value: resultBytes, ConsumedFuel: consumedFuel}, nil
+	result := &Result{
+		Value:        resultBytes,
+		ConsumedFuel: consumedFuel,
+		ComputeUnits: fuelSchedule.ComputeUnits(consumedFuel),
+	}
+	for _, upgrade := range callInfo.Upgrades() {
+		result.Upgrades = append(result.Upgrades, newUpgradeOutput(upgrade))
+	}
+	return result, nil
 }
 
-func (t *Call) ComputeUnits(chain.Rules) uint64 {
-	return t.Fuel / 1000
+// ComputeUnits charges for the maximum fuel the call may consume. The compute
+// units of the fuel actually consumed are reported in the Result and the fee
+// of the rest is refunded. Fuel is rounded up to whole compute units, so calls
+// consuming less than [runtime.FuelSchedule.FuelPerComputeUnit] fuel are not
+// free.
+func (t *Call) ComputeUnits(rules chain.Rules) uint64 {
+	return fuelComputeUnits(rules, t.Fuel)
 }
 
 func (t *Call) Size() int {
-	return codec.AddressLen + 2*consts.Uint64Len + len(t.CallData) + len(t.Function) + len(t.SpecifiedStateKeys)
+	return codec.AddressLen + 2*consts.Uint64Len + codec.StringLen(t.Function) + codec.BytesLen(t.CallData) + stateKeyPermissionsSize(t.SpecifiedStateKeys)
 }
 
 func (t *Call) Marshal(p *codec.Packer) {
@@ -103,12 +188,7 @@ func (t *Call) Marshal(p *codec.Packer) {
 	p.PackAddress(t.ContractAddress)
 	p.PackString(t.Function)
 	p.PackBytes(t.CallData)
-	p.PackInt(uint32(len(t.SpecifiedStateKeys)))
-
-	for _, stateKeyPermission := range t.SpecifiedStateKeys {
-		p.PackString(stateKeyPermission.Key)
-		p.PackByte(byte(stateKeyPermission.Permission))
-	}
+	marshalStateKeys(p, t.SpecifiedStateKeys)
 }
 
 func UnmarshalCallContract(r *runtime.WasmRuntime) func(p *codec.Packer) (chain.Action, error) {
@@ -122,13 +202,7 @@ func UnmarshalCallContract(r *runtime.WasmRuntime) func(p *codec.Packer) (chain.
 		if err := p.Err(); err != nil {
 			return nil, err
 		}
-		count := int(p.UnpackInt(true))
-		callContract.SpecifiedStateKeys = make([]StateKeyPermission, count)
-		for i := 0; i < count; i++ {
-			key := p.UnpackString(true)
-			value := p.UnpackByte()
-			callContract.SpecifiedStateKeys[i] = StateKeyPermission{Key: key, Permission: state.Permissions(value)}
-		}
+		callContract.SpecifiedStateKeys = unmarshalStateKeys(p, true)
 		return &callContract, nil
 	}
 }
@@ -141,8 +215,30 @@ func (*Call) ValidRange(chain.Rules) (int64, int64) {
 type Result struct {
 	Value        []byte `serialize:"true" json:"value"`
 	ConsumedFuel uint64 `serialize:"true" json:"consumedfuel"`
+	// ComputeUnits are the compute units of the consumed fuel
+	ComputeUnits uint64 `serialize:"true" json:"computeUnits"`
+	// Upgrades are the contract upgrades made during the call
+	Upgrades []*UpgradeOutput `serialize:"true" json:"upgrades"`
 }
 
 func (*Result) GetTypeID() uint8 {
 	return mconsts.ResultOutputID
 }
+
+// ComputeUnitsConsumed reports the compute units of the consumed fuel, so the
+// fee of the unconsumed fuel is refunded
+func (r *Result) ComputeUnitsConsumed() uint64 {
+	return r.ComputeUnits
+}
+
+// fuelComputeUnits returns the compute units of [fuel] under the fuel schedule
+// of [rules]. The schedule is verified when the rules are loaded, so an
+// invalid schedule should never be found here. If it is, the maximum compute
+// units are returned so the transaction can never be included.
+func fuelComputeUnits(rules chain.Rules, fuel uint64) uint64 {
+	fuelSchedule, err := runtime.GetFuelSchedule(rules)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return fuelSchedule.ComputeUnits(fuel)
+}
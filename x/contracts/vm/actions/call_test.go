@@ -8,11 +8,15 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/hypersdk/chain/chaintest"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/state/tstate"
+	"github.com/ava-labs/hypersdk/x/contracts/runtime"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
 )
 
 func TestCallAction(t *testing.T) {
@@ -35,3 +39,108 @@ func TestCallAction(t *testing.T) {
 		tt.Run(context.Background(), t)
 	}
 }
+
+func TestContractActionsMarshal(t *testing.T) {
+	require := require.New(t)
+
+	stateKeys := []StateKeyPermission{
+		{Key: "key1", Permission: state.Read},
+		{Key: "longer key2", Permission: state.Read | state.Write},
+		{Key: "prefix", Permission: state.Read, Prefix: true},
+	}
+	for _, action := range []interface {
+		Size() int
+		Marshal(*codec.Packer)
+	}{
+		&Call{
+			ContractAddress:    codectest.NewRandomAddress(),
+			Value:              1,
+			Function:           "function",
+			CallData:           []byte("call data"),
+			SpecifiedStateKeys: stateKeys,
+			Fuel:               2,
+		},
+		&Upgrade{
+			ContractAddress:    codectest.NewRandomAddress(),
+			ContractID:         []byte("contract id"),
+			SpecifiedStateKeys: stateKeys,
+			Fuel:               1,
+		},
+	} {
+		p := codec.NewWriter(action.Size(), action.Size())
+		action.Marshal(p)
+		require.NoError(p.Err())
+		require.Len(p.Bytes(), action.Size())
+
+		var unmarshaled any
+		var err error
+		r := codec.NewReader(p.Bytes(), len(p.Bytes()))
+		switch action.(type) {
+		case *Call:
+			unmarshaled, err = UnmarshalCallContract(nil)(r)
+		case *Upgrade:
+			unmarshaled, err = UnmarshalUpgradeContract(nil)(r)
+		}
+		require.NoError(err)
+		require.Equal(action, unmarshaled)
+	}
+}
+
+func TestCallStateKeysPrefixRange(t *testing.T) {
+	require := require.New(t)
+
+	call := &Call{
+		ContractAddress: codec.CreateAddress(0, ids.GenerateTestID()),
+		Function:        "iterate",
+		Fuel:            1000,
+		SpecifiedStateKeys: []StateKeyPermission{
+			{Key: "key", Permission: state.Read},
+			{Key: "prefix", Permission: state.Read, Prefix: true},
+		},
+	}
+	require.Equal(state.Keys{
+		"key": state.Read,
+		string(storage.PrefixRangeKey([]byte("prefix"))): state.Read,
+	}, call.StateKeys(codec.EmptyAddress, ids.Empty))
+}
+
+func TestContractStateIteratePrefix(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	addr := codec.CreateAddress(0, ids.GenerateTestID())
+	prefix := string(storage.AccountStateKey(addr)) + "a"
+	keys := []string{prefix + "2", prefix + "1", prefix + "3", string(storage.AccountStateKey(addr)) + "b"}
+
+	ts := tstate.New(1)
+	stateKeys := state.Keys{}
+	for _, key := range keys {
+		stateKeys.Add(key, state.All)
+	}
+	mu := ts.NewView(stateKeys, map[string][]byte{
+		keys[0]: {2},
+		keys[1]: {1},
+		keys[3]: {4},
+	})
+
+	permissions := []StateKeyPermission{{Key: prefix, Permission: state.Read, Prefix: true}}
+	for _, key := range keys {
+		permissions = append(permissions, StateKeyPermission{Key: key, Permission: state.All})
+	}
+	stateManager := newContractStateManager(mu, permissions)
+	contractState := stateManager.GetContractState(addr).(runtime.PrefixIterable)
+
+	iter, err := contractState.NewIteratorWithPrefix(ctx, []byte("a"))
+	require.NoError(err)
+	defer iter.Release()
+
+	values := [][]byte{}
+	for iter.Next() {
+		values = append(values, iter.Value())
+	}
+	require.NoError(iter.Error())
+	require.Equal([][]byte{{1}, {2}}, values)
+
+	_, err = contractState.NewIteratorWithPrefix(ctx, []byte("b"))
+	require.ErrorIs(err, storage.ErrUndeclaredPrefix)
+}
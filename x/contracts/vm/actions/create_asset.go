@@ -0,0 +1,128 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
+
+	mconsts "github.com/ava-labs/hypersdk/x/contracts/vm/consts"
+)
+
+const (
+	CreateAssetComputeUnits = 1
+	MaxAssetDecimals        = 18
+)
+
+var (
+	ErrAssetNameEmpty    = errors.New("asset name is empty")
+	ErrAssetSymbolEmpty  = errors.New("asset symbol is empty")
+	ErrAssetDecimalsHigh = errors.New("asset decimals are too high")
+	ErrAssetExists       = errors.New("asset already exists")
+
+	_ chain.Action = (*CreateAsset)(nil)
+)
+
+// CreateAsset creates a native asset owned by the actor. The ID of the asset
+// is the ID of the action.
+type CreateAsset struct {
+	Name     []byte `serialize:"true" json:"name"`
+	Symbol   []byte `serialize:"true" json:"symbol"`
+	Decimals uint8  `serialize:"true" json:"decimals"`
+	Metadata []byte `serialize:"true" json:"metadata"`
+}
+
+func (*CreateAsset) GetTypeID() uint8 {
+	return mconsts.CreateAssetID
+}
+
+func (*CreateAsset) StateKeys(_ codec.Address, actionID ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(actionID)): state.All,
+	}
+}
+
+func (c *CreateAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	actionID ids.ID,
+) (codec.Typed, error) {
+	if len(c.Name) == 0 {
+		return nil, ErrAssetNameEmpty
+	}
+	if len(c.Symbol) == 0 {
+		return nil, ErrAssetSymbolEmpty
+	}
+	if c.Decimals > MaxAssetDecimals {
+		return nil, ErrAssetDecimalsHigh
+	}
+	if _, err := storage.GetAsset(ctx, mu, actionID); !errors.Is(err, storage.ErrAssetNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrAssetExists
+	}
+	if err := storage.SetAsset(ctx, mu, actionID, &storage.Asset{
+		Owner:    actor,
+		Name:     c.Name,
+		Symbol:   c.Symbol,
+		Decimals: c.Decimals,
+		Metadata: c.Metadata,
+	}); err != nil {
+		return nil, err
+	}
+	return &CreateAssetResult{AssetID: actionID}, nil
+}
+
+func (*CreateAsset) ComputeUnits(chain.Rules) uint64 {
+	return CreateAssetComputeUnits
+}
+
+func (*CreateAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+var _ chain.Marshaler = (*CreateAsset)(nil)
+
+func (c *CreateAsset) Size() int {
+	return codec.BytesLen(c.Name) + codec.BytesLen(c.Symbol) + consts.Uint8Len + codec.BytesLen(c.Metadata)
+}
+
+func (c *CreateAsset) Marshal(p *codec.Packer) {
+	p.PackBytes(c.Name)
+	p.PackBytes(c.Symbol)
+	p.PackByte(c.Decimals)
+	p.PackBytes(c.Metadata)
+}
+
+func UnmarshalCreateAsset(p *codec.Packer) (chain.Action, error) {
+	var create CreateAsset
+	p.UnpackBytes(storage.MaxAssetNameSize, true, &create.Name)
+	p.UnpackBytes(storage.MaxAssetSymbolSize, true, &create.Symbol)
+	create.Decimals = p.UnpackByte()
+	p.UnpackBytes(storage.MaxAssetMetadataSize, false, &create.Metadata)
+	return &create, p.Err()
+}
+
+var _ codec.Typed = (*CreateAssetResult)(nil)
+
+type CreateAssetResult struct {
+	AssetID ids.ID `serialize:"true" json:"assetID"`
+}
+
+func (*CreateAssetResult) GetTypeID() uint8 {
+	return mconsts.CreateAssetOutputID
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/keys"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/x/contracts/runtime"
@@ -26,7 +27,9 @@ const MAXCREATIONSIZE = units.MiB
 type Deploy struct {
 	ContractID   runtime.ContractID `json:"contractID"`
 	CreationInfo []byte             `json:"creationInfo"`
-	address      codec.Address
+	// Immutable prevents the deployed account from ever being upgraded
+	Immutable bool `json:"immutable"`
+	address   codec.Address
 }
 
 func (*Deploy) GetTypeID() uint8 {
@@ -38,9 +41,14 @@ func (d *Deploy) StateKeys(_ codec.Address, _ ids.ID) state.Keys {
 		d.address = storage.GetAddressForDeploy(0, d.CreationInfo)
 	}
 	stateKey, _ := keys.Encode(storage.AccountContractKey(d.address), 36)
-	return state.Keys{
+	stateKeys := state.Keys{
 		string(stateKey): state.All,
 	}
+	if d.Immutable {
+		immutableKey, _ := keys.Encode(storage.AccountImmutableKey(d.address), 1)
+		stateKeys[string(immutableKey)] = state.All
+	}
+	return stateKeys
 }
 
 func (d *Deploy) Execute(
@@ -51,9 +59,17 @@ func (d *Deploy) Execute(
 	_ codec.Address,
 	_ ids.ID,
 ) (codec.Typed, error) {
-	result, err := (&storage.ContractStateManager{Mutable: mu}).
-		NewAccountWithContract(ctx, d.ContractID, d.CreationInfo)
-	return &AddressOutput{Address: result}, err
+	stateManager := &storage.ContractStateManager{Mutable: mu}
+	result, err := stateManager.NewAccountWithContract(ctx, d.ContractID, d.CreationInfo)
+	if err != nil {
+		return nil, err
+	}
+	if d.Immutable {
+		if err := stateManager.SetAccountImmutable(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+	return &AddressOutput{Address: result}, nil
 }
 
 func (*Deploy) ComputeUnits(chain.Rules) uint64 {
@@ -61,18 +77,20 @@ func (*Deploy) ComputeUnits(chain.Rules) uint64 {
 }
 
 func (d *Deploy) Size() int {
-	return len(d.CreationInfo) + len(d.ContractID)
+	return len(d.CreationInfo) + len(d.ContractID) + consts.BoolLen
 }
 
 func (d *Deploy) Marshal(p *codec.Packer) {
 	p.PackBytes(d.ContractID)
 	p.PackBytes(d.CreationInfo)
+	p.PackBool(d.Immutable)
 }
 
 func UnmarshalDeployContract(p *codec.Packer) (chain.Action, error) {
 	var deployContract Deploy
 	p.UnpackBytes(36, true, (*[]byte)(&deployContract.ContractID))
 	p.UnpackBytes(MAXCREATIONSIZE, false, &deployContract.CreationInfo)
+	deployContract.Immutable = p.UnpackBool()
 	deployContract.address = storage.GetAddressForDeploy(0, deployContract.CreationInfo)
 	if err := p.Err(); err != nil {
 		return nil, err
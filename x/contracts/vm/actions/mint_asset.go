@@ -0,0 +1,122 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	mconsts "github.com/ava-labs/hypersdk/x/contracts/vm/consts"
+)
+
+const MintAssetComputeUnits = 1
+
+var (
+	ErrWrongAssetOwner = errors.New("actor is not the owner of the asset")
+
+	_ chain.Action = (*MintAsset)(nil)
+)
+
+// MintAsset creates [Value] of [Asset] for [To]. Only the owner of the asset
+// can mint it.
+type MintAsset struct {
+	To    codec.Address `serialize:"true" json:"to"`
+	Asset ids.ID        `serialize:"true" json:"asset"`
+	Value uint64        `serialize:"true" json:"value"`
+}
+
+func (*MintAsset) GetTypeID() uint8 {
+	return mconsts.MintAssetID
+}
+
+func (m *MintAsset) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(m.Asset)):              state.Read | state.Write,
+		string(storage.AssetBalanceKey(m.Asset, m.To)): state.All,
+	}
+}
+
+func (m *MintAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if m.Value == 0 {
+		return nil, ErrOutputValueZero
+	}
+	asset, err := storage.GetAsset(ctx, mu, m.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if asset.Owner != actor {
+		return nil, ErrWrongAssetOwner
+	}
+	asset.Supply, err = smath.Add(asset.Supply, m.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetAsset(ctx, mu, m.Asset, asset); err != nil {
+		return nil, err
+	}
+	balance, err := storage.AddAssetBalance(ctx, mu, m.Asset, m.To, m.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &MintAssetResult{
+		ReceiverBalance: balance,
+		Supply:          asset.Supply,
+	}, nil
+}
+
+func (*MintAsset) ComputeUnits(chain.Rules) uint64 {
+	return MintAssetComputeUnits
+}
+
+func (*MintAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+var _ chain.Marshaler = (*MintAsset)(nil)
+
+func (*MintAsset) Size() int {
+	return codec.AddressLen + ids.IDLen + consts.Uint64Len
+}
+
+func (m *MintAsset) Marshal(p *codec.Packer) {
+	p.PackAddress(m.To)
+	p.PackID(m.Asset)
+	p.PackLong(m.Value)
+}
+
+func UnmarshalMintAsset(p *codec.Packer) (chain.Action, error) {
+	var mint MintAsset
+	p.UnpackAddress(&mint.To)
+	p.UnpackID(true, &mint.Asset)
+	mint.Value = p.UnpackUint64(true)
+	return &mint, p.Err()
+}
+
+var _ codec.Typed = (*MintAssetResult)(nil)
+
+type MintAssetResult struct {
+	ReceiverBalance uint64 `serialize:"true" json:"receiver_balance"`
+	Supply          uint64 `serialize:"true" json:"supply"`
+}
+
+func (*MintAssetResult) GetTypeID() uint8 {
+	return mconsts.MintAssetOutputID
+}
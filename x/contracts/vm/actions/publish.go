@@ -6,10 +6,14 @@ package actions
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
 
+	"github.com/ava-labs/hypersdk/abi"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/keys"
@@ -20,13 +24,21 @@ import (
 	mconsts "github.com/ava-labs/hypersdk/x/contracts/vm/consts"
 )
 
-var _ chain.Action = (*Publish)(nil)
+var (
+	_ chain.Action = (*Publish)(nil)
+
+	ErrInvalidContractABI = errors.New("invalid contract abi")
+)
 
 const MAXCONTRACTSIZE = 2 * units.MiB
 
 type Publish struct {
 	ContractBytes []byte `json:"contractBytes"`
-	id            runtime.ContractID
+	// ABI is the optional JSON encoded abi.ContractABI of the contract
+	ABI []byte `json:"abi"`
+	id  runtime.ContractID
+
+	r *runtime.WasmRuntime
 }
 
 func (*Publish) GetTypeID() uint8 {
@@ -38,9 +50,17 @@ func (t *Publish) StateKeys(_ codec.Address, _ ids.ID) state.Keys {
 		hashedID := sha256.Sum256(t.ContractBytes)
 		t.id, _ = keys.Encode(storage.ContractsKey(hashedID[:]), len(t.ContractBytes))
 	}
-	return state.Keys{
+	stateKeys := state.Keys{
 		string(t.id): state.Write | state.Allocate,
 	}
+	if len(t.ABI) > 0 {
+		// The ABI key is sized from the published ABI, so its number of
+		// chunks is stored alongside it for readers
+		chunks, _ := keys.NumChunks(t.ABI)
+		stateKeys.Add(string(storage.ContractABIChunksKey(t.id)), state.Write|state.Allocate)
+		stateKeys.Add(string(storage.ContractABIKey(t.id, chunks)), state.Write|state.Allocate)
+	}
+	return stateKeys
 }
 
 func (t *Publish) Execute(
@@ -51,10 +71,30 @@ func (t *Publish) Execute(
 	_ codec.Address,
 	_ ids.ID,
 ) (codec.Typed, error) {
+	if len(t.ABI) > 0 {
+		var contractABI abi.ContractABI
+		if err := json.Unmarshal(t.ABI, &contractABI); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidContractABI, err)
+		}
+		// Clients build types from the names in the ABI
+		if err := contractABI.Verify(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidContractABI, err)
+		}
+	}
 	resultBytes, err := storage.StoreContract(ctx, mu, t.ContractBytes)
 	if err != nil {
 		return nil, err
 	}
+	if len(t.ABI) > 0 {
+		if err := storage.StoreContractABI(ctx, mu, resultBytes, t.ABI); err != nil {
+			return nil, err
+		}
+	}
+	if t.r != nil && t.r.Config().PrecompileOnPublish {
+		// compilation happens in the background to keep it off the execution
+		// path
+		t.r.PrecompileContract(resultBytes, t.ContractBytes)
+	}
 	return &Result{Value: resultBytes}, nil
 }
 
@@ -63,21 +103,25 @@ func (*Publish) ComputeUnits(chain.Rules) uint64 {
 }
 
 func (t *Publish) Size() int {
-	return 4 + len(t.ContractBytes)
+	return 4 + len(t.ContractBytes) + 4 + len(t.ABI)
 }
 
 func (t *Publish) Marshal(p *codec.Packer) {
 	p.PackBytes(t.ContractBytes)
+	p.PackBytes(t.ABI)
 }
 
-func UnmarshalPublishContract(p *codec.Packer) (chain.Action, error) {
-	var publishContract Publish
-	p.UnpackBytes(MAXCONTRACTSIZE, true, &publishContract.ContractBytes)
-	if err := p.Err(); err != nil {
-		return nil, err
-	}
+func UnmarshalPublishContract(r *runtime.WasmRuntime) func(p *codec.Packer) (chain.Action, error) {
+	return func(p *codec.Packer) (chain.Action, error) {
+		publishContract := Publish{r: r}
+		p.UnpackBytes(MAXCONTRACTSIZE, true, &publishContract.ContractBytes)
+		p.UnpackBytes(storage.MaxContractABISize, false, &publishContract.ABI)
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
 
-	return &publishContract, nil
+		return &publishContract, nil
+	}
 }
 
 func (*Publish) ValidRange(chain.Rules) (int64, int64) {
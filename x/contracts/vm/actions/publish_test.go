@@ -0,0 +1,78 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/abi"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/codectest"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
+)
+
+func TestPublishContractABI(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	store := chaintest.NewInMemoryStore()
+
+	publish := &Publish{
+		ContractBytes: []byte("contract"),
+		ABI:           []byte(`{"functions":[{"name":"get_value","args":[],"output":"int64"}],"types":[]}`),
+	}
+	stateKeys := publish.StateKeys(codec.EmptyAddress, ids.Empty)
+	require.Len(stateKeys, 3)
+	chunks, ok := keys.NumChunks(publish.ABI)
+	require.True(ok)
+	require.Equal(uint16(2), chunks)
+	require.Contains(stateKeys, string(storage.ContractABIKey(publish.id, chunks)))
+
+	p := codec.NewWriter(publish.Size(), publish.Size())
+	publish.Marshal(p)
+	require.NoError(p.Err())
+	unmarshaled, err := UnmarshalPublishContract(nil)(codec.NewReader(p.Bytes(), len(p.Bytes())))
+	require.NoError(err)
+	require.Equal(publish.ABI, unmarshaled.(*Publish).ABI)
+
+	output, err := publish.Execute(ctx, nil, store, 0, codec.EmptyAddress, ids.Empty)
+	require.NoError(err)
+	contractID := output.(*Result).Value
+
+	account := codectest.NewRandomAddress()
+	contractStateManager := &storage.ContractStateManager{Mutable: store}
+	require.NoError(contractStateManager.SetAccountContract(ctx, account, contractID))
+
+	contractABI, err := storage.GetContractABIFromState(ctx, func(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+		values := make([][]byte, len(keys))
+		errs := make([]error, len(keys))
+		for i, key := range keys {
+			values[i], errs[i] = store.GetValue(ctx, key)
+		}
+		return values, errs
+	}, account)
+	require.NoError(err)
+	require.Equal([]abi.Function{{Name: "get_value", Args: []abi.Field{}, Output: "int64"}}, contractABI.Functions)
+}
+
+func TestPublishInvalidContractABI(t *testing.T) {
+	for _, contractABI := range []string{
+		"not json",
+		`{"functions":[{"name":"transfer","args":[{"name":"_to","type":"Address"}]}]}`,
+		`{"functions":[{"name":"transfer","args":[{"name":"to","type":"Address"},{"name":"to","type":"Address"}]}]}`,
+		`{"functions":[{"name":"get_value","args":[],"output":"i64"}]}`,
+	} {
+		publish := &Publish{
+			ContractBytes: []byte("contract"),
+			ABI:           []byte(contractABI),
+		}
+		_, err := publish.Execute(context.Background(), nil, chaintest.NewInMemoryStore(), 0, codec.EmptyAddress, ids.Empty)
+		require.ErrorIs(t, err, ErrInvalidContractABI, contractABI)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
+
+	mconsts "github.com/ava-labs/hypersdk/x/contracts/vm/consts"
+)
+
+const TransferAssetComputeUnits = 1
+
+var _ chain.Action = (*TransferAsset)(nil)
+
+type TransferAsset struct {
+	// To is the recipient of the [Value].
+	To codec.Address `serialize:"true" json:"to"`
+
+	// Asset is the native asset transferred.
+	Asset ids.ID `serialize:"true" json:"asset"`
+
+	// Amount of [Asset] transferred to [To].
+	Value uint64 `serialize:"true" json:"value"`
+
+	// Optional message to accompany transaction.
+	Memo []byte `serialize:"true" json:"memo"`
+}
+
+func (*TransferAsset) GetTypeID() uint8 {
+	return mconsts.TransferAssetID
+}
+
+func (t *TransferAsset) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetBalanceKey(t.Asset, actor)): state.Read | state.Write,
+		string(storage.AssetBalanceKey(t.Asset, t.To)):  state.All,
+	}
+}
+
+func (t *TransferAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	if t.Value == 0 {
+		return nil, ErrOutputValueZero
+	}
+	if len(t.Memo) > MaxMemoSize {
+		return nil, ErrOutputMemoTooLarge
+	}
+	senderBalance, err := storage.SubAssetBalance(ctx, mu, t.Asset, actor, t.Value)
+	if err != nil {
+		return nil, err
+	}
+	receiverBalance, err := storage.AddAssetBalance(ctx, mu, t.Asset, t.To, t.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferAssetResult{
+		SenderBalance:   senderBalance,
+		ReceiverBalance: receiverBalance,
+	}, nil
+}
+
+func (*TransferAsset) ComputeUnits(chain.Rules) uint64 {
+	return TransferAssetComputeUnits
+}
+
+func (*TransferAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+var _ chain.Marshaler = (*TransferAsset)(nil)
+
+func (t *TransferAsset) Size() int {
+	return codec.AddressLen + ids.IDLen + consts.Uint64Len + codec.BytesLen(t.Memo)
+}
+
+func (t *TransferAsset) Marshal(p *codec.Packer) {
+	p.PackAddress(t.To)
+	p.PackID(t.Asset)
+	p.PackLong(t.Value)
+	p.PackBytes(t.Memo)
+}
+
+func UnmarshalTransferAsset(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferAsset
+	p.UnpackAddress(&transfer.To)
+	p.UnpackID(true, &transfer.Asset)
+	transfer.Value = p.UnpackUint64(true)
+	p.UnpackBytes(MaxMemoSize, false, &transfer.Memo)
+	return &transfer, p.Err()
+}
+
+var _ codec.Typed = (*TransferAssetResult)(nil)
+
+type TransferAssetResult struct {
+	SenderBalance   uint64 `serialize:"true" json:"sender_balance"`
+	ReceiverBalance uint64 `serialize:"true" json:"receiver_balance"`
+}
+
+func (*TransferAssetResult) GetTypeID() uint8 {
+	return mconsts.TransferAssetOutputID
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/contracts/runtime"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
+
+	mconsts "github.com/ava-labs/hypersdk/x/contracts/vm/consts"
+)
+
+// AuthorizeUpgradeFunction is called on a contract with the new contract ID
+// before it is upgraded. Contracts authorize an upgrade by returning true.
+const AuthorizeUpgradeFunction = "authorize_upgrade"
+
+var (
+	_ chain.Action = (*Upgrade)(nil)
+
+	ErrUpgradeNotAuthorized = errors.New("upgrade not authorized by contract")
+)
+
+type Upgrade struct {
+	// ContractAddress is the account to upgrade
+	ContractAddress codec.Address `json:"contractAddress"`
+
+	// ContractID is the published contract that will replace the current one
+	ContractID runtime.ContractID `json:"contractID"`
+
+	// SpecifiedStateKeys are the state keys accessed by the contract when
+	// authorizing the upgrade
+	SpecifiedStateKeys []StateKeyPermission `json:"statekeys"`
+
+	// Fuel is the maximum fuel the contract may consume authorizing the upgrade
+	Fuel uint64 `json:"fuel"`
+
+	r *runtime.WasmRuntime
+}
+
+func (*Upgrade) GetTypeID() uint8 {
+	return mconsts.UpgradeID
+}
+
+func (u *Upgrade) StateKeys(_ codec.Address, _ ids.ID) state.Keys {
+	result := specifiedStateKeys(u.SpecifiedStateKeys)
+	contractKey, _ := keys.Encode(storage.AccountContractKey(u.ContractAddress), 36)
+	result.Add(string(contractKey), state.Read|state.Write)
+	immutableKey, _ := keys.Encode(storage.AccountImmutableKey(u.ContractAddress), 1)
+	result.Add(string(immutableKey), state.Read)
+	result.Add(string(u.ContractID), state.Read)
+	return result
+}
+
+func (u *Upgrade) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) (codec.Typed, error) {
+	fuelSchedule, err := runtime.GetFuelSchedule(rules)
+	if err != nil {
+		return nil, err
+	}
+	callLimits, err := runtime.GetCallLimits(rules)
+	if err != nil {
+		return nil, err
+	}
+	stateManager := newContractStateManager(mu, u.SpecifiedStateKeys)
+	params, err := runtime.Serialize(u.ContractID)
+	if err != nil {
+		return nil, err
+	}
+
+	resultBytes, err := u.r.CallContract(ctx, &runtime.CallInfo{
+		Contract:     u.ContractAddress,
+		Actor:        actor,
+		State:        stateManager,
+		FunctionName: AuthorizeUpgradeFunction,
+		Params:       params,
+		Timestamp:    uint64(timestamp),
+		Fuel:         u.Fuel,
+		FuelSchedule: fuelSchedule,
+		CallLimits:   callLimits,
+	})
+	if err != nil {
+		return nil, err
+	}
+	authorized, err := runtime.Deserialize[bool](resultBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !*authorized {
+		return nil, ErrUpgradeNotAuthorized
+	}
+
+	upgrade, err := runtime.UpgradeAccountContract(ctx, stateManager, u.ContractAddress, u.ContractID)
+	if err != nil {
+		return nil, err
+	}
+	return newUpgradeOutput(upgrade), nil
+}
+
+func (u *Upgrade) ComputeUnits(rules chain.Rules) uint64 {
+	return 1 + fuelComputeUnits(rules, u.Fuel)
+}
+
+func (u *Upgrade) Size() int {
+	return codec.AddressLen + consts.Uint64Len + codec.BytesLen(u.ContractID) + stateKeyPermissionsSize(u.SpecifiedStateKeys)
+}
+
+func (u *Upgrade) Marshal(p *codec.Packer) {
+	p.PackUint64(u.Fuel)
+	p.PackAddress(u.ContractAddress)
+	p.PackBytes(u.ContractID)
+	marshalStateKeys(p, u.SpecifiedStateKeys)
+}
+
+func UnmarshalUpgradeContract(r *runtime.WasmRuntime) func(p *codec.Packer) (chain.Action, error) {
+	return func(p *codec.Packer) (chain.Action, error) {
+		upgrade := Upgrade{r: r}
+		upgrade.Fuel = p.UnpackUint64(true)
+		p.UnpackAddress(&upgrade.ContractAddress)
+		p.UnpackBytes(36, true, (*[]byte)(&upgrade.ContractID))
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+		upgrade.SpecifiedStateKeys = unmarshalStateKeys(p, false)
+		return &upgrade, p.Err()
+	}
+}
+
+func (*Upgrade) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// UpgradeOutput records an account being upgraded to a new contract
+type UpgradeOutput struct {
+	Account            codec.Address `serialize:"true" json:"account"`
+	PreviousContractID []byte        `serialize:"true" json:"previousContractID"`
+	ContractID         []byte        `serialize:"true" json:"contractID"`
+}
+
+func (*UpgradeOutput) GetTypeID() uint8 {
+	return mconsts.UpgradeOutputID
+}
+
+func newUpgradeOutput(upgrade runtime.ContractUpgrade) *UpgradeOutput {
+	return &UpgradeOutput{
+		Account:            upgrade.Account,
+		PreviousContractID: upgrade.PreviousContractID,
+		ContractID:         upgrade.ContractID,
+	}
+}
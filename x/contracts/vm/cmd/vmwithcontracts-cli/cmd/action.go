@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/status-im/keycard-go/hexutils"
 
+	"github.com/ava-labs/hypersdk/abi/dynamic"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli/prompt"
 	"github.com/ava-labs/hypersdk/codec"
@@ -21,7 +22,9 @@ import (
 	"github.com/ava-labs/hypersdk/x/contracts/vm/vm"
 )
 
-var errUnexpectedSimulateActionsOutput = errors.New("returned output from SimulateActions was not actions.Result")
+const maxCallArgsSize = 4096
+
+var errUnexpectedActionOutput = errors.New("returned output was not actions.Result")
 
 var actionCmd = &cobra.Command{
 	Use: "action",
@@ -91,6 +94,19 @@ var publishFileCmd = &cobra.Command{
 			return err
 		}
 
+		// Select optional contract ABI
+		abiPath, err := prompt.String("abi file (optional)", 0, 1000)
+		if err != nil {
+			return err
+		}
+		var abiBytes []byte
+		if len(abiPath) > 0 {
+			abiBytes, err = os.ReadFile(abiPath)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Confirm action
 		cont, err := prompt.Continue()
 		if !cont || err != nil {
@@ -100,6 +116,7 @@ var publishFileCmd = &cobra.Command{
 		// Generate transaction
 		result, err := sendAndWait(ctx, []chain.Action{&actions.Publish{
 			ContractBytes: bytes,
+			ABI:           abiBytes,
 		}}, cli, bcli, ws, factory)
 
 		if result != nil && result.Success {
@@ -149,6 +166,20 @@ var callCmd = &cobra.Command{
 			Fuel:            uint64(1000000000),
 		}
 
+		// Encode the arguments when the contract published its ABI
+		contractABI, abiErr := cli.GetContractABI(ctx, contractAddress)
+		hasABI := abiErr == nil
+		if hasABI {
+			args, err := prompt.String("arguments (json)", 0, maxCallArgsSize)
+			if err != nil {
+				return err
+			}
+			action.CallData, err = dynamic.MarshalCallData(contractABI, function, args)
+			if err != nil {
+				return err
+			}
+		}
+
 		actionSimulationResults, err := cli.SimulateActions(ctx, chain.Actions{action}, priv.Address)
 		if err != nil {
 			return err
@@ -158,16 +189,10 @@ var callCmd = &cobra.Command{
 		}
 		actionSimulationResult := actionSimulationResults[0]
 
-		rtx := codec.NewReader(actionSimulationResult.Output, len(actionSimulationResult.Output))
-
-		simulationResultOutput, err := (*vm.OutputParser).Unmarshal(rtx)
+		simulationResult, err := unmarshalResult(actionSimulationResult.Output)
 		if err != nil {
 			return err
 		}
-		simulationResult, ok := simulationResultOutput.(*actions.Result)
-		if !ok {
-			return errUnexpectedSimulateActionsOutput
-		}
 
 		action.SpecifiedStateKeys = make([]actions.StateKeyPermission, 0, len(actionSimulationResult.StateKeys))
 		for key, value := range actionSimulationResult.StateKeys {
@@ -186,11 +211,27 @@ var callCmd = &cobra.Command{
 
 		if result != nil && result.Success {
 			utils.Outf(hexutils.BytesToHex(result.Outputs[0]) + "\n")
+			// The output is the serialized [actions.Result], which wraps the
+			// value returned by the contract
+			callResult, err := unmarshalResult(result.Outputs[0])
+			if err != nil {
+				return err
+			}
+			if hasABI {
+				output, err := dynamic.UnmarshalCallResult(contractABI, function, callResult.Value)
+				if err != nil {
+					return err
+				}
+				if len(output) > 0 {
+					utils.Outf("%s\n", output)
+				}
+				return nil
+			}
 			switch function {
 			case "balance":
 				{
 					var intValue uint64
-					err := borsh.Deserialize(&intValue, result.Outputs[0])
+					err := borsh.Deserialize(&intValue, callResult.Value)
 					if err != nil {
 						return err
 					}
@@ -199,7 +240,7 @@ var callCmd = &cobra.Command{
 			case "get_value":
 				{
 					var intValue int64
-					err := borsh.Deserialize(&intValue, result.Outputs[0])
+					err := borsh.Deserialize(&intValue, callResult.Value)
 					if err != nil {
 						return err
 					}
@@ -252,3 +293,16 @@ var deployCmd = &cobra.Command{
 		return err
 	},
 }
+
+// unmarshalResult unmarshals the [actions.Result] output of a call
+func unmarshalResult(output []byte) (*actions.Result, error) {
+	typedOutput, err := (*vm.OutputParser).Unmarshal(codec.NewReader(output, len(output)))
+	if err != nil {
+		return nil, err
+	}
+	result, ok := typedOutput.(*actions.Result)
+	if !ok {
+		return nil, errUnexpectedActionOutput
+	}
+	return result, nil
+}
@@ -5,11 +5,21 @@ package consts
 
 const (
 	// Action TypeIDs
-	TransferID     uint8 = 0
-	CallContractID uint8 = 1
-	DeployID       uint8 = 2
-	PublishID      uint8 = 3
+	TransferID      uint8 = 0
+	CallContractID  uint8 = 1
+	DeployID        uint8 = 2
+	PublishID       uint8 = 3
+	UpgradeID       uint8 = 4
+	CreateAssetID   uint8 = 5
+	MintAssetID     uint8 = 6
+	BurnAssetID     uint8 = 7
+	TransferAssetID uint8 = 8
 
-	ResultOutputID  uint8 = 0
-	AddressOutputID uint8 = 1
+	ResultOutputID        uint8 = 0
+	AddressOutputID       uint8 = 1
+	UpgradeOutputID       uint8 = 2
+	CreateAssetOutputID   uint8 = 3
+	MintAssetOutputID     uint8 = 4
+	BurnAssetOutputID     uint8 = 5
+	TransferAssetOutputID uint8 = 6
 )
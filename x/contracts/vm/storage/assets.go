@@ -0,0 +1,201 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	MaxAssetNameSize     = 64
+	MaxAssetSymbolSize   = 8
+	MaxAssetMetadataSize = 256
+
+	maxAssetSize = codec.AddressLen + 3*consts.IntLen + MaxAssetNameSize +
+		MaxAssetSymbolSize + MaxAssetMetadataSize + consts.Uint8Len + consts.Uint64Len
+)
+
+// Asset is the metadata of a native asset
+type Asset struct {
+	// Owner is the only account allowed to mint the asset
+	Owner    codec.Address `json:"owner"`
+	Name     []byte        `json:"name"`
+	Symbol   []byte        `json:"symbol"`
+	Decimals uint8         `json:"decimals"`
+	Metadata []byte        `json:"metadata"`
+	// Supply is the amount of the asset in circulation
+	Supply uint64 `json:"supply"`
+}
+
+// [assetsPrefix] + [asset]
+func AssetKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen)
+	k[0] = assetsPrefix
+	copy(k[1:], asset[:])
+	k, _ = keys.Encode(k, maxAssetSize)
+	return
+}
+
+// [assetBalancesPrefix] + [asset] + [owner]
+func AssetBalanceKey(asset ids.ID, owner codec.Address) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+codec.AddressLen)
+	k[0] = assetBalancesPrefix
+	copy(k[1:], asset[:])
+	copy(k[1+ids.IDLen:], owner[:])
+	return keys.EncodeChunks(k, BalanceChunks)
+}
+
+func SetAsset(
+	ctx context.Context,
+	mu state.Mutable,
+	assetID ids.ID,
+	asset *Asset,
+) error {
+	p := codec.NewWriter(maxAssetSize, maxAssetSize)
+	p.PackAddress(asset.Owner)
+	p.PackBytes(asset.Name)
+	p.PackBytes(asset.Symbol)
+	p.PackByte(asset.Decimals)
+	p.PackBytes(asset.Metadata)
+	p.PackUint64(asset.Supply)
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, AssetKey(assetID), p.Bytes())
+}
+
+// GetAsset returns the metadata of [assetID] or ErrAssetNotFound if it was
+// never created
+func GetAsset(
+	ctx context.Context,
+	im state.Immutable,
+	assetID ids.ID,
+) (*Asset, error) {
+	return innerGetAsset(im.GetValue(ctx, AssetKey(assetID)))
+}
+
+// Used to serve RPC queries
+func GetAssetFromState(
+	ctx context.Context,
+	f ReadState,
+	assetID ids.ID,
+) (*Asset, error) {
+	values, errs := f(ctx, [][]byte{AssetKey(assetID)})
+	return innerGetAsset(values[0], errs[0])
+}
+
+func innerGetAsset(v []byte, err error) (*Asset, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, ErrAssetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(v, maxAssetSize)
+	var asset Asset
+	p.UnpackAddress(&asset.Owner)
+	p.UnpackBytes(MaxAssetNameSize, false, &asset.Name)
+	p.UnpackBytes(MaxAssetSymbolSize, false, &asset.Symbol)
+	asset.Decimals = p.UnpackByte()
+	p.UnpackBytes(MaxAssetMetadataSize, false, &asset.Metadata)
+	asset.Supply = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func GetAssetBalance(
+	ctx context.Context,
+	im state.Immutable,
+	assetID ids.ID,
+	owner codec.Address,
+) (uint64, error) {
+	return innerGetAssetBalance(im.GetValue(ctx, AssetBalanceKey(assetID, owner)))
+}
+
+// Used to serve RPC queries
+func GetAssetBalanceFromState(
+	ctx context.Context,
+	f ReadState,
+	assetID ids.ID,
+	owner codec.Address,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{AssetBalanceKey(assetID, owner)})
+	return innerGetAssetBalance(values[0], errs[0])
+}
+
+func innerGetAssetBalance(v []byte, err error) (uint64, error) {
+	bal, _, err := innerGetBalance(v, err)
+	return bal, err
+}
+
+func AddAssetBalance(
+	ctx context.Context,
+	mu state.Mutable,
+	assetID ids.ID,
+	owner codec.Address,
+	amount uint64,
+) (uint64, error) {
+	key := AssetBalanceKey(assetID, owner)
+	bal, err := innerGetAssetBalance(mu.GetValue(ctx, key))
+	if err != nil {
+		return 0, err
+	}
+	nbal, err := smath.Add(bal, amount)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"%w: could not add asset balance (asset=%s, bal=%d, addr=%v, amount=%d)",
+			ErrInvalidBalance,
+			assetID,
+			bal,
+			owner,
+			amount,
+		)
+	}
+	return nbal, setBalance(ctx, mu, key, nbal)
+}
+
+func SubAssetBalance(
+	ctx context.Context,
+	mu state.Mutable,
+	assetID ids.ID,
+	owner codec.Address,
+	amount uint64,
+) (uint64, error) {
+	key := AssetBalanceKey(assetID, owner)
+	bal, err := innerGetAssetBalance(mu.GetValue(ctx, key))
+	if err != nil {
+		return 0, err
+	}
+	nbal, err := smath.Sub(bal, amount)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"%w: could not subtract asset balance (asset=%s, bal=%d, addr=%v, amount=%d)",
+			ErrInvalidBalance,
+			assetID,
+			bal,
+			owner,
+			amount,
+		)
+	}
+	if nbal == 0 {
+		// If there is no balance left, we should delete the record instead of
+		// setting it to 0.
+		return 0, mu.Remove(ctx, key)
+	}
+	return nbal, setBalance(ctx, mu, key, nbal)
+}
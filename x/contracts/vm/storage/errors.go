@@ -5,4 +5,11 @@ package storage
 
 import "errors"
 
-var ErrInvalidBalance = errors.New("invalid balance")
+var (
+	ErrInvalidBalance   = errors.New("invalid balance")
+	ErrUndeclaredPrefix = errors.New("prefix range not declared")
+	ErrAssetNotFound    = errors.New("asset not found")
+
+	ErrContractABITooLarge      = errors.New("contract abi too large")
+	ErrInvalidContractABIChunks = errors.New("invalid contract abi chunks")
+)
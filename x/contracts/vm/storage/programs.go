@@ -4,21 +4,30 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
 
+	"github.com/ava-labs/hypersdk/abi"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/keys"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/x/contracts/runtime"
 )
 
-// [accountStatePrefix] + [account]
-func accountStateKey(account codec.Address) (k []byte) {
+// AccountStateKey is the prefix of the state of [account]
+func AccountStateKey(account codec.Address) (k []byte) {
 	k = make([]byte, 2+codec.AddressLen)
 	k[0] = accountsPrefix
 	copy(k[1:], account[:])
@@ -34,6 +43,23 @@ func AccountContractKey(account codec.Address) (k []byte) {
 	return
 }
 
+func AccountImmutableKey(account codec.Address) (k []byte) {
+	k = make([]byte, 2+codec.AddressLen)
+	k[0] = accountsPrefix
+	copy(k[1:], account[:])
+	k[len(k)-1] = accountImmutablePrefix
+	return
+}
+
+// PrefixRangeKey is the state key declared by actions that iterate over the
+// keys beginning with [prefix]. No value is ever stored at this key.
+func PrefixRangeKey(prefix []byte) (k []byte) {
+	k = make([]byte, 1+len(prefix))
+	k[0] = prefixRangesPrefix
+	copy(k[1:], prefix)
+	return keys.EncodeChunks(k, 0)
+}
+
 func ContractsKey(id []byte) (k []byte) {
 	k = make([]byte, 1+len(id))
 	k[0] = contractsPrefix
@@ -41,6 +67,87 @@ func ContractsKey(id []byte) (k []byte) {
 	return
 }
 
+// MaxContractABISize is the maximum size of the ABI published with a contract
+const MaxContractABISize = 64 * units.KiB
+
+// ContractABIChunksKey is the key of the number of chunks of the ABI of the
+// contract stored at [contractID]
+//
+// [contractABIsPrefix] + [contractID] + [contractABIChunksPrefix]
+func ContractABIChunksKey(contractID []byte) (k []byte) {
+	k = make([]byte, 1+len(contractID)+1)
+	k[0] = contractABIsPrefix
+	copy(k[1:], contractID)
+	k[1+len(contractID)] = contractABIChunksPrefix
+	k, _ = keys.Encode(k, consts.Uint16Len)
+	return
+}
+
+// ContractABIKey is the key of the ABI of the contract stored at
+// [contractID], where [chunks] is the number of chunks of the ABI
+//
+// [contractABIsPrefix] + [contractID] + [contractABIPrefix]
+func ContractABIKey(contractID []byte, chunks uint16) (k []byte) {
+	k = make([]byte, 1+len(contractID)+1)
+	k[0] = contractABIsPrefix
+	copy(k[1:], contractID)
+	k[1+len(contractID)] = contractABIPrefix
+	return keys.EncodeChunks(k, chunks)
+}
+
+// StoreContractABI stores the JSON encoded [contractABI] of the contract
+// stored at [contractID]
+func StoreContractABI(
+	ctx context.Context,
+	mu state.Mutable,
+	contractID []byte,
+	contractABI []byte,
+) error {
+	chunks, ok := keys.NumChunks(contractABI)
+	if !ok {
+		return ErrContractABITooLarge
+	}
+	if err := mu.Insert(ctx, ContractABIChunksKey(contractID), binary.BigEndian.AppendUint16(nil, chunks)); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ContractABIKey(contractID, chunks), contractABI)
+}
+
+// GetContractABIFromState returns the ABI of the contract associated with
+// [account]
+func GetContractABIFromState(
+	ctx context.Context,
+	f ReadState,
+	account codec.Address,
+) (abi.ContractABI, error) {
+	contractKey, _ := keys.Encode(AccountContractKey(account), 36)
+	values, errs := f(ctx, [][]byte{contractKey})
+	if errs[0] != nil {
+		return abi.ContractABI{}, errs[0]
+	}
+	contractID := values[0]
+
+	values, errs = f(ctx, [][]byte{ContractABIChunksKey(contractID)})
+	if errs[0] != nil {
+		return abi.ContractABI{}, errs[0]
+	}
+	if len(values[0]) != consts.Uint16Len {
+		return abi.ContractABI{}, ErrInvalidContractABIChunks
+	}
+	chunks := binary.BigEndian.Uint16(values[0])
+
+	values, errs = f(ctx, [][]byte{ContractABIKey(contractID, chunks)})
+	if errs[0] != nil {
+		return abi.ContractABI{}, errs[0]
+	}
+
+	var contractABI abi.ContractABI
+	if err := json.Unmarshal(values[0], &contractABI); err != nil {
+		return abi.ContractABI{}, err
+	}
+	return contractABI, nil
+}
+
 func StoreContract(
 	ctx context.Context,
 	mu state.Mutable,
@@ -56,10 +163,49 @@ func GetAddressForDeploy(typeID uint8, creationData []byte) codec.Address {
 	return codec.CreateAddress(typeID, digest)
 }
 
-var _ runtime.StateManager = (*ContractStateManager)(nil)
+var (
+	_ runtime.StateManager   = (*ContractStateManager)(nil)
+	_ runtime.PrefixIterable = (*ContractStateManager)(nil)
+	_ runtime.AssetManager   = (*ContractStateManager)(nil)
+	_ runtime.PrefixIterable = (*prefixedStateMutable)(nil)
+)
 
 type ContractStateManager struct {
 	state.Mutable
+
+	// PrefixRanges are the prefixes of state that may be iterated
+	PrefixRanges [][]byte
+
+	// Keys are the declared state keys. Iterators only return keys that are
+	// declared with read permission, which keeps conflict detection sound.
+	Keys state.Keys
+}
+
+// NewIteratorWithPrefix iterates over the declared keys beginning with
+// [prefix] that exist in state. [prefix] must be within a declared prefix
+// range. The keys are fixed when the iterator is created and their values
+// are read as it advances.
+func (p *ContractStateManager) NewIteratorWithPrefix(ctx context.Context, prefix []byte) (database.Iterator, error) {
+	declared := false
+	for _, prefixRange := range p.PrefixRanges {
+		if bytes.HasPrefix(prefix, prefixRange) {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		return nil, ErrUndeclaredPrefix
+	}
+
+	stateKeys := make([]string, 0)
+	for key, permissions := range p.Keys {
+		if strings.HasPrefix(key, string(prefix)) && permissions.Has(state.Read) {
+			stateKeys = append(stateKeys, key)
+		}
+	}
+	slices.Sort(stateKeys)
+
+	return &declaredKeyIterator{ctx: ctx, state: p.Mutable, keys: stateKeys}, nil
 }
 
 func (p *ContractStateManager) GetBalance(ctx context.Context, address codec.Address) (uint64, error) {
@@ -75,8 +221,23 @@ func (p *ContractStateManager) TransferBalance(ctx context.Context, from codec.A
 	return err
 }
 
+func (p *ContractStateManager) GetAssetBalance(ctx context.Context, asset ids.ID, account codec.Address) (uint64, error) {
+	return GetAssetBalance(ctx, p, asset, account)
+}
+
+func (p *ContractStateManager) TransferAsset(ctx context.Context, asset ids.ID, from codec.Address, to codec.Address, amount uint64) error {
+	if _, err := SubAssetBalance(ctx, p, asset, from, amount); err != nil {
+		if errors.Is(err, ErrInvalidBalance) {
+			return fmt.Errorf("%w: %w", runtime.ErrInsufficientAssetBalance, err)
+		}
+		return err
+	}
+	_, err := AddAssetBalance(ctx, p, asset, to, amount)
+	return err
+}
+
 func (p *ContractStateManager) GetContractState(address codec.Address) state.Mutable {
-	return &prefixedStateMutable{prefix: accountStateKey(address), inner: p}
+	return &prefixedStateMutable{prefix: AccountStateKey(address), inner: p}
 }
 
 func (p *ContractStateManager) GetAccountContract(ctx context.Context, account codec.Address) (runtime.ContractID, error) {
@@ -110,6 +271,23 @@ func (p *ContractStateManager) SetAccountContract(ctx context.Context, account c
 	return p.Insert(ctx, key, contractID)
 }
 
+func (p *ContractStateManager) SetAccountImmutable(ctx context.Context, account codec.Address) error {
+	key, _ := keys.Encode(AccountImmutableKey(account), 1)
+	return p.Insert(ctx, key, []byte{1})
+}
+
+func (p *ContractStateManager) IsAccountImmutable(ctx context.Context, account codec.Address) (bool, error) {
+	key, _ := keys.Encode(AccountImmutableKey(account), 1)
+	_, err := p.GetValue(ctx, key)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 type prefixedStateMutable struct {
 	inner  state.Mutable
 	prefix []byte
@@ -133,3 +311,73 @@ func (s *prefixedStateMutable) Insert(ctx context.Context, key []byte, value []b
 func (s *prefixedStateMutable) Remove(ctx context.Context, key []byte) error {
 	return s.inner.Remove(ctx, s.prefixKey(key))
 }
+
+func (s *prefixedStateMutable) NewIteratorWithPrefix(ctx context.Context, prefix []byte) (database.Iterator, error) {
+	inner, ok := s.inner.(runtime.PrefixIterable)
+	if !ok {
+		return nil, runtime.ErrIterationNotSupported
+	}
+	iter, err := inner.NewIteratorWithPrefix(ctx, s.prefixKey(prefix))
+	if err != nil {
+		return nil, err
+	}
+	return &prefixedIterator{Iterator: iter, prefixLen: len(s.prefix)}, nil
+}
+
+// prefixedIterator removes the prefix of the state it iterates from its keys
+type prefixedIterator struct {
+	database.Iterator
+	prefixLen int
+}
+
+func (i *prefixedIterator) Key() []byte {
+	return i.Iterator.Key()[i.prefixLen:]
+}
+
+// declaredKeyIterator iterates over sorted declared keys that exist in
+// state. Values are read as the iterator advances, so each read is paid for
+// by the fuel charged per item.
+type declaredKeyIterator struct {
+	ctx   context.Context
+	state state.Immutable
+	keys  []string
+	key   []byte
+	value []byte
+	err   error
+}
+
+func (i *declaredKeyIterator) Next() bool {
+	i.key, i.value = nil, nil
+	for i.err == nil && len(i.keys) > 0 {
+		key := []byte(i.keys[0])
+		i.keys = i.keys[1:]
+		value, err := i.state.GetValue(i.ctx, key)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			i.err = err
+			break
+		}
+		i.key, i.value = key, value
+		return true
+	}
+	i.keys = nil
+	return false
+}
+
+func (i *declaredKeyIterator) Error() error {
+	return i.err
+}
+
+func (i *declaredKeyIterator) Key() []byte {
+	return i.key
+}
+
+func (i *declaredKeyIterator) Value() []byte {
+	return i.value
+}
+
+func (i *declaredKeyIterator) Release() {
+	i.keys, i.key, i.value = nil, nil, nil
+}
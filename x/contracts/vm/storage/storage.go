@@ -30,17 +30,35 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // 0x3/ (balance)
 //   -> [owner] => balance
 // 0x4/ (account-storage)
-// 0x4/address/0x1 (address associated contract)
+// 0x4/address/0x0 (address associated contract)
 // 0x4/address/0x1 (address associated state)
+// 0x4/address/0x2 (address contract is immutable)
 // 0x5/ (contracts-storage)
+// 0x6/ (contract-abis)
+// 0x6/contractID/0x0 (contract abi chunks)
+// 0x6/contractID/0x1 (contract abi)
+// 0x7/ (assets)
+//   -> [asset] => metadata
+// 0x8/ (asset-balances)
+//   -> [asset|owner] => balance
+// 0x9/ (prefix-ranges)
+//   -> [prefix] => never stored, locks the keys beginning with [prefix]
 
 const (
 	balancePrefix byte = metadata.DefaultMinimumPrefix + iota
 	accountsPrefix
 	contractsPrefix
+	contractABIsPrefix
+	assetsPrefix
+	assetBalancesPrefix
+	prefixRangesPrefix
 
-	accountContractPrefix = 0x0
-	accountStatePrefix    = 0x1
+	accountContractPrefix  = 0x0
+	accountStatePrefix     = 0x1
+	accountImmutablePrefix = 0x2
+
+	contractABIChunksPrefix = 0x0
+	contractABIPrefix       = 0x1
 )
 
 const BalanceChunks uint16 = 1
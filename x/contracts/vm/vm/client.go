@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
+
 	"github.com/ava-labs/hypersdk/api/jsonrpc"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
@@ -16,6 +18,7 @@ import (
 	"github.com/ava-labs/hypersdk/requester"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/x/contracts/vm/consts"
+	"github.com/ava-labs/hypersdk/x/contracts/vm/storage"
 )
 
 const balanceCheckInterval = 500 * time.Millisecond
@@ -65,6 +68,35 @@ func (cli *JSONRPCClient) Balance(ctx context.Context, addr codec.Address) (uint
 	return resp.Amount, err
 }
 
+// Asset returns the metadata of the native asset [asset]
+func (cli *JSONRPCClient) Asset(ctx context.Context, asset ids.ID) (*storage.Asset, error) {
+	resp := new(AssetReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"asset",
+		&AssetArgs{
+			Asset: asset,
+		},
+		resp,
+	)
+	return resp.Asset, err
+}
+
+// AssetBalance returns the balance of the native asset [asset] held by [addr]
+func (cli *JSONRPCClient) AssetBalance(ctx context.Context, asset ids.ID, addr codec.Address) (uint64, error) {
+	resp := new(BalanceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"assetBalance",
+		&AssetBalanceArgs{
+			Asset:   asset,
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Amount, err
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr codec.Address,
@@ -0,0 +1,119 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/genesis"
+	"github.com/ava-labs/hypersdk/x/contracts/runtime"
+)
+
+// rulesCacheSize is the number of parsed rules kept by [RuleFactory]
+const rulesCacheSize = 16
+
+var (
+	_ genesis.GenesisAndRuleFactory = (*GenesisFactory)(nil)
+	_ chain.RuleFactory             = (*RuleFactory)(nil)
+	_ chain.Rules                   = (*Rules)(nil)
+)
+
+// GenesisFactory loads the default genesis and verifies the fuel schedule and
+// call limits of its rules, so invalid values fail the chain on startup rather
+// than every contract call.
+type GenesisFactory struct{}
+
+func (GenesisFactory) Load(genesisBytes []byte, upgradeBytes []byte, networkID uint32, chainID ids.ID) (genesis.Genesis, chain.RuleFactory, error) {
+	g, ruleFactory, err := genesis.DefaultGenesisFactory{}.Load(genesisBytes, upgradeBytes, networkID, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := NewRules(ruleFactory.GetRules(0)); err != nil {
+		return nil, nil, err
+	}
+	return g, NewRuleFactory(ruleFactory), nil
+}
+
+// RuleFactory wraps a [chain.RuleFactory] and parses the fuel schedule and
+// call limits of the rules it returns, so rule upgrades can change them. Parsed
+// rules are cached by the rules returned by the wrapped factory.
+type RuleFactory struct {
+	factory chain.RuleFactory
+	rules   cache.Cacher[chain.Rules, *Rules]
+}
+
+func NewRuleFactory(factory chain.RuleFactory) *RuleFactory {
+	return &RuleFactory{
+		factory: factory,
+		rules:   &cache.LRU[chain.Rules, *Rules]{Size: rulesCacheSize},
+	}
+}
+
+// GetRules returns the rules at [t]. If their fuel schedule or call limits are
+// invalid, the rules are returned unparsed so that every contract call fails
+// with the parsing error.
+func (f *RuleFactory) GetRules(t int64) chain.Rules {
+	rules := f.factory.GetRules(t)
+	// Rules that can't be used as a map key are parsed on every call
+	cacheable := reflect.TypeOf(rules).Comparable()
+	if cacheable {
+		if parsed, ok := f.rules.Get(rules); ok {
+			return parsed
+		}
+	}
+
+	parsed, err := NewRules(rules)
+	if err != nil {
+		parsed = &Rules{Rules: rules}
+	}
+	if cacheable {
+		f.rules.Put(rules, parsed)
+	}
+	return parsed
+}
+
+// Rules are chain rules with a parsed and verified fuel schedule and call
+// limits
+type Rules struct {
+	chain.Rules
+
+	fuelSchedule *runtime.FuelSchedule
+	callLimits   *runtime.CallLimits
+}
+
+// NewRules parses the fuel schedule and call limits of [rules] and returns an
+// error if either is invalid
+func NewRules(rules chain.Rules) (*Rules, error) {
+	fuelSchedule, err := runtime.GetFuelSchedule(rules)
+	if err != nil {
+		return nil, err
+	}
+	callLimits, err := runtime.GetCallLimits(rules)
+	if err != nil {
+		return nil, err
+	}
+	return &Rules{
+		Rules:        rules,
+		fuelSchedule: fuelSchedule,
+		callLimits:   callLimits,
+	}, nil
+}
+
+// FetchCustom returns the parsed fuel schedule for [runtime.FuelScheduleKey]
+// and the parsed call limits for [runtime.CallLimitsKey], or the values of the
+// wrapped rules if they could not be parsed
+func (r *Rules) FetchCustom(key string) (any, bool) {
+	switch {
+	case key == runtime.FuelScheduleKey && r.fuelSchedule != nil:
+		return r.fuelSchedule, true
+	case key == runtime.CallLimitsKey && r.callLimits != nil:
+		return r.callLimits, true
+	default:
+		return r.Rules.FetchCustom(key)
+	}
+}
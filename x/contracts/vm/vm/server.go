@@ -4,8 +4,12 @@
 package vm
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/abi"
 	"github.com/ava-labs/hypersdk/api"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/genesis"
@@ -63,3 +67,46 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	reply.Amount = balance
 	return err
 }
+
+// getContractABI serves the ABIs published with contracts in the core GetABI
+// API
+func getContractABI(ctx context.Context, v api.VM, account codec.Address) (abi.ContractABI, error) {
+	return storage.GetContractABIFromState(ctx, v.ReadState, account)
+}
+
+type AssetArgs struct {
+	Asset ids.ID `json:"asset"`
+}
+
+type AssetReply struct {
+	Asset *storage.Asset `json:"asset"`
+}
+
+func (j *JSONRPCServer) Asset(req *http.Request, args *AssetArgs, reply *AssetReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.Asset")
+	defer span.End()
+
+	asset, err := storage.GetAssetFromState(ctx, j.vm.ReadState, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Asset = asset
+	return nil
+}
+
+type AssetBalanceArgs struct {
+	Asset   ids.ID        `json:"asset"`
+	Address codec.Address `json:"address"`
+}
+
+func (j *JSONRPCServer) AssetBalance(req *http.Request, args *AssetBalanceArgs, reply *BalanceReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "Server.AssetBalance")
+	defer span.End()
+
+	balance, err := storage.GetAssetBalanceFromState(ctx, j.vm.ReadState, args.Asset, args.Address)
+	if err != nil {
+		return err
+	}
+	reply.Amount = balance
+	return nil
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/extension/externalsubscriber"
-	"github.com/ava-labs/hypersdk/genesis"
 	"github.com/ava-labs/hypersdk/state/metadata"
 	"github.com/ava-labs/hypersdk/vm"
 	"github.com/ava-labs/hypersdk/x/contracts/runtime"
@@ -41,8 +40,13 @@ func init() {
 		// Pass nil as second argument if manual marshalling isn't needed (if in doubt, you probably don't)
 		ActionParser.Register(&actions.Transfer{}, actions.UnmarshalTransfer),
 		ActionParser.Register(&actions.Call{}, actions.UnmarshalCallContract(wasmRuntime)),
-		ActionParser.Register(&actions.Publish{}, actions.UnmarshalPublishContract),
+		ActionParser.Register(&actions.Publish{}, actions.UnmarshalPublishContract(wasmRuntime)),
 		ActionParser.Register(&actions.Deploy{}, actions.UnmarshalDeployContract),
+		ActionParser.Register(&actions.Upgrade{}, actions.UnmarshalUpgradeContract(wasmRuntime)),
+		ActionParser.Register(&actions.CreateAsset{}, actions.UnmarshalCreateAsset),
+		ActionParser.Register(&actions.MintAsset{}, actions.UnmarshalMintAsset),
+		ActionParser.Register(&actions.BurnAsset{}, actions.UnmarshalBurnAsset),
+		ActionParser.Register(&actions.TransferAsset{}, actions.UnmarshalTransferAsset),
 
 		// When registering new auth, ALWAYS make sure to append at the end.
 		AuthParser.Register(&auth.ED25519{}, auth.UnmarshalED25519),
@@ -51,6 +55,11 @@ func init() {
 
 		OutputParser.Register(&actions.Result{}, nil),
 		OutputParser.Register(&actions.AddressOutput{}, nil),
+		OutputParser.Register(&actions.UpgradeOutput{}, nil),
+		OutputParser.Register(&actions.CreateAssetResult{}, nil),
+		OutputParser.Register(&actions.MintAssetResult{}, nil),
+		OutputParser.Register(&actions.BurnAssetResult{}, nil),
+		OutputParser.Register(&actions.TransferAssetResult{}, nil),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -62,7 +71,7 @@ func New(options ...vm.Option) (*vm.VM, error) {
 	opts := append([]vm.Option{
 		indexer.With(),
 		ws.With(),
-		jsonrpc.With(),
+		jsonrpc.WithContractABIs(getContractABI),
 		With(), // Add Controller API
 		externalsubscriber.With(),
 	}, options...)
@@ -77,7 +86,7 @@ func NewWithOptions(options ...vm.Option) (*vm.VM, error) {
 	}, options...)
 	return vm.New(
 		consts.Version,
-		genesis.DefaultGenesisFactory{},
+		GenesisFactory{},
 		&storage.BalanceHandler{},
 		metadata.NewDefaultManager(),
 		ActionParser,
@@ -5,7 +5,7 @@ extern crate alloc;
 
 use crate::{
     host::{Accessor, CallContractArgs},
-    state::{Cache, Error, IntoPairs, Schema},
+    state::{self, Cache, Error, IntoPairs, PrefixIter, Schema},
     types::{Address, ContractId},
     Gas, Id,
 };
@@ -181,6 +181,23 @@ impl Context {
         self.state_cache.delete(key)
     }
 
+    /// Returns an iterator over the raw keys and values of the contract state that begin with `prefix`.
+    /// Pending changes are written to the host before the iterator is created.
+    /// Fuel is consumed for each item returned by the iterator.
+    #[inline]
+    pub fn iter_prefix(&mut self, prefix: &[u8]) -> PrefixIter {
+        self.state_cache.flush();
+        state::iter_prefix(prefix)
+    }
+
+    /// Returns an iterator over the values stored at every key of `K`.
+    /// Fuel is consumed for each item returned by the iterator.
+    #[inline]
+    pub fn iter_values<K: Schema>(&mut self) -> impl Iterator<Item = Result<K::Value, Error>> {
+        self.iter_prefix(&[K::prefix()])
+            .map(|(_, value)| borsh::from_slice(&value).map_err(|_| Error::Deserialization))
+    }
+
     /// Deploy an instance of the specified contract and returns the account of the new instance
     /// # Panics
     /// Panics if there was an issue deserializing the account
@@ -194,6 +211,20 @@ impl Context {
         borsh::from_slice(&bytes).expect("failed to deserialize the account")
     }
 
+    /// Replaces the contract of the current account with the specified contract.
+    /// Contracts must authorize the caller before upgrading.
+    /// # Errors
+    /// Returns an [`ExternalCallError`] if the account is immutable or the upgrade fails.
+    /// # Panics
+    /// Panics if there was an issue deserializing the result
+    #[inline]
+    pub fn upgrade(&mut self, contract_id: ContractId) -> Result<(), ExternalCallError> {
+        let ptr = borsh::to_vec(&contract_id).expect("failed to serialize args");
+        let bytes = self.host_accessor.upgrade(&ptr);
+
+        borsh::from_slice(&bytes).expect("failed to deserialize the result")
+    }
+
     /// Gets the remaining fuel available to this contract
     /// # Panics
     /// Panics if there was an issue deserializing the remaining fuel
@@ -230,6 +261,31 @@ impl Context {
         borsh::from_slice(&bytes).expect("failed to deserialize the result")
     }
 
+    /// Gets the balance of the native `asset` for the specified address
+    /// # Panics
+    /// Panics if there was an issue deserializing the balance
+    #[must_use]
+    #[inline]
+    pub fn get_asset_balance(&mut self, asset: Id, account: Address) -> u64 {
+        let ptr = borsh::to_vec(&(asset, account)).expect("failed to serialize args");
+        let bytes = self.host_accessor.get_asset_balance(&ptr);
+
+        borsh::from_slice(&bytes).expect("failed to deserialize the balance")
+    }
+
+    /// Transfer the native `asset` from the calling contract to the passed address
+    /// # Panics
+    /// Panics if there was an issue deserializing the result
+    /// # Errors
+    /// Errors if there are insufficient funds
+    #[inline]
+    pub fn send_asset(&self, asset: Id, to: Address, amount: u64) -> Result<(), ExternalCallError> {
+        let ptr = borsh::to_vec(&(asset, to, amount)).expect("failed to serialize args");
+        let bytes = self.host_accessor.send_asset(&ptr);
+
+        borsh::from_slice(&bytes).expect("failed to deserialize the result")
+    }
+
     /// Attempts to call a function `name` with `args` on the given contract. This method
     /// is used to call functions on external contracts.
     /// # Errors
@@ -341,6 +397,13 @@ impl Context {
     pub fn mock_set_balance(&self, account: Address, balance: u64) {
         self.host_accessor.set_balance(account, balance);
     }
+
+    /// Sets the balance of the native `asset` for the specified address
+    #[cfg(feature = "test")]
+    pub fn mock_set_asset_balance(&self, asset: Id, account: Address, balance: u64) {
+        self.host_accessor
+            .set_asset_balance(asset, account, balance);
+    }
 }
 
 /// An error that is returned from call to public functions.
@@ -357,6 +420,12 @@ pub enum ExternalCallError {
     OutOfFuel = 2,
     /// insufficient funds
     InsufficientFunds = 3,
+    /// the contract is immutable
+    ImmutableContract = 4,
+    /// the maximum call depth was exceeded
+    CallDepthExceeded = 5,
+    /// the called contract is already executing
+    ReentrantCall = 6,
 }
 
 /// Arguments for an external call.
@@ -56,13 +56,16 @@ impl BorshSerialize for CallContractArgs<'_> {
 #[cfg(feature = "test")]
 mod test_wrappers {
     use super::CallContractArgs;
-    use crate::{host::StateAccessor, Address, Gas, HostPtr};
+    use crate::{host::StateAccessor, Address, Gas, HostPtr, Id};
     use core::cell::{Cell, RefCell};
 
     pub const BALANCE_PREFIX: u8 = 0;
     pub const SEND_PREFIX: u8 = 1;
     pub const CALL_FUNCTION_PREFIX: u8 = 2;
     pub const DEPLOY_PREFIX: u8 = 3;
+    pub const UPGRADE_PREFIX: u8 = 4;
+    pub const ASSET_BALANCE_PREFIX: u8 = 5;
+    pub const SEND_ASSET_PREFIX: u8 = 6;
 
     impl StateAccessor {
         pub fn put(_args: &[u8]) {
@@ -74,6 +77,20 @@ mod test_wrappers {
             // if calling get_bytes, not found in cache
             HostPtr::null()
         }
+
+        pub fn iter_prefix(_args: &[u8]) -> HostPtr {
+            // there is no host state to iterate over
+            let id_bytes = borsh::to_vec(&0u32).expect("failed to serialize");
+            let ptr = crate::memory::alloc(id_bytes.len());
+            unsafe {
+                std::ptr::copy(id_bytes.as_ptr(), ptr.as_ptr().cast_mut(), id_bytes.len());
+            }
+            ptr
+        }
+
+        pub fn next(_args: &[u8]) -> HostPtr {
+            HostPtr::null()
+        }
     }
 
     #[derive(Clone)]
@@ -189,6 +206,70 @@ mod test_wrappers {
 
             host_ptr
         }
+
+        pub fn get_asset_balance(&self, args: &[u8]) -> HostPtr {
+            // asset balance prefix + key
+            let key = [ASSET_BALANCE_PREFIX]
+                .iter()
+                .chain(args.iter())
+                .copied()
+                .collect::<Vec<u8>>();
+
+            let host_ptr = self.state.get(&key);
+            assert!(
+                !host_ptr.is_null(),
+                "get_asset_balance not mocked. Please mock the function call."
+            );
+
+            host_ptr
+        }
+
+        pub fn set_asset_balance(&self, asset: Id, account: Address, balance: u64) {
+            let args = borsh::to_vec(&(asset, account)).expect("failed to serialize");
+            let key = [ASSET_BALANCE_PREFIX]
+                .iter()
+                .chain(args.iter())
+                .copied()
+                .collect::<Vec<u8>>();
+
+            let balance_bytes = borsh::to_vec(&balance).expect("failed to serialize");
+
+            self.state.put(&key, balance_bytes);
+        }
+
+        pub fn send_asset(&self, args: &[u8]) -> HostPtr {
+            // send asset prefix + key
+            let key = [SEND_ASSET_PREFIX]
+                .iter()
+                .chain(args.iter())
+                .copied()
+                .collect::<Vec<u8>>();
+
+            let host_ptr = self.state.get(&key);
+            assert!(
+                !host_ptr.is_null(),
+                "send_asset not mocked. Please mock the function call."
+            );
+
+            host_ptr
+        }
+
+        pub fn upgrade(&self, args: &[u8]) -> HostPtr {
+            // upgrade prefix + key
+            let key = [UPGRADE_PREFIX]
+                .iter()
+                .chain(args.iter())
+                .copied()
+                .collect::<Vec<u8>>();
+
+            let host_ptr = self.state.get(&key);
+            assert!(
+                !host_ptr.is_null(),
+                "upgrade not mocked. Please mock the function call."
+            );
+
+            host_ptr
+        }
     }
 
     impl Default for MockState {
@@ -280,6 +361,28 @@ mod external_wrappers {
 
             unsafe { get_bytes(args.as_ptr(), args.len()) }
         }
+
+        #[inline]
+        pub fn iter_prefix(args: &[u8]) -> HostPtr {
+            #[link(wasm_import_module = "state")]
+            extern "C" {
+                #[link_name = "iter_prefix"]
+                fn iter_prefix(ptr: *const u8, len: usize) -> HostPtr;
+            }
+
+            unsafe { iter_prefix(args.as_ptr(), args.len()) }
+        }
+
+        #[inline]
+        pub fn next(args: &[u8]) -> HostPtr {
+            #[link(wasm_import_module = "state")]
+            extern "C" {
+                #[link_name = "next"]
+                fn next(ptr: *const u8, len: usize) -> HostPtr;
+            }
+
+            unsafe { next(args.as_ptr(), args.len()) }
+        }
     }
 
     #[derive(Clone)]
@@ -305,6 +408,17 @@ mod external_wrappers {
             unsafe { deploy(args.as_ptr(), args.len()) }
         }
 
+        #[inline]
+        pub fn upgrade(&self, args: &[u8]) -> HostPtr {
+            #[link(wasm_import_module = "contract")]
+            extern "C" {
+                #[link_name = "upgrade"]
+                fn upgrade(ptr: *const u8, len: usize) -> HostPtr;
+            }
+
+            unsafe { upgrade(args.as_ptr(), args.len()) }
+        }
+
         #[inline]
         pub fn call_contract(&self, args: &CallContractArgs) -> HostPtr {
             #[link(wasm_import_module = "contract")]
@@ -350,5 +464,27 @@ mod external_wrappers {
 
             unsafe { send_value(args.as_ptr(), args.len()) }
         }
+
+        #[inline]
+        pub fn get_asset_balance(&self, args: &[u8]) -> HostPtr {
+            #[link(wasm_import_module = "asset")]
+            extern "C" {
+                #[link_name = "balance"]
+                fn get(ptr: *const u8, len: usize) -> HostPtr;
+            }
+
+            unsafe { get(args.as_ptr(), args.len()) }
+        }
+
+        #[inline]
+        pub fn send_asset(&self, args: &[u8]) -> HostPtr {
+            #[link(wasm_import_module = "asset")]
+            extern "C" {
+                #[link_name = "transfer"]
+                fn send_asset(ptr: *const u8, len: usize) -> HostPtr;
+            }
+
+            unsafe { send_asset(args.as_ptr(), args.len()) }
+        }
     }
 }
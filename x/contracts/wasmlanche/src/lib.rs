@@ -85,7 +85,7 @@ mod logging {
 pub use self::context::ExternalCallContext;
 pub use self::{
     context::{Context, ExternalCallArgs, ExternalCallError},
-    state::{macro_types, Error},
+    state::{macro_types, Error, PrefixIter},
     types::{Address, ContractId, Gas, Id, ID_LEN},
 };
 
@@ -97,6 +97,22 @@ pub use self::{
 
 pub use sdk_macros::{public, state_schema};
 
+/// Declares that the contract cannot be called while it is executing further
+/// up the call stack. Calls re-entering the contract, including calls it makes
+/// to itself, fail with [`ExternalCallError::ReentrantCall`].
+///
+/// ```
+/// wasmlanche::nonreentrant!();
+/// # fn main() {}
+/// ```
+#[macro_export]
+macro_rules! nonreentrant {
+    () => {
+        #[no_mangle]
+        extern "C-unwind" fn __wasmlanche_nonreentrant() {}
+    };
+}
+
 // re-exports
 pub use borsh;
 pub use bytemuck;
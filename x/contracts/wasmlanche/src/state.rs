@@ -305,6 +305,43 @@ fn get_bytes(key: &[u8]) -> Option<CacheValue> {
     }
 }
 
+/// An iterator over the raw keys and values of the contract state that begin with a prefix.
+/// Keys are returned in lexicographic order.
+pub struct PrefixIter {
+    id: u32,
+}
+
+impl Iterator for PrefixIter {
+    type Item = (CacheKey, CacheValue);
+
+    fn next(&mut self) -> Option<Self::Item> {
+        let args = borsh::to_vec(&self.id).expect("failed to serialize args");
+        let ptr = StateAccessor::next(&args);
+
+        if ptr.is_null() {
+            None
+        } else {
+            let (key, value): (Vec<u8>, CacheValue) =
+                from_slice(&ptr).expect("failed to deserialize the state entry");
+            Some((key.into(), value))
+        }
+    }
+}
+
+pub(crate) fn iter_prefix(prefix: &[u8]) -> PrefixIter {
+    #[derive(BorshSerialize)]
+    struct IterPrefixArgs<'a> {
+        prefix: &'a [u8],
+    }
+
+    let args = borsh::to_vec(&IterPrefixArgs { prefix }).expect("failed to serialize args");
+
+    let ptr = StateAccessor::iter_prefix(&args);
+    let id = from_slice(&ptr).expect("failed to deserialize the iterator");
+
+    PrefixIter { id }
+}
+
 pub trait Sealed {}
 
 pub trait IntoPairs: Sealed {
@@ -109,6 +109,10 @@ type Block struct {
 	ParentID  ids.ID `serialize:"true"`
 	Height    uint64 `serialize:"true"`
 	Timestamp int64  `serialize:"true"`
+	// PChainHeight is the P-Chain height the validator set of the block's
+	// epoch is read at. It is chosen by the first block of an epoch and
+	// copied by the rest of the blocks in the epoch.
+	PChainHeight uint64 `serialize:"true"`
 
 	ChunkCerts []*ChunkCertificate `serialize:"true"`
 
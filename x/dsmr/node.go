@@ -28,6 +28,8 @@ var (
 	ErrEmptyChunk                          = errors.New("empty chunk")
 	ErrNoAvailableChunkCerts               = errors.New("no available chunk certs")
 	ErrTimestampNotMonotonicallyIncreasing = errors.New("block timestamp must be greater than parent timestamp")
+	ErrInvalidPChainHeight                 = errors.New("invalid P-Chain height")
+	ErrTxNotAssigned                       = errors.New("tx is not assigned to this node")
 )
 
 type Validator struct {
@@ -46,12 +48,16 @@ func New[T Tx](
 	getChunkSignatureClient *p2p.Client,
 	chunkCertificateGossipClient *p2p.Client,
 	validators []Validator,
+	partition *EpochedPartition[T],
+	txBatchClient *p2p.Client,
+	mempool Mempool[T],
 ) (*Node[T], error) {
 	storage, err := newChunkStorage[T](NoVerifier[T]{}, memdb.New())
 	if err != nil {
 		return nil, err
 	}
 
+	txRouter := NewTxRouter[T](nodeID, partition, mempool, txBatchClient)
 	return &Node[T]{
 		nodeID:         nodeID,
 		networkID:      networkID,
@@ -66,6 +72,9 @@ func New[T Tx](
 		),
 		chunkCertificateGossipClient: NewChunkCertificateGossipClient(chunkCertificateGossipClient),
 		validators:                   validators,
+		partition:                    partition,
+		txRouter:                     txRouter,
+		TxHandler:                    txRouter.TxHandler,
 		GetChunkHandler: &GetChunkHandler[T]{
 			storage: storage,
 		},
@@ -93,15 +102,24 @@ type Node[T Tx] struct {
 	getChunkSignatureClient      *TypedClient[*dsmr.GetChunkSignatureRequest, *dsmr.GetChunkSignatureResponse, []byte]
 	chunkCertificateGossipClient *TypedClient[[]byte, []byte, *dsmr.ChunkCertificateGossip]
 	validators                   []Validator
+	partition                    *EpochedPartition[T]
+	txRouter                     *TxRouter[T]
 
+	TxHandler                     *TxHandler[T]
 	GetChunkHandler               *GetChunkHandler[T]
 	GetChunkSignatureHandler      *acp118.Handler
 	ChunkCertificateGossipHandler *ChunkCertificateGossipHandler[T]
 	storage                       *chunkStorage[T]
 }
 
-// BuildChunk builds transactions into a Chunk
-// TODO handle frozen sponsor + validator assignments
+// RouteTxs sends [txs] to the validators assigned to their sponsors in the
+// current epoch
+func (n *Node[T]) RouteTxs(ctx context.Context, txs []T) error {
+	return n.txRouter.RouteTxs(ctx, txs)
+}
+
+// BuildChunk builds transactions assigned to this node in the current epoch
+// into a Chunk
 func (n *Node[T]) BuildChunk(
 	ctx context.Context,
 	txs []T,
@@ -112,6 +130,17 @@ func (n *Node[T]) BuildChunk(
 		return Chunk[T]{}, ErrEmptyChunk
 	}
 
+	epoch := n.partition.Epoch(n.txRouter.clock.Time().UnixMilli())
+	for _, tx := range txs {
+		nodeID, err := n.partition.AssignTx(ctx, epoch, tx)
+		if err != nil {
+			return Chunk[T]{}, err
+		}
+		if nodeID != n.nodeID {
+			return Chunk[T]{}, fmt.Errorf("%w: %s is assigned to %s", ErrTxNotAssigned, tx.GetID(), nodeID)
+		}
+	}
+
 	chunk, err := signChunk[T](
 		UnsignedChunk[T]{
 			Producer:    n.nodeID,
@@ -172,11 +201,20 @@ func (n *Node[T]) BuildChunk(
 	return chunk, n.storage.AddLocalChunkWithCert(chunk, &chunkCert)
 }
 
-func (n *Node[T]) BuildBlock(parent Block, timestamp int64) (Block, error) {
+// BuildBlock builds a block on [parent]. If the block starts a new epoch,
+// the validator set of the epoch is read at [pChainHeight].
+func (n *Node[T]) BuildBlock(parent Block, timestamp int64, pChainHeight uint64) (Block, error) {
 	if timestamp <= parent.Timestamp {
 		return Block{}, ErrTimestampNotMonotonicallyIncreasing
 	}
 
+	if n.partition.Epoch(timestamp) == n.partition.Epoch(parent.Timestamp) {
+		pChainHeight = parent.PChainHeight
+	}
+	if pChainHeight < parent.PChainHeight {
+		return Block{}, fmt.Errorf("%w: %d is lower than parent %d", ErrInvalidPChainHeight, pChainHeight, parent.PChainHeight)
+	}
+
 	chunkCerts := n.storage.GatherChunkCerts()
 	availableChunkCerts := make([]*ChunkCertificate, 0)
 	for _, chunkCert := range chunkCerts {
@@ -192,10 +230,11 @@ func (n *Node[T]) BuildBlock(parent Block, timestamp int64) (Block, error) {
 	}
 
 	blk := Block{
-		ParentID:   parent.GetID(),
-		Height:     parent.Height + 1,
-		Timestamp:  timestamp,
-		ChunkCerts: availableChunkCerts,
+		ParentID:     parent.GetID(),
+		Height:       parent.Height + 1,
+		Timestamp:    timestamp,
+		PChainHeight: pChainHeight,
+		ChunkCerts:   availableChunkCerts,
 	}
 
 	packer := wrappers.Packer{Bytes: make([]byte, 0, InitialChunkSize), MaxSize: consts.NetworkSizeLimit}
@@ -208,7 +247,26 @@ func (n *Node[T]) BuildBlock(parent Block, timestamp int64) (Block, error) {
 	return blk, nil
 }
 
-func (*Node[T]) Execute(ctx context.Context, _ Block, block Block) error {
+func (n *Node[T]) Execute(ctx context.Context, parent Block, block Block) error {
+	if n.partition.Epoch(block.Timestamp) == n.partition.Epoch(parent.Timestamp) {
+		if block.PChainHeight != parent.PChainHeight {
+			return fmt.Errorf("%w: %d differs from parent %d in the same epoch", ErrInvalidPChainHeight, block.PChainHeight, parent.PChainHeight)
+		}
+	} else {
+		if block.PChainHeight < parent.PChainHeight {
+			return fmt.Errorf("%w: %d is lower than parent %d", ErrInvalidPChainHeight, block.PChainHeight, parent.PChainHeight)
+		}
+
+		// The validator set of the epoch must be available on every node
+		currentHeight, err := n.partition.validators.GetCurrentHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get current P-Chain height: %w", err)
+		}
+		if block.PChainHeight > currentHeight {
+			return fmt.Errorf("%w: %d is higher than current height %d", ErrInvalidPChainHeight, block.PChainHeight, currentHeight)
+		}
+	}
+
 	// TODO: Verify header fields
 	// TODO: de-duplicate chunk certificates (internal to block and across history)
 	for _, chunkCert := range block.ChunkCerts {
@@ -264,5 +322,6 @@ func (n *Node[T]) Accept(ctx context.Context, block Block) error {
 		}
 	}
 
+	n.partition.Accept(block)
 	return n.storage.SetMin(block.Timestamp, chunkIDs)
 }
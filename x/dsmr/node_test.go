@@ -29,6 +29,11 @@ var (
 	_ Verifier[tx] = (*failVerifier)(nil)
 )
 
+// newTestNodePartition returns a partition that assigns every tx to [nodeID]
+func newTestNodePartition(t *testing.T, nodeID ids.NodeID) *EpochedPartition[tx] {
+	return newTestEpochedPartition(t, Validator{NodeID: nodeID, Weight: 1})
+}
+
 // Test that chunks can be built through Node.NewChunk
 func TestNode_BuildChunk(t *testing.T) {
 	tests := []struct {
@@ -36,6 +41,7 @@ func TestNode_BuildChunk(t *testing.T) {
 		txs         []tx
 		expiry      int64
 		beneficiary codec.Address
+		notAssigned bool
 		wantErr     error
 	}{
 		{
@@ -74,6 +80,19 @@ func TestNode_BuildChunk(t *testing.T) {
 			expiry:      123,
 			beneficiary: codec.Address{123},
 		},
+		{
+			name: "tx assigned to another node",
+			txs: []tx{
+				{
+					ID:     ids.GenerateTestID(),
+					Expiry: 1,
+				},
+			},
+			expiry:      123,
+			beneficiary: codec.Address{123},
+			notAssigned: true,
+			wantErr:     ErrTxNotAssigned,
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,6 +106,10 @@ func TestNode_BuildChunk(t *testing.T) {
 			pk := bls.PublicFromSecretKey(sk)
 			signer := warp.NewSigner(sk, networkID, chainID)
 			nodeID := ids.GenerateTestNodeID()
+			partitionNodeID := nodeID
+			if tt.notAssigned {
+				partitionNodeID = ids.GenerateTestNodeID()
+			}
 			node, err := New[tx](
 				nodeID,
 				networkID,
@@ -116,6 +139,15 @@ func TestNode_BuildChunk(t *testing.T) {
 					ids.EmptyNodeID,
 				),
 				nil,
+				newTestNodePartition(t, partitionNodeID),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				newTestMempool(),
 			)
 			r.NoError(err)
 
@@ -151,8 +183,9 @@ func TestNode_GetChunk_AvailableChunk(t *testing.T) {
 	r.NoError(err)
 	pk := bls.PublicFromSecretKey(sk)
 	signer := warp.NewSigner(sk, networkID, chainID)
+	nodeID := ids.GenerateTestNodeID()
 	node, err := New[tx](
-		ids.GenerateTestNodeID(),
+		nodeID,
 		networkID,
 		chainID,
 		pk,
@@ -180,6 +213,15 @@ func TestNode_GetChunk_AvailableChunk(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		nil,
+		newTestNodePartition(t, nodeID),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 
@@ -198,6 +240,7 @@ func TestNode_GetChunk_AvailableChunk(t *testing.T) {
 			Timestamp: 1,
 		},
 		2,
+		0,
 	)
 	r.NoError(err)
 	r.NoError(node.Accept(context.Background(), blk))
@@ -241,8 +284,9 @@ func TestNode_GetChunk_PendingChunk(t *testing.T) {
 	r.NoError(err)
 	pk := bls.PublicFromSecretKey(sk)
 	signer := warp.NewSigner(sk, networkID, chainID)
+	nodeID := ids.GenerateTestNodeID()
 	node, err := New[tx](
-		ids.GenerateTestNodeID(),
+		nodeID,
 		networkID,
 		chainID,
 		pk,
@@ -270,6 +314,15 @@ func TestNode_GetChunk_PendingChunk(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		nil,
+		newTestNodePartition(t, nodeID),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 
@@ -318,8 +371,9 @@ func TestNode_GetChunk_UnknownChunk(t *testing.T) {
 	r.NoError(err)
 	pk := bls.PublicFromSecretKey(sk)
 	signer := warp.NewSigner(sk, networkID, chainID)
+	nodeID := ids.GenerateTestNodeID()
 	node, err := New[tx](
-		ids.GenerateTestNodeID(),
+		nodeID,
 		networkID,
 		chainID,
 		pk,
@@ -347,6 +401,15 @@ func TestNode_GetChunk_UnknownChunk(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		nil,
+		newTestNodePartition(t, nodeID),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 
@@ -560,6 +623,15 @@ func TestNode_BuiltChunksAvailableOverGetChunk(t *testing.T) {
 					ids.EmptyNodeID,
 				),
 				nil,
+				newTestNodePartition(t, nodeID),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				newTestMempool(),
 			)
 			r.NoError(err)
 
@@ -584,6 +656,7 @@ func TestNode_BuiltChunksAvailableOverGetChunk(t *testing.T) {
 					Timestamp: 1,
 				},
 				2,
+				0,
 			)
 			r.NoError(err)
 			r.NoError(node.Accept(context.Background(), block))
@@ -663,8 +736,9 @@ func TestNode_GetChunkSignature_SignValidChunk(t *testing.T) {
 			pk1 := bls.PublicFromSecretKey(sk1)
 			signer1 := warp.NewSigner(sk1, networkID, chainID)
 
+			nodeID := ids.GenerateTestNodeID()
 			node1, err := New[tx](
-				ids.GenerateTestNodeID(),
+				nodeID,
 				networkID,
 				chainID,
 				pk1,
@@ -692,6 +766,15 @@ func TestNode_GetChunkSignature_SignValidChunk(t *testing.T) {
 					ids.EmptyNodeID,
 				),
 				nil,
+				newTestNodePartition(t, nodeID),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				newTestMempool(),
 			)
 			r.NoError(err)
 
@@ -711,8 +794,9 @@ func TestNode_GetChunkSignature_SignValidChunk(t *testing.T) {
 			r.NoError(err)
 			pk2 := bls.PublicFromSecretKey(sk2)
 			signer2 := warp.NewSigner(sk2, networkID, chainID)
+			nodeID2 := ids.GenerateTestNodeID()
 			node2, err := New[tx](
-				ids.GenerateTestNodeID(),
+				nodeID2,
 				networkID,
 				chainID,
 				pk2,
@@ -740,6 +824,15 @@ func TestNode_GetChunkSignature_SignValidChunk(t *testing.T) {
 					ids.EmptyNodeID,
 				),
 				nil,
+				newTestNodePartition(t, nodeID2),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				newTestMempool(),
 			)
 			r.NoError(err)
 			chunk, err := node2.BuildChunk(
@@ -858,6 +951,15 @@ func TestNode_GetChunkSignature_DuplicateChunk(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		nil,
+		newTestNodePartition(t, ids.EmptyNodeID),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 
@@ -888,6 +990,7 @@ func TestNode_GetChunkSignature_DuplicateChunk(t *testing.T) {
 			Timestamp: 1,
 		},
 		2,
+		0,
 	)
 	r.NoError(err)
 	r.NoError(node.Accept(context.Background(), blk))
@@ -951,6 +1054,15 @@ func TestGetChunkSignature_PersistAttestedBlocks(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		nil,
+		newTestNodePartition(t, ids.EmptyNodeID),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 
@@ -987,6 +1099,15 @@ func TestGetChunkSignature_PersistAttestedBlocks(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		[]Validator{{NodeID: node1.nodeID}},
+		newTestNodePartition(t, ids.EmptyNodeID),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 
@@ -1009,6 +1130,7 @@ func TestGetChunkSignature_PersistAttestedBlocks(t *testing.T) {
 				Timestamp: 0,
 			},
 			1,
+			0,
 		)
 		if err == nil {
 			break
@@ -1287,6 +1409,15 @@ func TestNode_NewBlock_IncludesChunkCerts(t *testing.T) {
 					ids.EmptyNodeID,
 				),
 				nil,
+				newTestNodePartition(t, ids.EmptyNodeID),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				newTestMempool(),
 			)
 			r.NoError(err)
 
@@ -1308,7 +1439,7 @@ func TestNode_NewBlock_IncludesChunkCerts(t *testing.T) {
 				wantChunks = append(wantChunks, chunk)
 			}
 
-			blk, err := node.BuildBlock(tt.parent, tt.timestamp)
+			blk, err := node.BuildBlock(tt.parent, tt.timestamp, 0)
 			r.ErrorIs(err, tt.wantErr)
 			if err != nil {
 				return
@@ -1333,6 +1464,133 @@ func TestNode_NewBlock_IncludesChunkCerts(t *testing.T) {
 	}
 }
 
+// Blocks should keep the P-Chain height of their epoch
+func TestNode_BlockPChainHeight(t *testing.T) {
+	parent := Block{
+		ParentID:     ids.GenerateTestID(),
+		Height:       1,
+		Timestamp:    1,
+		PChainHeight: 5,
+	}
+
+	tests := []struct {
+		name             string
+		timestamp        int64
+		pChainHeight     uint64
+		wantPChainHeight uint64
+		wantErr          error
+		wantExecuteErr   error
+	}{
+		{
+			name:             "same epoch uses parent height",
+			timestamp:        2,
+			pChainHeight:     10,
+			wantPChainHeight: 5,
+		},
+		{
+			name:             "new epoch uses provided height",
+			timestamp:        1_000,
+			pChainHeight:     10,
+			wantPChainHeight: 10,
+		},
+		{
+			name:         "new epoch with lower height",
+			timestamp:    1_000,
+			pChainHeight: 4,
+			wantErr:      ErrInvalidPChainHeight,
+		},
+		{
+			name:             "new epoch with height above current height",
+			timestamp:        1_000,
+			pChainHeight:     11,
+			wantPChainHeight: 11,
+			wantExecuteErr:   ErrInvalidPChainHeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			networkID := uint32(123)
+			chainID := ids.Empty
+			sk, err := bls.NewSecretKey()
+			r.NoError(err)
+			pk := bls.PublicFromSecretKey(sk)
+			signer := warp.NewSigner(sk, networkID, chainID)
+			nodeID := ids.GenerateTestNodeID()
+			node, err := New[tx](
+				nodeID,
+				networkID,
+				chainID,
+				pk,
+				signer,
+				NoVerifier[tx]{},
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				nil,
+				newTestNodePartition(t, nodeID),
+				p2ptest.NewClient(
+					t,
+					context.Background(),
+					&p2p.NoOpHandler{},
+					ids.EmptyNodeID,
+					ids.EmptyNodeID,
+				),
+				newTestMempool(),
+			)
+			r.NoError(err)
+
+			_, err = node.BuildChunk(
+				context.Background(),
+				[]tx{{ID: ids.GenerateTestID(), Expiry: 1}},
+				2_000,
+				codec.Address{123},
+			)
+			r.NoError(err)
+
+			blk, err := node.BuildBlock(parent, tt.timestamp, tt.pChainHeight)
+			r.ErrorIs(err, tt.wantErr)
+			if err != nil {
+				// Blocks built by other nodes are verified the same way
+				blk = Block{
+					ParentID:     parent.GetID(),
+					Height:       parent.Height + 1,
+					Timestamp:    tt.timestamp,
+					PChainHeight: tt.pChainHeight,
+				}
+				r.ErrorIs(node.Execute(context.Background(), parent, blk), ErrInvalidPChainHeight)
+				return
+			}
+			r.Equal(tt.wantPChainHeight, blk.PChainHeight)
+			r.ErrorIs(node.Execute(context.Background(), parent, blk), tt.wantExecuteErr)
+
+			// The P-Chain height cannot decrease
+			blk.PChainHeight = parent.PChainHeight - 1
+			r.ErrorIs(node.Execute(context.Background(), parent, blk), ErrInvalidPChainHeight)
+		})
+	}
+}
+
 // Nodes should request chunks referenced in accepted blocks
 func TestAccept_RequestReferencedChunks(t *testing.T) {
 	r := require.New(t)
@@ -1344,8 +1602,9 @@ func TestAccept_RequestReferencedChunks(t *testing.T) {
 	pk1 := bls.PublicFromSecretKey(sk1)
 	signer1 := warp.NewSigner(sk1, networkID, chainID)
 	r.NoError(err)
+	nodeID := ids.GenerateTestNodeID()
 	node1, err := New[tx](
-		ids.GenerateTestNodeID(),
+		nodeID,
 		networkID,
 		chainID,
 		pk1,
@@ -1373,6 +1632,15 @@ func TestAccept_RequestReferencedChunks(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		nil,
+		newTestNodePartition(t, nodeID),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 
@@ -1387,7 +1655,7 @@ func TestAccept_RequestReferencedChunks(t *testing.T) {
 		ParentID:  ids.GenerateTestID(),
 		Height:    0,
 		Timestamp: 0,
-	}, 1)
+	}, 1, 0)
 	r.NoError(err)
 	r.NoError(node1.Accept(context.Background(), blk))
 
@@ -1395,8 +1663,9 @@ func TestAccept_RequestReferencedChunks(t *testing.T) {
 	r.NoError(err)
 	pk2 := bls.PublicFromSecretKey(sk2)
 	signer2 := warp.NewSigner(sk2, networkID, chainID)
+	nodeID2 := ids.GenerateTestNodeID()
 	node2, err := New[tx](
-		ids.GenerateTestNodeID(),
+		nodeID2,
 		networkID,
 		chainID,
 		pk2,
@@ -1424,6 +1693,15 @@ func TestAccept_RequestReferencedChunks(t *testing.T) {
 			ids.EmptyNodeID,
 		),
 		[]Validator{{NodeID: node1.nodeID}},
+		newTestNodePartition(t, nodeID2),
+		p2ptest.NewClient(
+			t,
+			context.Background(),
+			&p2p.NoOpHandler{},
+			ids.EmptyNodeID,
+			ids.EmptyNodeID,
+		),
+		newTestMempool(),
 	)
 	r.NoError(err)
 	r.NoError(node2.Accept(context.Background(), blk))
@@ -39,6 +39,16 @@ var (
 		Message: "invalid chunk",
 	}
 
+	ErrWrongPartition = &common.AppError{
+		Code:    4,
+		Message: "tx is assigned to a different validator",
+	}
+
+	ErrInvalidEpoch = &common.AppError{
+		Code:    5,
+		Message: "invalid epoch",
+	}
+
 	_ acp118.Verifier = (*ChunkSignatureRequestVerifier[Tx])(nil)
 	_ p2p.Handler     = (*GetChunkHandler[Tx])(nil)
 	_ p2p.Handler     = (*ChunkCertificateGossipHandler[Tx])(nil)
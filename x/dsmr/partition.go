@@ -5,8 +5,12 @@ package dsmr
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
@@ -15,6 +19,20 @@ import (
 	"github.com/ava-labs/hypersdk/consts"
 )
 
+var (
+	ErrInvalidEpochDuration = errors.New("epoch duration must be positive")
+	ErrNoValidators         = errors.New("no validators with non-zero weight")
+	ErrUnknownEpoch         = errors.New("unknown epoch")
+)
+
+// ValidatorSetProvider returns the validator set used to partition sponsors
+// at a P-Chain height
+type ValidatorSetProvider interface {
+	// GetCurrentHeight returns the P-Chain height this node has accepted
+	GetCurrentHeight(ctx context.Context) (uint64, error)
+	GetValidatorSet(ctx context.Context, pChainHeight uint64) ([]Validator, error)
+}
+
 type weightedValidator struct {
 	weight uint64
 	nodeID ids.NodeID
@@ -58,6 +76,10 @@ func calculateSponsorWeightIndex(sponsor codec.Address, totalWeight uint64) uint
 }
 
 func (p *Partition[T]) AssignTx(tx T) (ids.NodeID, bool) {
+	if p.totalWeight == 0 {
+		return ids.EmptyNodeID, false
+	}
+
 	sponsor := tx.GetSponsor()
 	sponsorWeightIndex := calculateSponsorWeightIndex(sponsor, p.totalWeight)
 	nodeIDIndex := sort.Search(len(p.validators), func(i int) bool {
@@ -66,3 +88,154 @@ func (p *Partition[T]) AssignTx(tx T) (ids.NodeID, bool) {
 
 	return p.validators[nodeIDIndex].nodeID, true
 }
+
+// EpochedPartition assigns sponsors to validators using the validator set of
+// the epoch a timestamp falls in.
+//
+// The validator set of an epoch is read at the P-Chain height recorded in the
+// accepted blocks of the epoch (see [Block.PChainHeight]), so every node
+// computes the same partition for an epoch and validator set changes on the
+// P-Chain cannot move a sponsor between validators mid-epoch. Until a block
+// of an epoch is accepted, the partition of the latest prior epoch with an
+// accepted block is used.
+type EpochedPartition[T Tx] struct {
+	epochDuration int64
+	validators    ValidatorSetProvider
+
+	lock       sync.Mutex
+	heights    map[uint64]uint64 // epoch -> P-Chain height
+	partitions map[uint64]*Partition[T]
+}
+
+func NewEpochedPartition[T Tx](
+	epochDuration int64,
+	validators ValidatorSetProvider,
+) (*EpochedPartition[T], error) {
+	if epochDuration <= 0 {
+		return nil, ErrInvalidEpochDuration
+	}
+
+	return &EpochedPartition[T]{
+		epochDuration: epochDuration,
+		validators:    validators,
+		heights:       make(map[uint64]uint64),
+		partitions:    make(map[uint64]*Partition[T]),
+	}, nil
+}
+
+// Epoch returns the epoch that [timestamp] falls in
+func (e *EpochedPartition[T]) Epoch(timestamp int64) uint64 {
+	if timestamp < 0 {
+		return 0
+	}
+	return uint64(timestamp / e.epochDuration)
+}
+
+// Accept records the P-Chain height of the epoch of [block] and drops the
+// partitions of every epoch prior to the previous one. It must be called with
+// the last accepted block on startup and with every accepted block after.
+func (e *EpochedPartition[T]) Accept(block Block) {
+	epoch := e.Epoch(block.Timestamp)
+
+	e.lock.Lock()
+	if _, ok := e.heights[epoch]; !ok {
+		e.heights[epoch] = block.PChainHeight
+	}
+	e.lock.Unlock()
+
+	// Batches are accepted from the previous epoch, so keep its partition
+	if epoch > 0 {
+		e.SetMin(epoch - 1)
+	}
+}
+
+// Partition returns the frozen partition for [epoch]
+func (e *EpochedPartition[T]) Partition(ctx context.Context, epoch uint64) (*Partition[T], error) {
+	e.lock.Lock()
+	epoch, height, partition, err := e.lookup(epoch)
+	e.lock.Unlock()
+	if err != nil || partition != nil {
+		return partition, err
+	}
+
+	// The validator set is read from the P-Chain without holding the lock
+	validators, err := e.validators.GetValidatorSet(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set for epoch %d at P-Chain height %d: %w", epoch, height, err)
+	}
+	partition = NewPartition[T](validators)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if existing, ok := e.partitions[epoch]; ok {
+		return existing, nil
+	}
+	// Don't cache the partition of an epoch pruned during the fetch
+	if _, ok := e.heights[epoch]; ok {
+		e.partitions[epoch] = partition
+	}
+	return partition, nil
+}
+
+// lookup returns the epoch and P-Chain height [epoch] is partitioned with and
+// its partition, if it was already computed. It must be called with the lock
+// held.
+func (e *EpochedPartition[T]) lookup(epoch uint64) (uint64, uint64, *Partition[T], error) {
+	// If no block of [epoch] has been accepted, use the latest epoch before it
+	// with an accepted block
+	var (
+		height uint64
+		found  bool
+	)
+	requestedEpoch := epoch
+	for heightEpoch, heightForEpoch := range e.heights {
+		if heightEpoch > requestedEpoch || (found && heightEpoch < epoch) {
+			continue
+		}
+		epoch = heightEpoch
+		height = heightForEpoch
+		found = true
+	}
+	if !found {
+		return 0, 0, nil, fmt.Errorf("%w: %d", ErrUnknownEpoch, requestedEpoch)
+	}
+	return epoch, height, e.partitions[epoch], nil
+}
+
+// AssignTx returns the validator assigned to [tx]'s sponsor during [epoch]
+func (e *EpochedPartition[T]) AssignTx(ctx context.Context, epoch uint64, tx T) (ids.NodeID, error) {
+	partition, err := e.Partition(ctx, epoch)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+
+	nodeID, ok := partition.AssignTx(tx)
+	if !ok {
+		return ids.EmptyNodeID, fmt.Errorf("%w: epoch %d", ErrNoValidators, epoch)
+	}
+	return nodeID, nil
+}
+
+// SetMin drops the partitions of every epoch prior to [epoch], except the
+// latest one if no block of [epoch] was accepted, which [epoch] falls back to
+func (e *EpochedPartition[T]) SetMin(epoch uint64) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	_, accepted := e.heights[epoch]
+	priorEpoch, foundPrior := uint64(0), false
+	for heightEpoch := range e.heights {
+		if heightEpoch < epoch && (!foundPrior || heightEpoch > priorEpoch) {
+			priorEpoch = heightEpoch
+			foundPrior = true
+		}
+	}
+	for heightEpoch := range e.heights {
+		if heightEpoch >= epoch || (!accepted && foundPrior && heightEpoch == priorEpoch) {
+			continue
+		}
+		delete(e.heights, heightEpoch)
+		delete(e.partitions, heightEpoch)
+	}
+}
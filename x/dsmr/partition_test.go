@@ -4,6 +4,7 @@
 package dsmr
 
 import (
+	"context"
 	"encoding/binary"
 	"strconv"
 	"testing"
@@ -221,3 +222,205 @@ func BenchmarkAssignTx(b *testing.B) {
 		}
 	}
 }
+
+type testValidatorSetProvider struct {
+	currentHeight uint64
+	validatorSets map[uint64][]Validator // P-Chain height -> validators
+	calls         int
+}
+
+func (t *testValidatorSetProvider) GetCurrentHeight(context.Context) (uint64, error) {
+	return t.currentHeight, nil
+}
+
+func (t *testValidatorSetProvider) GetValidatorSet(_ context.Context, pChainHeight uint64) ([]Validator, error) {
+	t.calls++
+	return t.validatorSets[pChainHeight], nil
+}
+
+func TestEpochedPartition_FreezesAssignments(t *testing.T) {
+	r := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	validatorSets := &testValidatorSetProvider{
+		validatorSets: map[uint64][]Validator{
+			5: {{NodeID: nodeID0, Weight: 100}},
+			7: {{NodeID: nodeID1, Weight: 100}},
+		},
+	}
+	partition, err := NewEpochedPartition[tx](10, validatorSets)
+	r.NoError(err)
+
+	r.Equal(uint64(0), partition.Epoch(9))
+	r.Equal(uint64(1), partition.Epoch(10))
+
+	partition.Accept(Block{Timestamp: 1, PChainHeight: 5})
+
+	tx := createTestPartitionTx(0)
+	nodeID, err := partition.AssignTx(context.Background(), 0, tx)
+	r.NoError(err)
+	r.Equal(nodeID0, nodeID)
+
+	// Validator set changes within an epoch are not observed
+	validatorSets.validatorSets[5] = []Validator{{NodeID: nodeID1, Weight: 100}}
+	nodeID, err = partition.AssignTx(context.Background(), 0, tx)
+	r.NoError(err)
+	r.Equal(nodeID0, nodeID)
+	r.Equal(1, validatorSets.calls)
+
+	// Later blocks of an epoch do not change its P-Chain height
+	partition.Accept(Block{Timestamp: 2, PChainHeight: 7})
+	nodeID, err = partition.AssignTx(context.Background(), 0, tx)
+	r.NoError(err)
+	r.Equal(nodeID0, nodeID)
+	r.Equal(1, validatorSets.calls)
+
+	partition.Accept(Block{Timestamp: 10, PChainHeight: 7})
+	nodeID, err = partition.AssignTx(context.Background(), 1, tx)
+	r.NoError(err)
+	r.Equal(nodeID1, nodeID)
+	r.Equal(2, validatorSets.calls)
+}
+
+func TestEpochedPartition_FallsBackToPriorEpoch(t *testing.T) {
+	r := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	validatorSets := &testValidatorSetProvider{
+		validatorSets: map[uint64][]Validator{
+			5: {{NodeID: nodeID, Weight: 100}},
+		},
+	}
+	partition, err := NewEpochedPartition[tx](10, validatorSets)
+	r.NoError(err)
+
+	tx := createTestPartitionTx(0)
+	_, err = partition.AssignTx(context.Background(), 0, tx)
+	r.ErrorIs(err, ErrUnknownEpoch)
+
+	partition.Accept(Block{Timestamp: 10, PChainHeight: 5})
+
+	// Epochs prior to the first accepted block are unknown
+	_, err = partition.AssignTx(context.Background(), 0, tx)
+	r.ErrorIs(err, ErrUnknownEpoch)
+
+	// Epochs without an accepted block use the latest prior epoch
+	for _, epoch := range []uint64{1, 2, 3} {
+		assigned, err := partition.AssignTx(context.Background(), epoch, tx)
+		r.NoError(err)
+		r.Equal(nodeID, assigned)
+	}
+	r.Equal(1, validatorSets.calls)
+}
+
+func TestEpochedPartition_AcceptPrunesEpochs(t *testing.T) {
+	r := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	validatorSets := &testValidatorSetProvider{
+		validatorSets: map[uint64][]Validator{
+			0: {{NodeID: nodeID, Weight: 100}},
+			1: {{NodeID: nodeID, Weight: 100}},
+			2: {{NodeID: nodeID, Weight: 100}},
+		},
+	}
+	partition, err := NewEpochedPartition[tx](10, validatorSets)
+	r.NoError(err)
+
+	tx := createTestPartitionTx(0)
+	for epoch := uint64(0); epoch < 3; epoch++ {
+		partition.Accept(Block{
+			Timestamp:    int64(epoch) * 10,
+			PChainHeight: epoch,
+		})
+		_, err := partition.AssignTx(context.Background(), epoch, tx)
+		r.NoError(err)
+	}
+
+	// The previous epoch is kept to accept batches sent across the boundary
+	_, err = partition.AssignTx(context.Background(), 1, tx)
+	r.NoError(err)
+	_, err = partition.AssignTx(context.Background(), 0, tx)
+	r.ErrorIs(err, ErrUnknownEpoch)
+
+	// The latest epoch with an accepted block is kept to fall back to
+	partition.Accept(Block{Timestamp: 50, PChainHeight: 2})
+	_, err = partition.AssignTx(context.Background(), 4, tx)
+	r.NoError(err)
+	_, err = partition.AssignTx(context.Background(), 3, tx)
+	r.NoError(err)
+	_, err = partition.AssignTx(context.Background(), 1, tx)
+	r.ErrorIs(err, ErrUnknownEpoch)
+}
+
+type blockingValidatorSetProvider struct {
+	testValidatorSetProvider
+	fetching chan struct{}
+	release  chan struct{}
+}
+
+func (b *blockingValidatorSetProvider) GetValidatorSet(ctx context.Context, pChainHeight uint64) ([]Validator, error) {
+	close(b.fetching)
+	<-b.release
+	return b.testValidatorSetProvider.GetValidatorSet(ctx, pChainHeight)
+}
+
+func TestEpochedPartition_FetchesValidatorSetWithoutLock(t *testing.T) {
+	r := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	validatorSets := &blockingValidatorSetProvider{
+		testValidatorSetProvider: testValidatorSetProvider{
+			validatorSets: map[uint64][]Validator{
+				5: {{NodeID: nodeID, Weight: 100}},
+			},
+		},
+		fetching: make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+	partition, err := NewEpochedPartition[tx](10, validatorSets)
+	r.NoError(err)
+	partition.Accept(Block{Timestamp: 1, PChainHeight: 5})
+
+	type result struct {
+		nodeID ids.NodeID
+		err    error
+	}
+	results := make(chan result)
+	go func() {
+		assigned, err := partition.AssignTx(context.Background(), 0, createTestPartitionTx(0))
+		results <- result{nodeID: assigned, err: err}
+	}()
+
+	// Blocks are accepted while the validator set is being fetched
+	<-validatorSets.fetching
+	partition.Accept(Block{Timestamp: 2, PChainHeight: 5})
+	close(validatorSets.release)
+
+	res := <-results
+	r.NoError(res.err)
+	r.Equal(nodeID, res.nodeID)
+
+	// The fetched partition is cached
+	assigned, err := partition.AssignTx(context.Background(), 0, createTestPartitionTx(0))
+	r.NoError(err)
+	r.Equal(nodeID, assigned)
+	r.Equal(1, validatorSets.calls)
+}
+
+func TestEpochedPartition_NoValidators(t *testing.T) {
+	r := require.New(t)
+
+	partition, err := NewEpochedPartition[tx](10, &testValidatorSetProvider{})
+	r.NoError(err)
+	partition.Accept(Block{})
+
+	_, err = partition.AssignTx(context.Background(), 0, createTestPartitionTx(0))
+	r.ErrorIs(err, ErrNoValidators)
+}
+
+func TestEpochedPartition_InvalidEpochDuration(t *testing.T) {
+	_, err := NewEpochedPartition[tx](0, &testValidatorSetProvider{})
+	require.ErrorIs(t, err, ErrInvalidEpochDuration)
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dsmr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// maxRouteAttempts is the number of times a batch of transactions is routed
+// before it is dropped
+const maxRouteAttempts = 3
+
+var _ p2p.Handler = (*TxHandler[Tx])(nil)
+
+// TxBatch is a batch of transactions sent to the validator assigned to their
+// sponsors during Epoch
+type TxBatch[T Tx] struct {
+	Epoch uint64 `serialize:"true"`
+	Txs   []T    `serialize:"true"`
+}
+
+type Mempool[T Tx] interface {
+	Add(ctx context.Context, txs []T)
+}
+
+// TxRouter forwards transactions to the validator assigned to their sponsor in
+// the current epoch. Transactions assigned to this node are added to the local
+// mempool.
+type TxRouter[T Tx] struct {
+	nodeID    ids.NodeID
+	partition *EpochedPartition[T]
+	mempool   Mempool[T]
+	client    *TypedClient[*TxBatch[T], []byte, []byte]
+	clock     *mockable.Clock
+
+	TxHandler *TxHandler[T]
+}
+
+func NewTxRouter[T Tx](
+	nodeID ids.NodeID,
+	partition *EpochedPartition[T],
+	mempool Mempool[T],
+	client *p2p.Client,
+) *TxRouter[T] {
+	clock := &mockable.Clock{}
+	return &TxRouter[T]{
+		nodeID:    nodeID,
+		partition: partition,
+		mempool:   mempool,
+		client:    NewTxBatchClient[T](client),
+		clock:     clock,
+		TxHandler: &TxHandler[T]{
+			nodeID:    nodeID,
+			partition: partition,
+			mempool:   mempool,
+			clock:     clock,
+		},
+	}
+}
+
+// RouteTxs sends [txs] to the validators assigned to their sponsors.
+//
+// Batches rejected by a validator (for example because it observed a
+// different epoch) are routed again with the latest partition, up to
+// [maxRouteAttempts] times, after which they are dropped.
+func (r *TxRouter[T]) RouteTxs(ctx context.Context, txs []T) error {
+	return r.routeTxs(ctx, txs, 1)
+}
+
+func (r *TxRouter[T]) routeTxs(ctx context.Context, txs []T, attempt int) error {
+	epoch := r.partition.Epoch(r.clock.Time().UnixMilli())
+	batches := make(map[ids.NodeID][]T)
+	for _, tx := range txs {
+		nodeID, err := r.partition.AssignTx(ctx, epoch, tx)
+		if err != nil {
+			return err
+		}
+
+		batches[nodeID] = append(batches[nodeID], tx)
+	}
+
+	for nodeID, batch := range batches {
+		if nodeID == r.nodeID {
+			r.mempool.Add(ctx, batch)
+			continue
+		}
+
+		onResponse := func(ctx context.Context, _ ids.NodeID, _ []byte, err error) {
+			if err == nil || attempt >= maxRouteAttempts {
+				return
+			}
+			// The batch is dropped if it cannot be routed again
+			_ = r.routeTxs(ctx, batch, attempt+1)
+		}
+		if err := r.client.AppRequest(
+			ctx,
+			nodeID,
+			&TxBatch[T]{
+				Epoch: epoch,
+				Txs:   batch,
+			},
+			onResponse,
+		); err != nil {
+			return fmt.Errorf("failed to send txs to %s: %w", nodeID, err)
+		}
+	}
+
+	return nil
+}
+
+// TxHandler accepts transactions whose sponsors are assigned to this node
+type TxHandler[T Tx] struct {
+	nodeID    ids.NodeID
+	partition *EpochedPartition[T]
+	mempool   Mempool[T]
+	clock     *mockable.Clock
+}
+
+func (*TxHandler[_]) AppGossip(context.Context, ids.NodeID, []byte) {}
+
+// AppRequest adds a batch of transactions to the mempool if every transaction
+// is assigned to this node. Batches are accepted from the current and previous
+// epochs to tolerate requests sent across an epoch boundary.
+func (t *TxHandler[T]) AppRequest(ctx context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	batch := TxBatch[T]{}
+	if err := codec.LinearCodec.UnmarshalFrom(
+		&wrappers.Packer{Bytes: requestBytes, MaxSize: MaxMessageSize},
+		&batch,
+	); err != nil {
+		return nil, &common.AppError{
+			Code:    common.ErrUndefined.Code,
+			Message: err.Error(),
+		}
+	}
+
+	epoch := t.partition.Epoch(t.clock.Time().UnixMilli())
+	if batch.Epoch > epoch || batch.Epoch+1 < epoch {
+		return nil, ErrInvalidEpoch
+	}
+
+	for _, tx := range batch.Txs {
+		nodeID, err := t.partition.AssignTx(ctx, batch.Epoch, tx)
+		if err != nil {
+			return nil, &common.AppError{
+				Code:    common.ErrUndefined.Code,
+				Message: err.Error(),
+			}
+		}
+
+		if nodeID != t.nodeID {
+			return nil, ErrWrongPartition
+		}
+	}
+
+	t.mempool.Add(ctx, batch.Txs)
+	return []byte{}, nil
+}
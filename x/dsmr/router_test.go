@@ -0,0 +1,235 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dsmr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/p2ptest"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Mempool[tx] = (*testMempool)(nil)
+
+type testMempool struct {
+	txs chan []tx
+}
+
+func newTestMempool() *testMempool {
+	return &testMempool{txs: make(chan []tx, 1)}
+}
+
+func (t *testMempool) Add(_ context.Context, txs []tx) {
+	t.txs <- txs
+}
+
+// newTestEpochedPartition returns a partition with one second epochs where
+// [validators] are the validator set of the first two epochs and the current
+// P-Chain height is 10
+func newTestEpochedPartition(t *testing.T, validators ...Validator) *EpochedPartition[tx] {
+	partition, err := NewEpochedPartition[tx](
+		time.Second.Milliseconds(),
+		&testValidatorSetProvider{
+			currentHeight: 10,
+			validatorSets: map[uint64][]Validator{
+				0: validators,
+				1: validators,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	for epoch := uint64(0); epoch < 2; epoch++ {
+		partition.Accept(Block{
+			Timestamp:    int64(epoch) * time.Second.Milliseconds(),
+			PChainHeight: epoch,
+		})
+	}
+	return partition
+}
+
+func TestTxHandler(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	otherNodeID := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name       string
+		validators []Validator
+		epoch      uint64
+		now        time.Time
+		wantErr    error
+	}{
+		{
+			name:       "assigned to node",
+			validators: []Validator{{NodeID: nodeID, Weight: 1}},
+			epoch:      1,
+			now:        time.UnixMilli(1_000),
+		},
+		{
+			name:       "assigned to node in previous epoch",
+			validators: []Validator{{NodeID: nodeID, Weight: 1}},
+			epoch:      0,
+			now:        time.UnixMilli(1_000),
+		},
+		{
+			name:       "assigned to other node",
+			validators: []Validator{{NodeID: otherNodeID, Weight: 1}},
+			epoch:      1,
+			now:        time.UnixMilli(1_000),
+			wantErr:    ErrWrongPartition,
+		},
+		{
+			name:       "stale epoch",
+			validators: []Validator{{NodeID: nodeID, Weight: 1}},
+			epoch:      0,
+			now:        time.UnixMilli(2_000),
+			wantErr:    ErrInvalidEpoch,
+		},
+		{
+			name:       "future epoch",
+			validators: []Validator{{NodeID: nodeID, Weight: 1}},
+			epoch:      2,
+			now:        time.UnixMilli(1_000),
+			wantErr:    ErrInvalidEpoch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			mempool := newTestMempool()
+			router := NewTxRouter[tx](
+				nodeID,
+				newTestEpochedPartition(t, tt.validators...),
+				mempool,
+				p2ptest.NewClient(t, context.Background(), &p2p.NoOpHandler{}, ids.EmptyNodeID, ids.EmptyNodeID),
+			)
+			router.clock.Set(tt.now)
+
+			client := NewTxBatchClient[tx](p2ptest.NewClient(
+				t,
+				context.Background(),
+				router.TxHandler,
+				ids.EmptyNodeID,
+				ids.EmptyNodeID,
+			))
+
+			txs := []tx{createTestPartitionTx(0)}
+			done := make(chan struct{})
+			onResponse := func(_ context.Context, _ ids.NodeID, _ []byte, err error) {
+				defer close(done)
+				r.ErrorIs(err, tt.wantErr)
+			}
+
+			r.NoError(client.AppRequest(
+				context.Background(),
+				ids.EmptyNodeID,
+				&TxBatch[tx]{
+					Epoch: tt.epoch,
+					Txs:   txs,
+				},
+				onResponse,
+			))
+			<-done
+
+			if tt.wantErr != nil {
+				r.Empty(mempool.txs)
+				return
+			}
+			r.Equal(txs, <-mempool.txs)
+		})
+	}
+}
+
+func TestTxRouter_RouteTxs(t *testing.T) {
+	r := require.New(t)
+
+	nodeIDs := []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+	validators := []Validator{
+		{NodeID: nodeIDs[0], Weight: 100},
+		{NodeID: nodeIDs[1], Weight: 100},
+	}
+
+	remoteMempool := newTestMempool()
+	remote := NewTxRouter[tx](
+		nodeIDs[1],
+		newTestEpochedPartition(t, validators...),
+		remoteMempool,
+		p2ptest.NewClient(t, context.Background(), &p2p.NoOpHandler{}, ids.EmptyNodeID, ids.EmptyNodeID),
+	)
+
+	localMempool := newTestMempool()
+	local := NewTxRouter[tx](
+		nodeIDs[0],
+		newTestEpochedPartition(t, validators...),
+		localMempool,
+		p2ptest.NewClient(t, context.Background(), remote.TxHandler, nodeIDs[0], nodeIDs[1]),
+	)
+
+	now := time.UnixMilli(1_000)
+	local.clock.Set(now)
+	remote.clock.Set(now)
+
+	// Find a sponsor assigned to each validator
+	partition, err := local.partition.Partition(context.Background(), local.partition.Epoch(now.UnixMilli()))
+	r.NoError(err)
+	localTxs := []tx{}
+	remoteTxs := []tx{}
+	for _, weight := range []uint64{0, 150} {
+		tx := createTestPartitionTx(weight)
+		nodeID, ok := partition.AssignTx(tx)
+		r.True(ok)
+		if nodeID == nodeIDs[0] {
+			localTxs = append(localTxs, tx)
+		} else {
+			remoteTxs = append(remoteTxs, tx)
+		}
+	}
+	r.Len(localTxs, 1)
+	r.Len(remoteTxs, 1)
+
+	r.NoError(local.RouteTxs(context.Background(), append(localTxs, remoteTxs...)))
+	r.Equal(localTxs, <-localMempool.txs)
+	r.Equal(remoteTxs, <-remoteMempool.txs)
+}
+
+type rejectingHandler struct {
+	p2p.NoOpHandler
+	requests chan struct{}
+}
+
+func (r *rejectingHandler) AppRequest(context.Context, ids.NodeID, time.Time, []byte) ([]byte, *common.AppError) {
+	r.requests <- struct{}{}
+	return nil, ErrWrongPartition
+}
+
+// Rejected batches are routed again until the attempts are exhausted
+func TestTxRouter_RouteTxsRetriesRejectedBatches(t *testing.T) {
+	r := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	remoteNodeID := ids.GenerateTestNodeID()
+	handler := &rejectingHandler{requests: make(chan struct{}, maxRouteAttempts+1)}
+	router := NewTxRouter[tx](
+		nodeID,
+		newTestEpochedPartition(t, Validator{NodeID: remoteNodeID, Weight: 1}),
+		newTestMempool(),
+		p2ptest.NewClient(t, context.Background(), handler, nodeID, remoteNodeID),
+	)
+	router.clock.Set(time.UnixMilli(1_000))
+
+	r.NoError(router.RouteTxs(context.Background(), []tx{createTestPartitionTx(0)}))
+	for i := 0; i < maxRouteAttempts; i++ {
+		<-handler.requests
+	}
+	r.Never(func() bool {
+		return len(handler.requests) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
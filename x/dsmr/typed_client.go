@@ -25,6 +25,7 @@ var (
 	_ Marshaler[*dsmr.GetChunkRequest, Chunk[Tx], []byte]                                = (*getChunkMarshaler[Tx])(nil)
 	_ Marshaler[*dsmr.GetChunkSignatureRequest, *dsmr.GetChunkSignatureResponse, []byte] = (*getChunkSignatureMarshaler)(nil)
 	_ Marshaler[[]byte, []byte, *dsmr.ChunkCertificateGossip]                            = (*chunkCertificateGossipMarshaler)(nil)
+	_ Marshaler[*TxBatch[Tx], []byte, []byte]                                            = (*txBatchMarshaler[Tx])(nil)
 )
 
 type Marshaler[T any, U any, V any] interface {
@@ -166,6 +167,25 @@ func (getChunkMarshaler[_]) MarshalGossip(bytes []byte) ([]byte, error) {
 	return bytes, nil
 }
 
+type txBatchMarshaler[T Tx] struct{}
+
+func (txBatchMarshaler[T]) MarshalRequest(batch *TxBatch[T]) ([]byte, error) {
+	packer := wrappers.Packer{MaxSize: MaxMessageSize}
+	if err := codec.LinearCodec.MarshalInto(batch, &packer); err != nil {
+		return nil, err
+	}
+
+	return packer.Bytes, nil
+}
+
+func (txBatchMarshaler[_]) UnmarshalResponse(bytes []byte) ([]byte, error) {
+	return bytes, nil
+}
+
+func (txBatchMarshaler[_]) MarshalGossip(bytes []byte) ([]byte, error) {
+	return bytes, nil
+}
+
 func NewGetChunkClient[T Tx](client *p2p.Client) *TypedClient[*dsmr.GetChunkRequest, Chunk[T], []byte] {
 	return &TypedClient[*dsmr.GetChunkRequest, Chunk[T], []byte]{
 		client:    client,
@@ -189,3 +209,10 @@ func NewChunkCertificateGossipClient(client *p2p.Client) *TypedClient[[]byte, []
 		marshaler: chunkCertificateGossipMarshaler{},
 	}
 }
+
+func NewTxBatchClient[T Tx](client *p2p.Client) *TypedClient[*TxBatch[T], []byte, []byte] {
+	return &TypedClient[*TxBatch[T], []byte, []byte]{
+		client:    client,
+		marshaler: txBatchMarshaler[T]{},
+	}
+}
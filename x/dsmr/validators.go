@@ -0,0 +1,48 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dsmr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+var _ ValidatorSetProvider = (*PChainValidatorSetProvider)(nil)
+
+// PChainValidatorSetProvider provides the validator set of a subnet from the
+// P-Chain
+type PChainValidatorSetProvider struct {
+	state    validators.State
+	subnetID ids.ID
+}
+
+func NewPChainValidatorSetProvider(state validators.State, subnetID ids.ID) *PChainValidatorSetProvider {
+	return &PChainValidatorSetProvider{
+		state:    state,
+		subnetID: subnetID,
+	}
+}
+
+func (p *PChainValidatorSetProvider) GetCurrentHeight(ctx context.Context) (uint64, error) {
+	return p.state.GetCurrentHeight(ctx)
+}
+
+func (p *PChainValidatorSetProvider) GetValidatorSet(ctx context.Context, height uint64) ([]Validator, error) {
+	validatorSet, err := p.state.GetValidatorSet(ctx, height, p.subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set at P-Chain height %d: %w", height, err)
+	}
+
+	result := make([]Validator, 0, len(validatorSet))
+	for nodeID, validator := range validatorSet {
+		result = append(result, Validator{
+			NodeID: nodeID,
+			Weight: validator.Weight,
+		})
+	}
+	return result, nil
+}